@@ -99,4 +99,22 @@ CREATE TABLE restock_order_items (
     unit_cost NUMERIC(10,2) NOT NULL
 );
 
+ALTER TABLE grocery_order_items
+ADD COLUMN picked_quantity INT,
+ADD COLUMN short_quantity INT NOT NULL DEFAULT 0;
+
+ALTER TABLE restock_order_items
+ADD COLUMN picked_quantity INT,
+ADD COLUMN short_quantity INT NOT NULL DEFAULT 0;
+
+ALTER TABLE grocery_orders ADD COLUMN failure_reason TEXT;
+ALTER TABLE restock_orders ADD COLUMN failure_reason TEXT;
+
+ALTER TABLE grocery_orders ADD COLUMN failure_code TEXT;
+ALTER TABLE restock_orders ADD COLUMN failure_code TEXT;
+
+ALTER TABLE grocery_orders ADD COLUMN quote_id TEXT;
+ALTER TABLE grocery_orders ADD COLUMN quote_expires_at TIMESTAMPTZ;
+ALTER TABLE grocery_orders ADD COLUMN quoted_total NUMERIC(10, 2);
+
 RESET ROLE;
@@ -17,4 +17,182 @@ CREATE INDEX idx_aisle_type ON available_stock(aisle_type);
 ALTER TABLE available_stock
 ADD COLUMN unit_cost NUMERIC(10, 2) DEFAULT 0.00;
 
+CREATE TABLE webhook_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    order_id TEXT NOT NULL,
+    order_type TEXT NOT NULL,
+    target_url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_outbox_due ON webhook_outbox(status, next_attempt_at);
+
+CREATE TABLE stock_lots (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    restock_order_id TEXT,
+
+    quantity INT NOT NULL DEFAULT 0,
+    received_quantity INT NOT NULL DEFAULT 0,
+    unit_cost NUMERIC(10, 2) NOT NULL DEFAULT 0.00,
+
+    mfd_date TIMESTAMP,
+    expiry_date TIMESTAMP,
+
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_lots_fefo ON stock_lots(sku, expiry_date) WHERE quantity > 0;
+
+CREATE TABLE lot_reservations (
+    order_id TEXT NOT NULL,
+    lot_id BIGINT NOT NULL REFERENCES stock_lots(id),
+    quantity INT NOT NULL,
+    PRIMARY KEY (order_id, lot_id)
+);
+
+CREATE TABLE stock_waste (
+    id BIGSERIAL PRIMARY KEY,
+    lot_id BIGINT NOT NULL REFERENCES stock_lots(id),
+    sku TEXT NOT NULL,
+    aisle_type TEXT NOT NULL,
+
+    quantity INT NOT NULL,
+    unit_cost NUMERIC(10, 2) NOT NULL DEFAULT 0.00,
+    expiry_date TIMESTAMP,
+
+    written_off_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_waste_written_off ON stock_waste(written_off_at, aisle_type);
+
+CREATE TABLE inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL,
+    delta INT NOT NULL,
+    reason TEXT NOT NULL,
+    order_id TEXT,
+    balance_after INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_inventory_movements_sku_time ON inventory_movements(sku, created_at);
+
+CREATE FUNCTION reject_inventory_movement_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER inventory_movements_append_only
+BEFORE UPDATE OR DELETE ON inventory_movements
+FOR EACH ROW EXECUTE FUNCTION reject_inventory_movement_change();
+
+CREATE TABLE reservations (
+    order_id TEXT PRIMARY KEY,
+    status TEXT NOT NULL DEFAULT 'HELD', -- HELD, COMMITTED, RELEASED, EXPIRED
+    expires_at TIMESTAMP NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reservations_held_expiry ON reservations(expires_at) WHERE status = 'HELD';
+
+-- What each order's reserve asked for and got, so a repeated ReserveItems replays the same result.
+CREATE TABLE reservation_items (
+    order_id TEXT NOT NULL REFERENCES reservations(order_id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    requested INT NOT NULL CHECK (requested > 0),
+    reserved INT NOT NULL CHECK (reserved >= 0),
+
+    PRIMARY KEY (order_id, sku)
+);
+
+-- Sellable stock per sku when the reserve ran, so rejected and partial reserves can report shortfalls.
+-- A rejected all-or-nothing reserve takes nothing and leaves its reservation with status REJECTED.
+ALTER TABLE reservation_items ADD COLUMN available INT NOT NULL DEFAULT 0;
+
+-- Per-sku restock thresholds. A reorder_point of 0 turns low-stock alerts off for the sku.
+ALTER TABLE available_stock
+    ADD COLUMN reorder_point INT NOT NULL DEFAULT 0 CHECK (reorder_point >= 0),
+    ADD COLUMN target_level INT NOT NULL DEFAULT 0 CHECK (target_level >= 0);
+
+-- One row each time a reserve takes a sku from at or above its reorder point to below it.
+CREATE TABLE low_stock_events (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    order_id TEXT,
+    quantity INT NOT NULL, -- stock left after the reserve
+    reorder_point INT NOT NULL,
+    target_level INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_low_stock_events_sku_created ON low_stock_events(sku, created_at);
+
+-- Manual stock corrections after a shelf count. Adjustments above the approval threshold wait in
+-- PENDING_APPROVAL until a second person approves (APPLIED) or rejects (REJECTED) them.
+CREATE TABLE stock_adjustments (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    reason TEXT NOT NULL, -- CYCLE_COUNT, DAMAGE, THEFT, FOUND
+    delta INT NOT NULL,
+    counted_quantity INT, -- the shelf count, for CYCLE_COUNT
+    status TEXT NOT NULL, -- PENDING_APPROVAL, APPLIED, REJECTED
+    requested_by TEXT NOT NULL,
+    decided_by TEXT,
+    note TEXT NOT NULL DEFAULT '',
+    quantity_after INT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP
+);
+
+CREATE INDEX idx_stock_adjustments_pending ON stock_adjustments(created_at) WHERE status = 'PENDING_APPROVAL';
+
+-- Durable state of every dispatched order, so finalization survives a redis flush or an inventory restart.
+-- stage: DISPATCHED -> PICKING (first robot report) -> BILLING (finalization claimed) -> NOTIFIED (ordering
+-- webhook in the outbox) -> DONE. Inventory resumes every workflow that is not DONE on startup.
+CREATE TABLE fulfillment_workflows (
+    order_id TEXT NOT NULL,
+    order_type TEXT NOT NULL, -- CUSTOMER, RESTOCK
+    stage TEXT NOT NULL,
+    items JSONB NOT NULL, -- sku -> quantity for CUSTOMER, restock manifest lines for RESTOCK
+    robot_items JSONB NOT NULL, -- sku -> aisle task as dispatched to robots
+    expected_reports INT NOT NULL,
+    reports INT NOT NULL DEFAULT 0, -- counted robot reports
+    picked JSONB NOT NULL DEFAULT '{}', -- sku -> robot-reported quantity
+    reporters TEXT[] NOT NULL DEFAULT '{}',
+    fail_code TEXT, -- set when the order is failed instead of billed
+    fail_reason TEXT,
+    stock_settled BOOLEAN NOT NULL DEFAULT FALSE, -- stock effects of BILLING already applied
+
+    dispatched_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_type, order_id)
+);
+
+CREATE INDEX idx_fulfillment_workflows_open ON fulfillment_workflows(dispatched_at) WHERE stage <> 'DONE';
+
+-- The pricing quote a customer order was confirmed with; billing uses its locked prices.
+ALTER TABLE fulfillment_workflows ADD COLUMN quote_id TEXT;
+
+-- Settlement retries. A BILLING workflow whose settlement (stock, bill, webhook enqueue) fails stays in
+-- BILLING and is retried at next_settle_at; after too many attempts it moves to DEAD for an operator.
+ALTER TABLE fulfillment_workflows
+    ADD COLUMN settle_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN next_settle_at TIMESTAMPTZ,
+    ADD COLUMN last_error TEXT;
+
+CREATE INDEX idx_fulfillment_workflows_billing ON fulfillment_workflows(updated_at) WHERE stage = 'BILLING';
+
 RESET ROLE;
@@ -22,4 +22,35 @@ SELECT sku, unit_price FROM catalog;
 DROP TABLE catalog;
 ALTER TABLE catalog_new RENAME TO catalog;
 
+-- Every price a sku has had. The open period (effective_to IS NULL) is the current price,
+-- which catalog.unit_price mirrors.
+CREATE TABLE price_history (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL,
+    unit_price NUMERIC(10, 2) NOT NULL,
+    source TEXT NOT NULL, -- STOCK_METRICS, MANUAL, PROMO
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to TIMESTAMPTZ,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE UNIQUE INDEX idx_price_history_open ON price_history(sku) WHERE effective_to IS NULL;
+CREATE INDEX idx_price_history_sku_from ON price_history(sku, effective_from DESC);
+
+-- Prices locked at order preview. An order is billed at its quote's prices no matter how the
+-- catalog moves while it is picked.
+CREATE TABLE price_quotes (
+    quote_id TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE price_quote_lines (
+    quote_id TEXT NOT NULL REFERENCES price_quotes(quote_id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(10, 2) NOT NULL,
+    PRIMARY KEY (quote_id, sku)
+);
+
 RESET ROLE;
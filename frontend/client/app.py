@@ -3,6 +3,7 @@ import requests
 import time
 import json
 from pathlib import Path
+from urllib.parse import urlencode
 
 try:
     from streamlit_autorefresh import st_autorefresh
@@ -71,6 +72,9 @@ if 'device_id' not in st.session_state: st.session_state.device_id = None
 if 'cart_items' not in st.session_state: st.session_state.cart_items = [{"sku": "", "qty": 1}]
 if 'order_id' not in st.session_state: st.session_state.order_id = None
 if 'confirmed_items' not in st.session_state: st.session_state.confirmed_items = {}
+if 'suggested_items' not in st.session_state: st.session_state.suggested_items = []
+if 'catalog_items' not in st.session_state: st.session_state.catalog_items = None
+if 'catalog_next_token' not in st.session_state: st.session_state.catalog_next_token = ""
 if 'auth_restored' not in st.session_state: st.session_state.auth_restored = False
 if 'last_refresh_ts' not in st.session_state: st.session_state.last_refresh_ts = 0.0
 
@@ -235,6 +239,56 @@ else:
         clear_auth_cache()
         st.rerun()
 
+    st.header("🗂️ Catalog")
+    f1, f2, f3, f4 = st.columns([2, 2, 1, 1])
+    catalog_q = f1.text_input("NAME STARTS WITH", key="catalog_q")
+    catalog_aisle = f2.text_input("AISLE", key="catalog_aisle", placeholder="e.g. Produce")
+    catalog_expiring = f3.number_input("EXPIRING IN (DAYS)", min_value=0, value=0, key="catalog_expiring")
+    catalog_in_stock = f4.checkbox("IN STOCK ONLY", value=True, key="catalog_in_stock")
+    c1, c2, _ = st.columns([1, 1, 2])
+    browse_first = c1.button("BROWSE CATALOG")
+    browse_next = c2.button("NEXT PAGE ▶", disabled=not st.session_state.catalog_next_token)
+    if browse_first or browse_next:
+        params = {"in_stock": str(catalog_in_stock).lower(), "page_size": 25}
+        if catalog_q:
+            params["q"] = catalog_q
+        if catalog_aisle:
+            params["aisle"] = catalog_aisle
+        if catalog_expiring:
+            params["expiring_within_days"] = int(catalog_expiring)
+        if browse_next:
+            params["page_token"] = st.session_state.catalog_next_token
+        try:
+            catalog_res = auth_request("GET", f"/api/client/catalog?{urlencode(params)}")
+            if catalog_res is None:
+                st.error("Catalog Error: Backend unreachable.")
+            elif catalog_res.status_code == 200:
+                catalog_data = catalog_res.json().get("data", {})
+                st.session_state.catalog_items = catalog_data.get("items", [])
+                st.session_state.catalog_next_token = catalog_data.get("next_page_token", "")
+                print(f"[client-ui] catalog items={len(st.session_state.catalog_items)} next={st.session_state.catalog_next_token}")
+            else:
+                st.error(f"Catalog fetch failed: {catalog_res.text}")
+        except Exception as e:
+            st.error(f"Catalog Error: {str(e)}")
+    if st.session_state.catalog_items:
+        st.dataframe(
+            [
+                {
+                    "SKU": i["sku"],
+                    "NAME": i["name"],
+                    "AISLE": i["aisle_type"],
+                    "IN STOCK": i["quantity"],
+                    "PRICE": f"${i['unit_price']:.2f}" if i.get("priced") else "-",
+                    "EXPIRES": (i.get("expiry_date") or "")[:10],
+                }
+                for i in st.session_state.catalog_items
+            ],
+            use_container_width=True,
+        )
+    elif st.session_state.catalog_items is not None:
+        st.info("No items match these filters")
+
     st.header("🛒 Manifest Entry")
     for i, item in enumerate(st.session_state.cart_items):
         c1, c2 = st.columns([3, 1])
@@ -253,12 +307,13 @@ else:
     st.markdown("---")
 
     # --- PREVIEW ---
+    allow_partial = st.checkbox("Accept partial order if some items are short", value=False)
     if st.button("🔍 INITIATE STOCK SCAN"):
         payload = [{"sku": i["sku"], "quantity": int(i["qty"])} for i in st.session_state.cart_items if i["sku"]]
-        print(f"[client-ui] preview request payload={payload}")
+        print(f"[client-ui] preview request payload={payload} allow_partial={allow_partial}")
         
         try:
-            res = auth_request("POST", "/api/client/order/preview", {"items": payload})
+            res = auth_request("POST", "/api/client/order/preview", {"items": payload, "allow_partial": allow_partial})
             if res is None:
                 st.error("Connection Error: Backend unreachable.")
                 st.stop()
@@ -270,10 +325,20 @@ else:
                 st.session_state.confirmed_items = data.get("items") or {} 
                 
                 st.warning(f"SCAN SUCCESSFUL | ORDER ID: {st.session_state.order_id}")
+                if data.get("expires_at"):
+                    st.info(f"⏳ Stock held until {data.get('expires_at')} — dispatch before then or scan again.")
                 
                 if st.session_state.confirmed_items:
                     for sku, qty in st.session_state.confirmed_items.items():
                         st.write(f"🔸 **{sku}**: {qty} units available")
+                    for line in data.get("lines") or []:
+                        if line.get("shortfall"):
+                            st.write(f"⚠️ **{line['sku']}**: {line['reserved']} of {line['requested']} reserved ({line['shortfall']} short)")
+                    quote = data.get("quote")
+                    if quote:
+                        for line in quote.get("lines") or []:
+                            st.write(f"💲 **{line['sku']}**: {line['quantity']} × ${line['unit_price']:.2f} = ${line['total_price']:.2f}")
+                        st.info(f"🔒 Quoted total ${quote.get('total', 0):.2f}, locked until {quote.get('expires_at')}.")
                 else:
                     st.error("⚠️ Stock Scan returned 0 items. Inventory is currently empty.")
                     st.info("Load stock first from Truck UI (create a restock order), then retry the stock scan here.")
@@ -281,6 +346,17 @@ else:
                 response_text = (res.text or "").lower()
                 if "insufficient" in response_text or "stock" in response_text:
                     st.error("⚠️ Not enough stock available for this request.")
+                    try:
+                        body = res.json()
+                        for line in body.get("lines") or []:
+                            if line.get("shortfall"):
+                                st.write(f"🔻 **{line['sku']}**: requested {line['requested']}, available {line['available']} (short {line['shortfall']})")
+                                for sub in line.get("substitutes") or []:
+                                    st.write(f"   ↪ try **{sub['sku']}** ({sub['name']}): {sub['available']} in stock at ${sub['unit_price']:.2f}")
+                        st.session_state.suggested_items = body.get("suggested_items") or []
+                    except ValueError:
+                        pass
+                    st.info("Tick 'Accept partial order' to reserve what is in stock instead.")
                     st.info("Open Truck UI, create a restock order to load inventory, and retry this scan.")
                 else:
                     st.error(f"⚠️ Stock Scan Failed: {res.text}")
@@ -288,6 +364,16 @@ else:
             print(f"[client-ui] preview exception err={e}")
             st.error(f"Connection Error: {str(e)}")
 
+    if st.session_state.suggested_items and not st.session_state.order_id:
+        if st.button("🔁 USE SUGGESTED SUBSTITUTES"):
+            # Drop the old row widgets' state so the inputs show the suggested items.
+            for i in range(len(st.session_state.cart_items)):
+                st.session_state.pop(f"s_{i}", None)
+                st.session_state.pop(f"q_{i}", None)
+            st.session_state.cart_items = [{"sku": i["sku"], "qty": int(i["quantity"])} for i in st.session_state.suggested_items]
+            st.session_state.suggested_items = []
+            st.rerun()
+
     # --- CONFIRM ---
     if st.session_state.order_id:
         if st.button("🗑️ CANCEL CURRENT ORDER"):
@@ -333,11 +419,15 @@ else:
                                 print(f"[client-ui] poll order={order_data.get('OrderID')} status={order_status}")
                                 s.write(f"Robot Telemetry: `{order_status}`")
                                 
-                                if order_status == "COMPLETED":
+                                if order_status in ("COMPLETED", "PARTIALLY_FULFILLED"):
                                     # --- NEW: DISPLAY PRICE & RECEIPT ---
                                     final_price = order_data.get("TotalPrice", 0.0)
-                                    s.update(label=f"✅ SEQUENCE SUCCESS | FINAL CHARGE: ${final_price}", state="complete")
-                                    st.balloons()
+                                    if order_status == "PARTIALLY_FULFILLED":
+                                        s.update(label=f"⚠️ PARTIAL PICK | CHARGED FOR PICKED ITEMS: ${final_price}", state="complete")
+                                        st.warning("Some items could not be picked. You were only charged for what the robots collected.")
+                                    else:
+                                        s.update(label=f"✅ SEQUENCE SUCCESS | FINAL CHARGE: ${final_price}", state="complete")
+                                        st.balloons()
                                     
                                     st.markdown("### 🧾 DIGITAL RECEIPT")
                                     st.success(f"**Total Amount Charged:** ${final_price}")
@@ -352,8 +442,16 @@ else:
                                     break
                                 elif "FAILED" in str(order_status): 
                                     s.update(label="❌ SEQUENCE CRITICAL FAILURE", state="error")
+                                    if order_data.get("FailureReason"):
+                                        code = order_data.get("FailureCode")
+                                        prefix = f"[{code}] " if code else ""
+                                        st.error(f"Reason: {prefix}{order_data.get('FailureReason')}")
                                     break
                             time.sleep(2)
+                elif res.status_code == 409 and "expired" in (res.text or "").lower():
+                    st.error("⌛ Stock hold expired before dispatch. Run the stock scan again.")
+                    st.session_state.order_id = None
+                    st.session_state.confirmed_items = {}
                 else: st.error("❌ Signal Lost: Robot Dispatch Failed.")
             except Exception as e:
                 print(f"[client-ui] dispatch exception err={e}")
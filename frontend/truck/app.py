@@ -27,6 +27,21 @@ if 'restock_items' not in st.session_state:
     st.session_state.restock_items = [{"sku": "", "name": "", "aisle_type": "produce", "quantity": 1, "unit_cost": 0.0}]
 if 'restock_order_id' not in st.session_state:
     st.session_state.restock_order_id = None
+if 'suggested_manifests' not in st.session_state:
+    st.session_state.suggested_manifests = []
+
+# A manifest picked from the suggestions is applied before any widget is drawn.
+if st.session_state.get("pending_manifest"):
+    manifest = st.session_state.pop("pending_manifest")
+    for i in range(len(st.session_state.restock_items)):
+        for key in ("sku", "name", "aisle", "qty", "cost"):
+            st.session_state.pop(f"{key}_{i}", None)
+    st.session_state.supplier_id = manifest["supplier_id"]
+    st.session_state.supplier_name = manifest["supplier_name"]
+    st.session_state.restock_items = [
+        {"sku": i["sku"], "name": i["name"], "aisle_type": i["aisle_type"], "quantity": max(int(i["quantity"]), 1), "unit_cost": float(i["unit_cost"])}
+        for i in manifest["items"]
+    ]
 
 st.title("🚛 TRUCK OFFLOAD TERMINAL")
 
@@ -34,8 +49,31 @@ st.title("🚛 TRUCK OFFLOAD TERMINAL")
 with st.sidebar:
     st.header("Supplier Identity")
     st.caption("Required: enter supplier business id and readable supplier name before offloading.")
-    supplier_id = st.text_input("Supplier ID", placeholder="e.g. SUPP-NESTLE-01")
-    supplier_name = st.text_input("Supplier Name", placeholder="e.g. Nestle Waters")
+    supplier_id = st.text_input("Supplier ID", placeholder="e.g. SUPP-NESTLE-01", key="supplier_id")
+    supplier_name = st.text_input("Supplier Name", placeholder="e.g. Nestle Waters", key="supplier_name")
+
+    st.header("Suggested Restock")
+    st.caption("SKUs below their reorder point, grouped by their last supplier.")
+    if st.button("CHECK LOW STOCK"):
+        try:
+            res = requests.get(f"{BASE_URL}/api/truck/suggested-restock")
+            print(f"[truck-ui] suggested-restock status={res.status_code} body={res.text}")
+            if res.status_code == 200:
+                data = res.json().get("data", {})
+                st.session_state.suggested_manifests = data.get("manifests") or []
+                for item in data.get("unassigned") or []:
+                    st.warning(f"{item['sku']}: {item['current_quantity']} left, no supplier on record")
+                if not st.session_state.suggested_manifests:
+                    st.info("Nothing to reorder.")
+            else:
+                st.error(f"Suggestion failed: {res.text}")
+        except Exception as e:
+            st.error(f"Connection Error: {str(e)}")
+    for m in st.session_state.suggested_manifests:
+        st.write(f"**{m['supplier_name']}** ({m['supplier_id']}): {len(m['items'])} SKUs, ${m['total_cost']:.2f}")
+        if st.button(f"Load {m['supplier_id']}", key=f"load_{m['supplier_id']}"):
+            st.session_state.pending_manifest = m
+            st.rerun()
 
 # --- MAIN: DYNAMIC TABLE ---
 st.header("Inventory Manifest")
@@ -116,14 +154,21 @@ if st.button("OFFLOAD TRUCK"):
                             state = data.get("Status")
                             total_cost = data.get("TotalCost", 0.0)
                             status_box.write(f"Restock Telemetry: `{state}`")
-                            if state == "COMPLETED":
-                                status_box.update(label=f"✅ OFFLOAD COMPLETE | FINAL TOTAL COST: ${total_cost}", state="complete")
-                                st.balloons()
+                            if state in ("COMPLETED", "PARTIALLY_FULFILLED"):
+                                if state == "PARTIALLY_FULFILLED":
+                                    status_box.update(label=f"⚠️ PARTIAL OFFLOAD | TOTAL COST OF SHELVED STOCK: ${total_cost}", state="complete")
+                                else:
+                                    status_box.update(label=f"✅ OFFLOAD COMPLETE | FINAL TOTAL COST: ${total_cost}", state="complete")
+                                    st.balloons()
                                 st.session_state.restock_items = [{"sku": "", "name": "", "aisle_type": "produce", "quantity": 1, "unit_cost": 0.0}]
                                 st.session_state.restock_order_id = None
                                 break
                             if "FAILED" in str(state):
                                 status_box.update(label="❌ OFFLOAD FAILED", state="error")
+                                if data.get("FailureReason"):
+                                    code = data.get("FailureCode")
+                                    prefix = f"[{code}] " if code else ""
+                                    st.error(f"Reason: {prefix}{data.get('FailureReason')}")
                                 break
                         time_module.sleep(2)
             else:
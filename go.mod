@@ -9,6 +9,7 @@ require (
 	github.com/lib/pq v1.11.1
 	github.com/pebbe/zmq4 v1.4.0
 	github.com/redis/go-redis/v9 v9.17.3
+	github.com/robfig/cron/v3 v3.0.1
 	golang.org/x/crypto v0.47.0
 	google.golang.org/grpc v1.78.0
 	google.golang.org/protobuf v1.36.11
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"auto_grocery/inventory/internal/handler"
 	"auto_grocery/inventory/internal/mq"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	"github.com/robfig/cron/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -26,6 +29,32 @@ func getenv(key, fallback string) string {
 	return value
 }
 
+func getenvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("[inventory] WARN invalid %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+func getenvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("[inventory] WARN invalid %s=%q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
 // main wires inventory dependencies and starts the gRPC server.
 func main() {
 	_ = godotenv.Load("inventory/.env")
@@ -37,12 +66,30 @@ func main() {
 	defer db.Close()
 
 	stockStore := store.NewStore(db)
-	// Dual-DB: Database 0 for Clients, Database 1 for Restocks
+	// Workflow state lives in redis; "memory" keeps it in process for dev and tests and loses it on restart.
+	memoryBackend := getenv("MEMORY_BACKEND", "redis")
 	redisAddr := getenv("REDIS_ADDR", "localhost:6379")
-	redisPassword := os.Getenv("REDIS_PW")
-	memoryStore := store.NewMemoryStore(redisAddr, redisPassword)
-	if err := memoryStore.Ping(context.Background()); err != nil {
-		log.Fatalf("failed to connect/authenticate to Redis at %s: %v", redisAddr, err)
+	var memoryStore store.MemoryStore
+	switch memoryBackend {
+	case "redis":
+		redisStore := store.NewRedisMemoryStore(redisAddr, os.Getenv("REDIS_PW"))
+		if err := redisStore.Ping(context.Background()); err != nil {
+			log.Fatalf("failed to connect/authenticate to Redis at %s: %v", redisAddr, err)
+		}
+		// Orders dispatched by a release that split flows across DB 0 and DB 1 move into the namespaced keyspace.
+		moved, err := redisStore.MigrateLegacyKeys(context.Background())
+		if err != nil {
+			log.Fatalf("failed to migrate legacy redis keys: %v", err)
+		}
+		if moved > 0 {
+			log.Printf("[inventory] WARN migrated legacy redis keys count=%d", moved)
+		}
+		memoryStore = redisStore
+	case "memory":
+		log.Printf("[inventory] WARN MEMORY_BACKEND=memory: order workflow state is not shared or persisted")
+		memoryStore = store.NewLocalMemoryStore()
+	default:
+		log.Fatalf("invalid MEMORY_BACKEND %q: want redis or memory", memoryBackend)
 	}
 
 	robotZMQBindAddr := getenv("ROBOT_ZMQ_BIND_ADDR", "tcp://*:5556")
@@ -63,7 +110,48 @@ func main() {
 	orderWebhookURL := getenv("ORDERING_ORDER_WEBHOOK_URL", "http://localhost:5050/internal/webhook/update-order")
 	restockWebhookURL := getenv("ORDERING_RESTOCK_WEBHOOK_URL", "http://localhost:5050/internal/webhook/update-restock")
 
-	inventoryHandler := handler.NewInventoryHandler(stockStore, memoryStore, publisher, pricingClient, orderWebhookURL, restockWebhookURL)
+	robotHeartbeatTTL := getenvDuration("ROBOT_HEARTBEAT_TTL", 30*time.Second)
+
+	webhookRetry := handler.WebhookRetryPolicy{
+		MaxAttempts: getenvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		BaseBackoff: getenvDuration("WEBHOOK_BASE_BACKOFF", 5*time.Second),
+		MaxBackoff:  getenvDuration("WEBHOOK_MAX_BACKOFF", 10*time.Minute),
+	}
+
+	// FAILED aisle tasks are re-dispatched this many times before the order fails.
+	aisleRetryLimit := getenvInt("ROBOT_FAILURE_RETRY_LIMIT", 2)
+
+	// Previewed orders hold their stock this long unless confirmed.
+	reservationTTL := getenvDuration("RESERVATION_TTL", 15*time.Minute)
+
+	// Stock adjustments moving more units than this wait for a second person; 0 applies every adjustment at once.
+	adjustmentApprovalThreshold := getenvInt("ADJUSTMENT_APPROVAL_THRESHOLD", 25)
+
+	inventoryHandler := handler.NewInventoryHandler(stockStore, stockStore, memoryStore, publisher, pricingClient, orderWebhookURL, restockWebhookURL, robotHeartbeatTTL, webhookRetry, aisleRetryLimit, reservationTTL, adjustmentApprovalThreshold)
+
+	// Finish or re-track orders a previous run left mid-workflow before new reports arrive.
+	inventoryHandler.ResumeWorkflows(context.Background())
+
+	webhookDispatchInterval := getenvDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second)
+	go inventoryHandler.RunWebhookDispatcher(context.Background(), webhookDispatchInterval)
+
+	orderSweepInterval := getenvDuration("ORDER_SWEEP_INTERVAL", 30*time.Second)
+	orderSLA := getenvDuration("ORDER_SLA", 10*time.Minute)
+	go inventoryHandler.RunOrderSweeper(context.Background(), orderSweepInterval, orderSLA)
+
+	dispatchRedeliveryInterval := getenvDuration("DISPATCH_REDELIVERY_INTERVAL", 10*time.Second)
+	dispatchMaxAttempts := getenvInt("DISPATCH_MAX_ATTEMPTS", 5)
+	go inventoryHandler.RunDispatchRedelivery(context.Background(), dispatchRedeliveryInterval, dispatchMaxAttempts)
+
+	reservationSweepInterval := getenvDuration("RESERVATION_SWEEP_INTERVAL", 30*time.Second)
+	go inventoryHandler.RunReservationExpiry(context.Background(), reservationSweepInterval)
+
+	expiryWriteOffCron := getenv("EXPIRY_WRITEOFF_CRON", "0 * * * *")
+	expirySchedule, err := cron.ParseStandard(expiryWriteOffCron)
+	if err != nil {
+		log.Fatalf("invalid EXPIRY_WRITEOFF_CRON %q: %v", expiryWriteOffCron, err)
+	}
+	go inventoryHandler.RunExpiryWriteOff(context.Background(), expirySchedule)
 
 	inventoryGRPCAddr := getenv("INVENTORY_GRPC_ADDR", ":50051")
 	lis, err := net.Listen("tcp", inventoryGRPCAddr)
@@ -73,7 +161,7 @@ func main() {
 	grpcServer := grpc.NewServer()
 	pb.RegisterInventoryServiceServer(grpcServer, inventoryHandler)
 
-	log.Printf("[inventory] INFO service listening on %s (redis=%s pricing_grpc=%s robot_bind=%s)", inventoryGRPCAddr, redisAddr, pricingGRPCAddr, robotZMQBindAddr)
+	log.Printf("[inventory] INFO service listening on %s (memory=%s redis=%s pricing_grpc=%s robot_bind=%s)", inventoryGRPCAddr, memoryBackend, redisAddr, pricingGRPCAddr, robotZMQBindAddr)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,17 @@
+CREATE TABLE webhook_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    order_id TEXT NOT NULL,
+    order_type TEXT NOT NULL,
+    target_url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_outbox_due ON webhook_outbox(status, next_attempt_at);
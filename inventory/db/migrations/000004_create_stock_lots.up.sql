@@ -0,0 +1,31 @@
+-- One row per received shipment; available_stock.quantity is the sum of a sku's lots.
+CREATE TABLE stock_lots (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    restock_order_id TEXT,
+
+    quantity INT NOT NULL DEFAULT 0,
+    received_quantity INT NOT NULL DEFAULT 0,
+    unit_cost NUMERIC(10, 2) NOT NULL DEFAULT 0.00,
+
+    mfd_date TIMESTAMP,
+    expiry_date TIMESTAMP,
+
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_lots_fefo ON stock_lots(sku, expiry_date) WHERE quantity > 0;
+
+-- Units of each lot currently held by a client order.
+CREATE TABLE lot_reservations (
+    order_id TEXT NOT NULL,
+    lot_id BIGINT NOT NULL REFERENCES stock_lots(id),
+    quantity INT NOT NULL,
+    PRIMARY KEY (order_id, lot_id)
+);
+
+-- Existing stock becomes a single lot per sku carrying its current dates.
+INSERT INTO stock_lots (sku, quantity, received_quantity, unit_cost, mfd_date, expiry_date, received_at)
+SELECT sku, quantity, quantity, COALESCE(unit_cost, 0.00), mfd_date, expiry_date, last_updated
+FROM available_stock
+WHERE quantity > 0;
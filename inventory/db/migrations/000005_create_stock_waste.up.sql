@@ -0,0 +1,15 @@
+-- Expired units removed from sale by the scheduled write-off job, one row per lot cleared.
+CREATE TABLE stock_waste (
+    id BIGSERIAL PRIMARY KEY,
+    lot_id BIGINT NOT NULL REFERENCES stock_lots(id),
+    sku TEXT NOT NULL,
+    aisle_type TEXT NOT NULL,
+
+    quantity INT NOT NULL,
+    unit_cost NUMERIC(10, 2) NOT NULL DEFAULT 0.00,
+    expiry_date TIMESTAMP,
+
+    written_off_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_waste_written_off ON stock_waste(written_off_at, aisle_type);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_movements;
+DROP FUNCTION IF EXISTS reject_inventory_movement_change();
@@ -0,0 +1,27 @@
+-- Append-only journal of every change to available_stock.quantity.
+CREATE TABLE inventory_movements (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL,
+    delta INT NOT NULL,
+    reason TEXT NOT NULL,
+    order_id TEXT,
+    balance_after INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_inventory_movements_sku_time ON inventory_movements(sku, created_at);
+
+-- Start every existing sku's history from its current quantity.
+INSERT INTO inventory_movements (sku, delta, reason, balance_after)
+SELECT sku, quantity, 'OPENING_BALANCE', quantity
+FROM available_stock;
+
+CREATE FUNCTION reject_inventory_movement_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'inventory_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER inventory_movements_append_only
+BEFORE UPDATE OR DELETE ON inventory_movements
+FOR EACH ROW EXECUTE FUNCTION reject_inventory_movement_change();
@@ -0,0 +1,11 @@
+-- Client stock holds. HELD expires at expires_at unless ProcessCustomerOrder commits it first.
+CREATE TABLE reservations (
+    order_id TEXT PRIMARY KEY,
+    status TEXT NOT NULL DEFAULT 'HELD', -- HELD, COMMITTED, RELEASED, EXPIRED
+    expires_at TIMESTAMP NOT NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reservations_held_expiry ON reservations(expires_at) WHERE status = 'HELD';
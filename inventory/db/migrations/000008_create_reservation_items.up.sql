@@ -0,0 +1,9 @@
+-- What each order's reserve asked for and got, so a repeated ReserveItems replays the same result.
+CREATE TABLE reservation_items (
+    order_id TEXT NOT NULL REFERENCES reservations(order_id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    requested INT NOT NULL CHECK (requested > 0),
+    reserved INT NOT NULL CHECK (reserved >= 0),
+
+    PRIMARY KEY (order_id, sku)
+);
@@ -0,0 +1 @@
+ALTER TABLE reservation_items DROP COLUMN IF EXISTS available;
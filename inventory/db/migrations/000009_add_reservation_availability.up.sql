@@ -0,0 +1,6 @@
+-- Sellable stock per sku when the reserve ran, so rejected and partial reserves can report shortfalls.
+-- A rejected all-or-nothing reserve takes nothing and leaves its reservation with status REJECTED.
+ALTER TABLE reservation_items ADD COLUMN available INT NOT NULL DEFAULT 0;
+
+-- Earlier reserves only recorded what they got; treat that as what was available.
+UPDATE reservation_items SET available = reserved;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS low_stock_events;
+ALTER TABLE available_stock DROP COLUMN IF EXISTS target_level;
+ALTER TABLE available_stock DROP COLUMN IF EXISTS reorder_point;
@@ -0,0 +1,17 @@
+-- Per-sku restock thresholds. A reorder_point of 0 turns low-stock alerts off for the sku.
+ALTER TABLE available_stock
+    ADD COLUMN reorder_point INT NOT NULL DEFAULT 0 CHECK (reorder_point >= 0),
+    ADD COLUMN target_level INT NOT NULL DEFAULT 0 CHECK (target_level >= 0);
+
+-- One row each time a reserve takes a sku from at or above its reorder point to below it.
+CREATE TABLE low_stock_events (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    order_id TEXT,
+    quantity INT NOT NULL, -- stock left after the reserve
+    reorder_point INT NOT NULL,
+    target_level INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_low_stock_events_sku_created ON low_stock_events(sku, created_at);
@@ -0,0 +1,19 @@
+-- Manual stock corrections after a shelf count. Adjustments above the approval threshold wait in
+-- PENDING_APPROVAL until a second person approves (APPLIED) or rejects (REJECTED) them.
+CREATE TABLE stock_adjustments (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL REFERENCES available_stock(sku),
+    reason TEXT NOT NULL, -- CYCLE_COUNT, DAMAGE, THEFT, FOUND
+    delta INT NOT NULL,
+    counted_quantity INT, -- the shelf count, for CYCLE_COUNT
+    status TEXT NOT NULL, -- PENDING_APPROVAL, APPLIED, REJECTED
+    requested_by TEXT NOT NULL,
+    decided_by TEXT,
+    note TEXT NOT NULL DEFAULT '',
+    quantity_after INT,
+
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP
+);
+
+CREATE INDEX idx_stock_adjustments_pending ON stock_adjustments(created_at) WHERE status = 'PENDING_APPROVAL';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fulfillment_workflows;
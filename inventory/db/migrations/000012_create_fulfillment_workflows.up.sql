@@ -0,0 +1,23 @@
+-- Durable state of every dispatched order, so finalization survives a redis flush or an inventory restart.
+-- stage: DISPATCHED -> PICKING (first robot report) -> BILLING (finalization claimed) -> NOTIFIED (ordering
+-- webhook in the outbox) -> DONE. Inventory resumes every workflow that is not DONE on startup.
+CREATE TABLE fulfillment_workflows (
+    order_id TEXT NOT NULL,
+    order_type TEXT NOT NULL, -- CUSTOMER, RESTOCK
+    stage TEXT NOT NULL,
+    items JSONB NOT NULL, -- sku -> quantity for CUSTOMER, restock manifest lines for RESTOCK
+    robot_items JSONB NOT NULL, -- sku -> aisle task as dispatched to robots
+    expected_reports INT NOT NULL,
+    reports INT NOT NULL DEFAULT 0, -- counted robot reports
+    picked JSONB NOT NULL DEFAULT '{}', -- sku -> robot-reported quantity
+    reporters TEXT[] NOT NULL DEFAULT '{}',
+    fail_code TEXT, -- set when the order is failed instead of billed
+    fail_reason TEXT,
+    stock_settled BOOLEAN NOT NULL DEFAULT FALSE, -- stock effects of BILLING already applied
+
+    dispatched_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_type, order_id)
+);
+
+CREATE INDEX idx_fulfillment_workflows_open ON fulfillment_workflows(dispatched_at) WHERE stage <> 'DONE';
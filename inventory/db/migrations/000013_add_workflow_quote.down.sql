@@ -0,0 +1 @@
+ALTER TABLE fulfillment_workflows DROP COLUMN IF EXISTS quote_id;
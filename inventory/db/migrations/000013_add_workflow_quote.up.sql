@@ -0,0 +1,2 @@
+-- The pricing quote a customer order was confirmed with; billing uses its locked prices.
+ALTER TABLE fulfillment_workflows ADD COLUMN quote_id TEXT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_fulfillment_workflows_billing;
+ALTER TABLE fulfillment_workflows
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS next_settle_at,
+    DROP COLUMN IF EXISTS settle_attempts;
@@ -0,0 +1,8 @@
+-- Settlement retries. A BILLING workflow whose settlement (stock, bill, webhook enqueue) fails stays in
+-- BILLING and is retried at next_settle_at; after too many attempts it moves to DEAD for an operator.
+ALTER TABLE fulfillment_workflows
+    ADD COLUMN settle_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN next_settle_at TIMESTAMPTZ,
+    ADD COLUMN last_error TEXT;
+
+CREATE INDEX idx_fulfillment_workflows_billing ON fulfillment_workflows(updated_at) WHERE stage = 'BILLING';
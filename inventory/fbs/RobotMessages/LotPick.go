@@ -0,0 +1,79 @@
+// Code generated by the FlatBuffers compiler. DO NOT EDIT.
+
+package RobotMessages
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+type LotPick struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsLotPick(buf []byte, offset flatbuffers.UOffsetT) *LotPick {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &LotPick{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func FinishLotPickBuffer(builder *flatbuffers.Builder, offset flatbuffers.UOffsetT) {
+	builder.Finish(offset)
+}
+
+func GetSizePrefixedRootAsLotPick(buf []byte, offset flatbuffers.UOffsetT) *LotPick {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &LotPick{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func FinishSizePrefixedLotPickBuffer(builder *flatbuffers.Builder, offset flatbuffers.UOffsetT) {
+	builder.FinishSizePrefixed(offset)
+}
+
+func (rcv *LotPick) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *LotPick) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *LotPick) LotId() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *LotPick) MutateLotId(n int64) bool {
+	return rcv._tab.MutateInt64Slot(4, n)
+}
+
+func (rcv *LotPick) Quantity() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *LotPick) MutateQuantity(n int32) bool {
+	return rcv._tab.MutateInt32Slot(6, n)
+}
+
+func LotPickStart(builder *flatbuffers.Builder) {
+	builder.StartObject(2)
+}
+func LotPickAddLotId(builder *flatbuffers.Builder, lotId int64) {
+	builder.PrependInt64Slot(0, lotId, 0)
+}
+func LotPickAddQuantity(builder *flatbuffers.Builder, quantity int32) {
+	builder.PrependInt32Slot(1, quantity, 0)
+}
+func LotPickEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
@@ -77,8 +77,37 @@ func (rcv *OrderBroadcast) ItemsLength() int {
 	return 0
 }
 
+func (rcv *OrderBroadcast) Aisles(j int) []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.ByteVector(a + flatbuffers.UOffsetT(j*4))
+	}
+	return nil
+}
+
+func (rcv *OrderBroadcast) AislesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *OrderBroadcast) Retry() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *OrderBroadcast) MutateRetry(n int32) bool {
+	return rcv._tab.MutateInt32Slot(12, n)
+}
+
 func OrderBroadcastStart(builder *flatbuffers.Builder) {
-	builder.StartObject(3)
+	builder.StartObject(5)
 }
 func OrderBroadcastAddOrderId(builder *flatbuffers.Builder, orderId flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(orderId), 0)
@@ -92,6 +121,15 @@ func OrderBroadcastAddItems(builder *flatbuffers.Builder, items flatbuffers.UOff
 func OrderBroadcastStartItemsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(4, numElems, 4)
 }
+func OrderBroadcastAddAisles(builder *flatbuffers.Builder, aisles flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(3, flatbuffers.UOffsetT(aisles), 0)
+}
+func OrderBroadcastStartAislesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func OrderBroadcastAddRetry(builder *flatbuffers.Builder, retry int32) {
+	builder.PrependInt32Slot(4, retry, 0)
+}
 func OrderBroadcastEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
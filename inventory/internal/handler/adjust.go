@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdjustStock corrects a sku's stock after a physical count, or parks the correction for approval.
+func (h *InventoryHandler) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	if req.GetSku() == "" || req.GetRequestedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku and requested_by are required")
+	}
+	adj := store.StockAdjustment{
+		SKU:         req.GetSku(),
+		Reason:      strings.TrimPrefix(req.GetReason().String(), "ADJUSTMENT_REASON_"),
+		Delta:       int(req.GetDelta()),
+		RequestedBy: req.GetRequestedBy(),
+		Note:        req.GetNote(),
+	}
+	switch req.GetReason() {
+	case pb.AdjustmentReason_ADJUSTMENT_REASON_CYCLE_COUNT:
+		if req.GetCountedQuantity() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "counted_quantity must not be negative")
+		}
+		counted := int(req.GetCountedQuantity())
+		adj.CountedQuantity = &counted
+	case pb.AdjustmentReason_ADJUSTMENT_REASON_DAMAGE, pb.AdjustmentReason_ADJUSTMENT_REASON_THEFT:
+		if adj.Delta >= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "%s needs a negative delta", adj.Reason)
+		}
+	case pb.AdjustmentReason_ADJUSTMENT_REASON_FOUND:
+		if adj.Delta <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "FOUND needs a positive delta")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown adjustment reason %d", req.GetReason())
+	}
+
+	result, err := h.store.CreateAdjustment(ctx, adj, h.approvalThreshold)
+	if err != nil {
+		return nil, adjustmentError(adj.SKU, err)
+	}
+	log.Printf("[inventory] adjustment id=%d sku=%s reason=%s delta=%d status=%s requested_by=%s",
+		result.ID, result.SKU, result.Reason, result.Delta, result.Status, result.RequestedBy)
+
+	if result.Status == store.AdjustmentApplied {
+		h.pushStockMetrics(ctx, []string{result.SKU})
+	}
+	return adjustmentResponse(result), nil
+}
+
+// DecideAdjustment lets a second person approve or reject an adjustment above the approval threshold.
+func (h *InventoryHandler) DecideAdjustment(ctx context.Context, req *pb.DecideAdjustmentRequest) (*pb.AdjustStockResponse, error) {
+	if req.GetAdjustmentId() <= 0 || req.GetDecidedBy() == "" {
+		return nil, status.Error(codes.InvalidArgument, "adjustment_id and decided_by are required")
+	}
+
+	result, err := h.store.DecideAdjustment(ctx, req.GetAdjustmentId(), req.GetDecidedBy(), req.GetApprove())
+	if err != nil {
+		return nil, adjustmentError("", err)
+	}
+	log.Printf("[inventory] adjustment decided id=%d sku=%s status=%s requested_by=%s decided_by=%s",
+		result.ID, result.SKU, result.Status, result.RequestedBy, result.DecidedBy)
+
+	if result.Status == store.AdjustmentApplied {
+		h.pushStockMetrics(ctx, []string{result.SKU})
+	}
+	return adjustmentResponse(result), nil
+}
+
+// adjustmentResponse converts a stored adjustment to its RPC form.
+func adjustmentResponse(adj *store.StockAdjustment) *pb.AdjustStockResponse {
+	resp := &pb.AdjustStockResponse{AdjustmentId: adj.ID, Status: adj.Status, Delta: int32(adj.Delta)}
+	if adj.Status == store.AdjustmentApplied {
+		resp.QuantityAfter = int32(adj.QuantityAfter)
+	}
+	return resp
+}
+
+// adjustmentError maps store errors to gRPC status codes.
+func adjustmentError(sku string, err error) error {
+	switch {
+	case errors.Is(err, store.ErrUnknownSKU):
+		return status.Errorf(codes.NotFound, "sku %s not stocked", sku)
+	case errors.Is(err, store.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, "adjustment would take stock below zero")
+	case errors.Is(err, store.ErrAdjustmentNotPending):
+		return status.Error(codes.FailedPrecondition, "adjustment is not pending approval")
+	case errors.Is(err, store.ErrSelfApproval):
+		return status.Error(codes.PermissionDenied, "adjustment must be decided by a second person")
+	}
+	log.Printf("[inventory] ERROR adjustment failed sku=%s err=%v", sku, err)
+	return err
+}
+
+// pushStockMetrics sends the current quantity and unit cost of skus to pricing.
+func (h *InventoryHandler) pushStockMetrics(ctx context.Context, skus []string) {
+	current, err := h.store.GetBatchItems(ctx, skus)
+	if err != nil {
+		log.Printf("[inventory] WARN failed to fetch stock for pricing update skus=%v err=%v", skus, err)
+		return
+	}
+	var metrics []*pb.StockMetric
+	for _, sku := range skus {
+		if item, ok := current[sku]; ok {
+			metrics = append(metrics, &pb.StockMetric{Sku: sku, Quantity: int32(item.Quantity), UnitCost: item.UnitCost})
+		}
+	}
+
+	pricingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := h.pricingClient.UpdateStockMetrics(pricingCtx, &pb.UpdateStockMetricsRequest{Updates: metrics}); err != nil {
+		log.Printf("[inventory] WARN pricing metric update failed skus=%v err=%v", skus, err)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"time"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultCatalogPageSize = 50
+	maxCatalogPageSize     = 200
+)
+
+// ListStock pages through stock in sku order, merged with current prices from pricing.
+func (h *InventoryHandler) ListStock(ctx context.Context, req *pb.ListStockRequest) (*pb.ListStockResponse, error) {
+	if req.GetPageSize() < 0 || req.GetExpiringWithinDays() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size and expiring_within_days must not be negative")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultCatalogPageSize
+	}
+	pageSize = min(pageSize, maxCatalogPageSize)
+
+	// The page token is the last sku of the previous page.
+	after, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	filter := store.StockFilter{
+		AisleType:   req.GetAisleType(),
+		InStockOnly: req.GetInStockOnly(),
+		NamePrefix:  req.GetNamePrefix(),
+	}
+	if days := req.GetExpiringWithinDays(); days > 0 {
+		filter.ExpiringBefore = time.Now().AddDate(0, 0, int(days))
+	}
+
+	// One extra row tells us whether another page follows.
+	items, err := h.store.ListStock(ctx, filter, string(after), pageSize+1)
+	if err != nil {
+		log.Printf("[inventory] ERROR list stock failed err=%v", err)
+		return nil, err
+	}
+	resp := &pb.ListStockResponse{}
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(items[pageSize-1].SKU))
+	}
+
+	skus := make([]string, len(items))
+	for i, item := range items {
+		skus[i] = item.SKU
+	}
+	prices := h.catalogPrices(ctx, skus)
+
+	for _, i := range items {
+		item := &pb.CatalogItem{
+			Sku:       i.SKU,
+			Name:      i.Name,
+			AisleType: i.AisleType,
+			Quantity:  int32(i.Quantity),
+		}
+		if !i.ExpiryDate.IsZero() {
+			item.ExpiryDate = timestamppb.New(i.ExpiryDate)
+		}
+		if price, ok := prices[i.SKU]; ok {
+			item.UnitPrice = price
+			item.Priced = true
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+// catalogPrices fetches prices for one page in a single pricing call; a failure leaves the page unpriced.
+func (h *InventoryHandler) catalogPrices(ctx context.Context, skus []string) map[string]float64 {
+	if len(skus) == 0 {
+		return nil
+	}
+	pricingCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	resp, err := h.pricingClient.GetPrices(pricingCtx, &pb.GetPricesRequest{Skus: skus})
+	if err != nil {
+		log.Printf("[inventory] WARN catalog prices unavailable skus=%d err=%v", len(skus), err)
+		return nil
+	}
+	return resp.GetPrices()
+}
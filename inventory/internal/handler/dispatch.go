@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AcknowledgeDispatch marks an aisle task as received so it is no longer re-broadcast.
+func (h *InventoryHandler) AcknowledgeDispatch(ctx context.Context, req *pb.AcknowledgeDispatchRequest) (*pb.AcknowledgeDispatchResponse, error) {
+	if req.GetOrderId() == "" || req.GetAisle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and aisle are required")
+	}
+	isRestock, err := parseOrderType(req.GetOrderType())
+	if err != nil {
+		return nil, err
+	}
+	h.ackDispatch(ctx, req.GetOrderId(), isRestock, req.GetAisle(), req.GetRobotId())
+	return &pb.AcknowledgeDispatchResponse{Success: true}, nil
+}
+
+// ackDispatch removes an aisle task from the dispatch ledger; repeat acknowledgements are no-ops.
+func (h *InventoryHandler) ackDispatch(ctx context.Context, orderID string, isRestock bool, aisle string, robotID string) {
+	if aisle == "" {
+		return
+	}
+	removed, err := h.memoryStore.RemoveDispatchTask(ctx, orderID, isRestock, aisle)
+	if err != nil {
+		log.Printf("[inventory-dispatch] WARN ack failed order=%s aisle=%s robot=%s err=%v", orderID, aisle, robotID, err)
+		return
+	}
+	if removed {
+		log.Printf("[inventory-dispatch] task acknowledged order=%s aisle=%s robot=%s", orderID, aisle, robotID)
+	}
+}
+
+// RunDispatchRedelivery periodically re-broadcasts aisle tasks that no robot has acknowledged.
+func (h *InventoryHandler) RunDispatchRedelivery(ctx context.Context, interval time.Duration, maxAttempts int) {
+	log.Printf("[inventory-dispatch] started interval=%s max_attempts=%d", interval, maxAttempts)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[inventory-dispatch] stopped")
+			return
+		case <-ticker.C:
+			h.redeliverPending(ctx, interval, maxAttempts, false)
+			h.redeliverPending(ctx, interval, maxAttempts, true)
+		}
+	}
+}
+
+// redeliverPending re-sends every unacknowledged aisle task of one flow last sent at least interval ago.
+func (h *InventoryHandler) redeliverPending(ctx context.Context, interval time.Duration, maxAttempts int, isRestock bool) {
+	orderIDs, err := h.memoryStore.ListDispatchesSentBefore(ctx, isRestock, time.Now().Add(-interval))
+	if err != nil {
+		log.Printf("[inventory-dispatch] ERROR list pending dispatches restock=%t err=%v", isRestock, err)
+		return
+	}
+
+	orderType := orderTypeFor(isRestock)
+
+	for _, orderID := range orderIDs {
+		attempts, items, err := h.memoryStore.GetPendingDispatch(ctx, orderID, isRestock)
+		if errors.Is(err, store.ErrOrderNotTracked) {
+			// The order finished or expired; nothing left to redeliver.
+			h.memoryStore.ForgetDispatch(ctx, orderID, isRestock)
+			continue
+		} else if err != nil {
+			log.Printf("[inventory-dispatch] ERROR load pending dispatch order=%s err=%v", orderID, err)
+			continue
+		}
+
+		// Redeliveries repeat the aisle's current failure retry so robots treat them as the same task.
+		retries, err := h.memoryStore.GetAisleFailures(ctx, orderID, isRestock)
+		if err != nil {
+			log.Printf("[inventory-dispatch] WARN load aisle failures order=%s err=%v", orderID, err)
+		}
+
+		aisles := mq.AisleSet(items)
+		byAisle := make(map[string]map[string]mq.ItemDetails, len(aisles))
+		for sku, detail := range items {
+			if byAisle[detail.Aisle] == nil {
+				byAisle[detail.Aisle] = make(map[string]mq.ItemDetails)
+			}
+			byAisle[detail.Aisle][sku] = detail
+		}
+
+		var resent []string
+		for aisle, sent := range attempts {
+			if sent >= maxAttempts {
+				// Budget spent; the stuck-order sweeper fails the order once its SLA passes.
+				log.Printf("[inventory-dispatch] WARN giving up on aisle task order=%s aisle=%s attempts=%d", orderID, aisle, sent)
+				h.memoryStore.RemoveDispatchTask(ctx, orderID, isRestock, aisle)
+				continue
+			}
+			if err := h.publisher.SendAisleCommand(orderID, orderType, aisle, byAisle[aisle], aisles, int32(retries[aisle])); err != nil {
+				log.Printf("[inventory-dispatch] ERROR redelivery failed order=%s aisle=%s err=%v", orderID, aisle, err)
+				continue
+			}
+			log.Printf("[inventory-dispatch] redelivered unacknowledged task order=%s aisle=%s attempt=%d", orderID, aisle, sent+1)
+			resent = append(resent, aisle)
+		}
+
+		if len(resent) > 0 {
+			if err := h.memoryStore.MarkRedelivered(ctx, orderID, isRestock, resent, time.Now()); err != nil {
+				log.Printf("[inventory-dispatch] ERROR record redelivery order=%s err=%v", orderID, err)
+			}
+		}
+	}
+}
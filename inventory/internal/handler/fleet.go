@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RegisterRobot adds a robot to the live fleet for its aisle.
+func (h *InventoryHandler) RegisterRobot(ctx context.Context, req *pb.RegisterRobotRequest) (*pb.RegisterRobotResponse, error) {
+	if req.GetRobotId() == "" || req.GetAisle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "robot_id and aisle are required")
+	}
+
+	if err := h.memoryStore.RegisterRobot(ctx, req.GetRobotId(), req.GetAisle(), h.robotHeartbeatTTL); err != nil {
+		log.Printf("[inventory] ERROR robot register failed robot=%s aisle=%s err=%v", req.GetRobotId(), req.GetAisle(), err)
+		return nil, err
+	}
+	log.Printf("[inventory] robot registered robot=%s aisle=%s ttl=%s", req.GetRobotId(), req.GetAisle(), h.robotHeartbeatTTL)
+
+	return &pb.RegisterRobotResponse{
+		Success:                  true,
+		HeartbeatIntervalSeconds: h.heartbeatIntervalSeconds(),
+	}, nil
+}
+
+// RobotHeartbeat keeps a registered robot in the live fleet.
+func (h *InventoryHandler) RobotHeartbeat(ctx context.Context, req *pb.RobotHeartbeatRequest) (*pb.RobotHeartbeatResponse, error) {
+	if req.GetRobotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "robot_id is required")
+	}
+
+	registered, err := h.memoryStore.TouchRobot(ctx, req.GetRobotId(), h.robotHeartbeatTTL)
+	if err != nil {
+		log.Printf("[inventory] ERROR robot heartbeat failed robot=%s err=%v", req.GetRobotId(), err)
+		return nil, err
+	}
+	if !registered {
+		log.Printf("[inventory] WARN heartbeat from unregistered robot=%s aisle=%s", req.GetRobotId(), req.GetAisle())
+	}
+
+	return &pb.RobotHeartbeatResponse{Success: true, Registered: registered}, nil
+}
+
+// heartbeatIntervalSeconds tells robots to beat often enough to survive two missed heartbeats.
+func (h *InventoryHandler) heartbeatIntervalSeconds() int32 {
+	interval := h.robotHeartbeatTTL / 3
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return int32(interval / time.Second)
+}
+
+// trackExpectedReports snapshots the aisles an order needs and how many live robots serve them.
+func (h *InventoryHandler) trackExpectedReports(ctx context.Context, orderID string, isRestock bool, items map[string]mq.ItemDetails) (int, error) {
+	aisles := mq.AisleSet(items)
+
+	fleet, err := h.memoryStore.LiveFleet(ctx, h.robotHeartbeatTTL)
+	if err != nil {
+		log.Printf("[inventory] WARN live fleet lookup failed order=%s err=%v", orderID, err)
+	}
+
+	expected := 0
+	for _, aisle := range aisles {
+		live := fleet[aisle]
+		if live == 0 {
+			// Wait for at least one report so an unregistered robot can still complete the aisle.
+			log.Printf("[inventory] WARN no live robot registered order=%s aisle=%s", orderID, aisle)
+			live = 1
+		}
+		expected += live
+	}
+	log.Printf("[inventory] expected reports order=%s aisles=%v expected=%d fleet=%v", orderID, aisles, expected, fleet)
+
+	if err := h.memoryStore.SaveExpectedReports(ctx, orderID, isRestock, aisles, expected); err != nil {
+		return 0, err
+	}
+	return expected, h.memoryStore.MarkInFlight(ctx, orderID, isRestock, time.Now())
+}
+
+// countsTowardOrder reports whether a robot callback is one the order is waiting for.
+func countsTowardOrder(req *pb.ReportJobStatusRequest, jobStatus pb.JobStatus, aisles []string) bool {
+	if req.GetAisle() == "" {
+		// Robots that predate aisle reporting send NO_OP for orders outside their aisle.
+		return jobStatus != pb.JobStatus_JOB_STATUS_NO_OP
+	}
+	for _, aisle := range aisles {
+		if aisle == req.GetAisle() {
+			return true
+		}
+	}
+	return false
+}
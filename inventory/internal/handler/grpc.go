@@ -1,45 +1,67 @@
 package handler
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-	"net/http"
-	"os"
+	"maps"
+	"slices"
 	"time"
 
 	"auto_grocery/inventory/internal/mq"
 	"auto_grocery/inventory/internal/store"
 	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type InventoryHandler struct {
 	pb.UnimplementedInventoryServiceServer
 	store             *store.Store
-	memoryStore       *store.MemoryStore
+	workflows         store.WorkflowStore
+	memoryStore       store.MemoryStore
 	publisher         *mq.Publisher
 	pricingClient     pb.PricingServiceClient
 	orderWebhookURL   string
 	restockWebhookURL string
+	robotHeartbeatTTL time.Duration
+	webhookRetry      WebhookRetryPolicy
+	aisleRetryLimit   int
+	reservationTTL    time.Duration
+	approvalThreshold int
 }
 
 // NewInventoryHandler constructs the inventory gRPC handler and integration clients.
 func NewInventoryHandler(
 	s *store.Store,
-	ms *store.MemoryStore,
+	wfs store.WorkflowStore,
+	ms store.MemoryStore,
 	pub *mq.Publisher,
 	p pb.PricingServiceClient,
 	orderWebhookURL string,
 	restockWebhookURL string,
+	robotHeartbeatTTL time.Duration,
+	webhookRetry WebhookRetryPolicy,
+	aisleRetryLimit int,
+	reservationTTL time.Duration,
+	approvalThreshold int,
 ) *InventoryHandler {
 	return &InventoryHandler{
 		store:             s,
+		workflows:         wfs,
 		memoryStore:       ms,
 		publisher:         pub,
 		pricingClient:     p,
 		orderWebhookURL:   orderWebhookURL,
 		restockWebhookURL: restockWebhookURL,
+		robotHeartbeatTTL: robotHeartbeatTTL,
+		webhookRetry:      webhookRetry,
+		aisleRetryLimit:   aisleRetryLimit,
+		reservationTTL:    reservationTTL,
+		approvalThreshold: approvalThreshold,
 	}
 }
 
@@ -80,77 +102,187 @@ func (h *InventoryHandler) ReserveItems(ctx context.Context, req *pb.ReserveItem
 	if len(req.GetItems()) == 0 {
 		return &pb.ReserveItemsResponse{OrderId: req.GetOrderId(), Success: false, ErrorMessage: "no items to reserve"}, nil
 	}
+	for sku, qty := range req.GetItems() {
+		if sku == "" || qty <= 0 {
+			return &pb.ReserveItemsResponse{OrderId: req.GetOrderId(), Success: false, ErrorMessage: fmt.Sprintf("invalid quantity %d for sku %q", qty, sku)}, nil
+		}
+	}
+	partial := req.GetMode() == pb.ReserveMode_RESERVE_MODE_PARTIAL
 
-	reserved, err := h.store.ReserveStock(ctx, req.GetItems())
+	reservation, created, err := h.store.ReserveStock(ctx, req.GetOrderId(), req.GetItems(), h.reservationTTL, partial)
 	if err != nil {
 		log.Printf("[inventory] reserve db error order=%s err=%v", req.GetOrderId(), err)
 		return nil, err
 	}
-	log.Printf("[inventory] reserve result order=%s reserved=%v", req.GetOrderId(), reserved)
-
-	allReserved := true
-	for sku, wanted := range req.GetItems() {
-		if reserved[sku] < wanted {
-			allReserved = false
-			break
-		}
+	if !created {
+		// Same order_id again: report the first reserve's outcome instead of reserving twice.
+		log.Printf("[inventory] reserve replayed order=%s status=%s", req.GetOrderId(), reservation.Status)
+		resp := reserveResponse(reservation)
+		h.addSubstitutes(ctx, resp)
+		h.addQuote(ctx, resp)
+		return resp, nil
 	}
+	log.Printf("[inventory] reserve result order=%s partial=%t lines=%v", req.GetOrderId(), partial, reservation.Lines)
+	logLowStock(reservation.LowStock)
 
-	if !allReserved {
-		if len(reserved) > 0 {
-			_ = h.store.ReleaseStock(ctx, reserved)
-		}
+	if reservation.Status == store.ReservationRejected {
 		log.Printf("[inventory] reserve rejected order=%s reason=insufficient_stock", req.GetOrderId())
-		return &pb.ReserveItemsResponse{
-			OrderId:      req.GetOrderId(),
-			Success:      false,
-			ErrorMessage: "insufficient stock",
-		}, nil
+	} else {
+		log.Printf("[inventory] reserve success order=%s expires_at=%s", req.GetOrderId(), reservation.ExpiresAt.Format(time.RFC3339))
+	}
+	resp := reserveResponse(reservation)
+	h.addSubstitutes(ctx, resp)
+	h.addQuote(ctx, resp)
+	return resp, nil
+}
+
+// reserveResponse describes a reservation's outcome the same way on first and repeated reserves.
+func reserveResponse(r *store.Reservation) *pb.ReserveItemsResponse {
+	resp := &pb.ReserveItemsResponse{OrderId: r.OrderID, ExpiresAt: timestamppb.New(r.ExpiresAt)}
+	for _, sku := range slices.Sorted(maps.Keys(r.Lines)) {
+		line := r.Lines[sku]
+		resp.Lines = append(resp.Lines, &pb.ReserveLine{
+			Sku:       sku,
+			Requested: line.Requested,
+			Available: line.Available,
+			Reserved:  line.Reserved,
+			Shortfall: line.Shortfall(),
+		})
 	}
-	log.Printf("[inventory] reserve success order=%s", req.GetOrderId())
 
-	return &pb.ReserveItemsResponse{OrderId: req.GetOrderId(), Success: true}, nil
+	switch {
+	case r.Status == store.ReservationRejected:
+		resp.ErrorMessage = "insufficient stock"
+	case r.Live(time.Now()):
+		resp.Success = true
+	case r.Status == store.ReservationReleased:
+		resp.ErrorMessage = "reservation released"
+	default:
+		resp.ErrorMessage = "reservation expired"
+	}
+	return resp
 }
 
-// ReleaseItems restores previously reserved stock quantities.
+// ReleaseItems returns exactly what the order holds to stock; the caller's item map is ignored.
 func (h *InventoryHandler) ReleaseItems(ctx context.Context, req *pb.ReleaseItemsRequest) (*pb.ReleaseItemsResponse, error) {
 	log.Printf("[inventory] release request order=%s items=%v", req.GetOrderId(), req.GetItems())
-	if len(req.GetItems()) == 0 {
-		return &pb.ReleaseItemsResponse{Success: true}, nil
+	if req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
 	}
 
-	if err := h.store.ReleaseStock(ctx, req.GetItems()); err != nil {
+	released, err := h.store.ReleaseReservation(ctx, req.GetOrderId())
+	if errors.Is(err, store.ErrReservationCommitted) {
+		log.Printf("[inventory] release rejected order=%s reason=already_dispatched", req.GetOrderId())
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is already dispatched", req.GetOrderId())
+	} else if err != nil {
 		log.Printf("[inventory] release failed order=%s err=%v", req.GetOrderId(), err)
 		return nil, err
 	}
-	log.Printf("[inventory] release success order=%s", req.GetOrderId())
+	log.Printf("[inventory] release success order=%s released=%v", req.GetOrderId(), released)
 
-	return &pb.ReleaseItemsResponse{Success: true}, nil
+	return &pb.ReleaseItemsResponse{Success: true, Released: released}, nil
 }
 
 // ProcessCustomerOrder persists cart items and dispatches robots for client orders.
 func (h *InventoryHandler) ProcessCustomerOrder(ctx context.Context, req *pb.ProcessCustomerOrderRequest) (*pb.ProcessCustomerOrderResponse, error) {
 	orderID := req.GetOrderId()
 	log.Printf("[inventory] INFO processing customer order=%s", orderID)
-	log.Printf("[inventory] process-customer order=%s items=%v", orderID, req.GetItems())
+	log.Printf("[inventory] process-customer order=%s items=%v quote=%s", orderID, req.GetItems(), req.GetQuoteId())
+
+	// The order is what its reservation holds. Settlement releases unpicked units against these quantities,
+	// so trusting the caller's would let a dispatch return units the order never took.
+	items, err := h.store.HeldItems(ctx, orderID)
+	if h.dispatchedAlready(ctx, orderID, err) {
+		log.Printf("[inventory] process-customer repeated order=%s (already dispatched)", orderID)
+		return customerOrderDispatched(), nil
+	} else if errors.Is(err, store.ErrReservationNotHeld) {
+		log.Printf("[inventory] process-customer rejected order=%s reason=reservation_not_held", orderID)
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s is expired or released", orderID)
+	} else if err != nil {
+		log.Printf("[inventory] failed to load reservation order=%s err=%v", orderID, err)
+		return nil, err
+	}
+	if !maps.Equal(items, req.GetItems()) {
+		log.Printf("[inventory] process-customer rejected order=%s reason=items_mismatch held=%v", orderID, items)
+		return nil, status.Errorf(codes.InvalidArgument, "items for order %s do not match its reservation %v", orderID, items)
+	}
 
-	// 1. Save to Redis DB 0 (Client)
-	if err := h.memoryStore.SaveOrderItems(ctx, orderID, req.GetItems()); err != nil {
+	// 1. Save to Redis (order:CUSTOMER:<id>:items)
+	if err := h.memoryStore.SaveOrderItems(ctx, orderID, items); err != nil {
 		log.Printf("[inventory] failed to save order in redis order=%s err=%v", orderID, err)
+		h.discardDispatch(ctx, orderID)
 		return nil, err
 	}
 
 	// 2. Fetch Aisle info from DB
-	robotItems := h.prepareRobotItems(ctx, req.GetItems())
+	robotItems := h.prepareRobotItems(ctx, orderID, items)
+	expected, err := h.trackExpectedReports(ctx, orderID, false, robotItems)
+	if err != nil {
+		log.Printf("[inventory] failed to save expected reports order=%s err=%v", orderID, err)
+		h.discardDispatch(ctx, orderID)
+		return nil, err
+	}
+
+	// The hold becomes a commitment together with the workflow that will settle it. If either fails the
+	// hold stays HELD, so the client can release it or it expires, and nothing is dispatched.
+	err = h.workflows.CommitCustomerWorkflow(ctx, &store.Workflow{
+		OrderID:         orderID,
+		OrderType:       store.OrderTypeCustomer,
+		Items:           items,
+		QuoteID:         req.GetQuoteId(),
+		RobotItems:      robotItems,
+		ExpectedReports: expected,
+	})
+	if err != nil {
+		h.discardDispatch(ctx, orderID)
+	}
+	if h.dispatchedAlready(ctx, orderID, err) {
+		// A concurrent confirm of the same order committed it first and dispatches its robots.
+		log.Printf("[inventory] process-customer repeated order=%s (dispatched concurrently)", orderID)
+		return customerOrderDispatched(), nil
+	} else if errors.Is(err, store.ErrReservationNotHeld) {
+		log.Printf("[inventory] process-customer rejected order=%s reason=reservation_not_held", orderID)
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation for order %s is expired or released", orderID)
+	} else if err != nil {
+		log.Printf("[inventory] failed to commit order order=%s err=%v", orderID, err)
+		return nil, err
+	}
 
 	// 3. Dispatch Robots
-	h.assignRobots(orderID, "CUSTOMER", robotItems)
+	h.assignRobots(ctx, orderID, "CUSTOMER", robotItems)
 	log.Printf("[inventory] process-customer dispatched order=%s", orderID)
 
+	return customerOrderDispatched(), nil
+}
+
+// customerOrderDispatched is the answer to every confirm of a dispatched client order, retries included.
+func customerOrderDispatched() *pb.ProcessCustomerOrderResponse {
 	return &pb.ProcessCustomerOrderResponse{
 		Success: true,
 		Message: "Customer order processed and robots dispatched.",
-	}, nil
+	}
+}
+
+// dispatchedAlready reports whether a reservation error only means an earlier confirm of the order already
+// committed it: the reservation is COMMITTED or the order has a workflow.
+func (h *InventoryHandler) dispatchedAlready(ctx context.Context, orderID string, err error) bool {
+	if errors.Is(err, store.ErrReservationCommitted) {
+		return true
+	}
+	if !errors.Is(err, store.ErrReservationNotHeld) {
+		return false
+	}
+	_, wfErr := h.workflows.GetWorkflow(ctx, store.OrderTypeCustomer, orderID)
+	return wfErr == nil
+}
+
+// discardDispatch drops the redis state of a client order whose dispatch failed before its workflow was
+// recorded. A concurrent dispatch of the same order that did record one keeps its state.
+func (h *InventoryHandler) discardDispatch(ctx context.Context, orderID string) {
+	if _, err := h.workflows.GetWorkflow(ctx, store.OrderTypeCustomer, orderID); !errors.Is(err, store.ErrWorkflowNotFound) {
+		return
+	}
+	h.memoryStore.DeleteOrderData(ctx, orderID, false)
 }
 
 // RestockItemsOrder persists restock payloads and dispatches robots.
@@ -158,7 +290,7 @@ func (h *InventoryHandler) RestockItemsOrder(ctx context.Context, req *pb.Restoc
 	orderID := req.GetOrderId()
 	log.Printf("[inventory] INFO processing restock order=%s", orderID)
 
-	// 1. Save to Redis DB 1 (Restock)
+	// 1. Save to Redis (order:RESTOCK:<id>:items)
 	if err := h.memoryStore.SaveRestockItems(ctx, orderID, req.GetItems()); err != nil {
 		return nil, err
 	}
@@ -171,17 +303,36 @@ func (h *InventoryHandler) RestockItemsOrder(ctx context.Context, req *pb.Restoc
 			Aisle:    item.GetAisleType(),
 		}
 	}
+	expected, err := h.trackExpectedReports(ctx, orderID, true, robotItems)
+	if err != nil {
+		log.Printf("[inventory] failed to save expected reports order=%s err=%v", orderID, err)
+		return nil, err
+	}
+	if err := h.workflows.CreateWorkflow(ctx, &store.Workflow{
+		OrderID:         orderID,
+		OrderType:       store.OrderTypeRestock,
+		RestockItems:    req.GetItems(),
+		RobotItems:      robotItems,
+		ExpectedReports: expected,
+	}); err != nil {
+		log.Printf("[inventory] failed to record workflow order=%s err=%v", orderID, err)
+		return nil, err
+	}
 
 	// 3. Dispatch Robots
-	h.assignRobots(orderID, "RESTOCK", robotItems)
+	h.assignRobots(ctx, orderID, "RESTOCK", robotItems)
 
 	return &pb.RestockItemsOrderResponse{Success: true}, nil
 }
 
-// assignRobots broadcasts robot work assignments for an order.
-func (h *InventoryHandler) assignRobots(orderID string, orderType string, items map[string]mq.ItemDetails) {
+// assignRobots records the order's aisle tasks in the dispatch ledger and broadcasts them to robots.
+func (h *InventoryHandler) assignRobots(ctx context.Context, orderID string, orderType string, items map[string]mq.ItemDetails) {
 	log.Printf("[inventory] INFO dispatching robots type=%s order=%s", orderType, orderID)
 	log.Printf("[inventory] dispatch order=%s type=%s items=%v", orderID, orderType, items)
+	// Record before sending so an immediate acknowledgement always finds its task.
+	if err := h.memoryStore.SaveDispatchLedger(ctx, orderID, orderType == "RESTOCK", items, mq.AisleSet(items), time.Now()); err != nil {
+		log.Printf("[inventory] WARN dispatch ledger write failed order=%s err=%v (no redelivery)", orderID, err)
+	}
 	if err := h.publisher.SendRobotCommand(orderID, orderType, items); err != nil {
 		log.Printf("[inventory] ERROR zmq broadcast failed order=%s err=%v", orderID, err)
 	}
@@ -191,193 +342,139 @@ func (h *InventoryHandler) assignRobots(orderID string, orderType string, items
 func (h *InventoryHandler) ReportJobStatus(ctx context.Context, req *pb.ReportJobStatusRequest) (*pb.ReportJobStatusResponse, error) {
 	orderID := req.GetOrderId()
 	orderType := req.GetOrderType()
-	status := req.GetStatus()
-
-	// Explicit check based on the type reported by the robot
-	isRestock := (orderType == "RESTOCK")
-
-	var count int64
-	var err error
 
-	if isRestock {
-		count, err = h.memoryStore.IncrementRestockRobotCount(ctx, orderID)
-	} else {
-		count, err = h.memoryStore.IncrementClientRobotCount(ctx, orderID)
-	}
+	// The robot echoes the order type it was dispatched with; anything else cannot name an order.
+	isRestock, err := parseOrderType(orderType)
 	if err != nil {
-		log.Printf("[inventory] ERROR failed to increment robot count order=%s type=%s err=%v", orderID, orderType, err)
+		log.Printf("[inventory] WARN rejected robot status order=%s robot=%s err=%v", orderID, req.GetRobotId(), err)
 		return nil, err
 	}
 
-	log.Printf("[inventory] robot status received order=%s type=%s status=%s count=%d", orderID, orderType, status, count)
-
-	if count >= 5 {
-		marked, markErr := h.memoryStore.TryMarkOrderFinalized(ctx, orderID, isRestock)
-		if markErr != nil {
-			log.Printf("[inventory] ERROR failed to mark order finalized order=%s err=%v", orderID, markErr)
-			return nil, markErr
-		}
-		if marked {
-			log.Printf("[inventory] INFO robot threshold reached order=%s finalizing", orderID)
-			go h.finalizeOrder(orderID, isRestock)
-		}
+	jobStatus, err := parseJobStatus(req)
+	if err != nil {
+		log.Printf("[inventory] WARN rejected robot status order=%s robot=%s err=%v", orderID, req.GetRobotId(), err)
+		return nil, err
 	}
-
-	return &pb.ReportJobStatusResponse{Success: true}, nil
-}
-
-// finalizeOrder routes to client or restock finalization workflow.
-func (h *InventoryHandler) finalizeOrder(orderID string, isRestock bool) {
-	if isRestock {
-		h.finalizeRestock(orderID)
-	} else {
-		h.finalizeClientOrder(orderID)
+	status := jobStatusName(jobStatus)
+
+	aisles, expected, err := h.expectedReports(ctx, orderID, isRestock)
+	if errors.Is(err, store.ErrOrderNotTracked) {
+		log.Printf("[inventory] WARN robot status for untracked order=%s type=%s aisle=%s", orderID, orderType, req.GetAisle())
+		return &pb.ReportJobStatusResponse{Success: false}, nil
+	} else if err != nil {
+		log.Printf("[inventory] ERROR failed to load expected reports order=%s type=%s err=%v", orderID, orderType, err)
+		return nil, err
 	}
-}
 
-// finalizeRestock updates stock, pricing metrics, ordering status, and cache cleanup.
-func (h *InventoryHandler) finalizeRestock(orderID string) {
-	ctx := context.Background()
-	items, err := h.memoryStore.GetRestockItems(ctx, orderID)
-	if err != nil {
-		log.Printf("Finalize Restock Error: Could not find items for %s: %v", orderID, err)
-		return
+	if !countsTowardOrder(req, jobStatus, aisles) {
+		log.Printf("[inventory] robot status ignored order=%s type=%s aisle=%s status=%s (aisle not needed)", orderID, orderType, req.GetAisle(), status)
+		return &pb.ReportJobStatusResponse{Success: true}, nil
 	}
 
-	var totalCost float64
-	latestUnitCostBySKU := make(map[string]float64)
-	updatedSKUs := make(map[string]struct{})
-
-	for _, pi := range items {
-		totalCost += pi.GetUnitCost() * float64(pi.GetQuantity())
-
-		// Convert Proto Timestamp to Go Time
-		mfd := pi.GetMfdDate().AsTime()
-		expiry := pi.GetExpiryDate().AsTime()
+	// A status report also proves the robot received its aisle task.
+	h.ackDispatch(ctx, orderID, isRestock, req.GetAisle(), req.GetRobotId())
 
-		err := h.store.UpsertStock(ctx, store.StockItem{
-			SKU:        pi.GetSku(),
-			Name:       pi.GetName(),
-			AisleType:  pi.GetAisleType(),
-			Quantity:   int(pi.GetQuantity()),
-			UnitCost:   pi.GetUnitCost(),
-			MfdDate:    mfd,
-			ExpiryDate: expiry,
-		})
-		if err != nil {
-			log.Printf("Failed to upsert stock for %s: %v", pi.GetSku(), err)
-			continue
+	// A failed aisle never counts as a report; it is retried or fails the whole order.
+	if jobStatus == pb.JobStatus_JOB_STATUS_FAILED {
+		if err := h.handleFailedAisle(ctx, req, isRestock); err != nil {
+			return nil, err
 		}
-
-		latestUnitCostBySKU[pi.GetSku()] = pi.GetUnitCost()
-		updatedSKUs[pi.GetSku()] = struct{}{}
+		return &pb.ReportJobStatusResponse{Success: true}, nil
 	}
 
-	var updatedSKUList []string
-	for sku := range updatedSKUs {
-		updatedSKUList = append(updatedSKUList, sku)
+	// NO_OP reports complete the aisle without handling anything.
+	picked := req.GetProcessedItems()
+	if jobStatus == pb.JobStatus_JOB_STATUS_NO_OP {
+		picked = nil
 	}
-
-	var pricingUpdates []*pb.StockMetric
-	if len(updatedSKUList) > 0 {
-		currentItems, batchErr := h.store.GetBatchItems(ctx, updatedSKUList)
-		if batchErr != nil {
-			log.Printf("[inventory] WARN failed to fetch current stock for pricing updates err=%v", batchErr)
-		} else {
-			for _, sku := range updatedSKUList {
-				currentItem, exists := currentItems[sku]
-				if !exists {
-					log.Printf("[inventory] WARN missing current stock row sku=%s during pricing update", sku)
-					continue
-				}
-
-				pricingUpdates = append(pricingUpdates, &pb.StockMetric{
-					Sku:      sku,
-					Quantity: int32(currentItem.Quantity),
-					UnitCost: latestUnitCostBySKU[sku],
-				})
-			}
-		}
+	// Reconcile against the robot's own aisle task so it can neither over-report nor skip a line unnoticed.
+	if assignment, err := h.robotAssignment(ctx, orderID, isRestock); err != nil {
+		log.Printf("[inventory] WARN aisle assignment unavailable order=%s type=%s err=%v (report taken as sent)", orderID, orderType, err)
+	} else if len(assignment) > 0 {
+		picked = aisleReport(assignment, req.GetAisle(), picked)
 	}
 
-	go func(metrics []*pb.StockMetric) {
-		if len(metrics) == 0 {
-			return
-		}
-		bgCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		_, err := h.pricingClient.UpdateStockMetrics(bgCtx, &pb.UpdateStockMetricsRequest{Updates: metrics})
-		if err != nil {
-			log.Printf("[inventory] WARN async pricing metric update failed err=%v", err)
+	// One atomic step: retries and duplicate callbacks from the same robot never advance progress twice,
+	// and exactly one report claims finalization.
+	outcome, err := h.memoryStore.RecordRobotReport(ctx, orderID, isRestock, req.GetRobotId(), picked)
+	if errors.Is(err, store.ErrOrderNotTracked) {
+		// Finalized and cleaned up since the expected reports were read.
+		log.Printf("[inventory] WARN robot status for untracked order=%s type=%s aisle=%s", orderID, orderType, req.GetAisle())
+		return &pb.ReportJobStatusResponse{Success: false}, nil
+	} else if err != nil {
+		log.Printf("[inventory] ERROR failed to record robot report order=%s type=%s err=%v", orderID, orderType, err)
+		return nil, err
+	}
+	if outcome.Duplicate {
+		log.Printf("[inventory] duplicate robot status ignored order=%s type=%s robot=%s status=%s", orderID, orderType, req.GetRobotId(), status)
+		if outcome.Finalize {
+			// The report that completed the order failed to claim it; this retry claims it again.
+			return h.finalizeReportedOrder(ctx, orderID, isRestock)
 		}
-	}(pricingUpdates)
-
-	h.callWebhook(orderID, totalCost, h.restockWebhookURL, "total_cost")
-	h.memoryStore.DeleteOrderData(ctx, orderID, true)
-}
-
-// finalizeClientOrder computes final bill, updates ordering status, and clears cache state.
-func (h *InventoryHandler) finalizeClientOrder(orderID string) {
-	log.Printf("[inventory] finalize-client start order=%s", orderID)
-	ctx := context.Background()
-	items, err := h.memoryStore.GetOrderItems(ctx, orderID)
-	if err != nil {
-		log.Printf("Finalize Client Order Error: %v", err)
-		return
+		return &pb.ReportJobStatusResponse{Success: true}, nil
 	}
-	log.Printf("[inventory] finalize-client redis items order=%s items=%v", orderID, items)
-
-	var cartItems []*pb.CartItem
-	for sku, qty := range items {
-		cartItems = append(cartItems, &pb.CartItem{Sku: sku, Quantity: qty})
+	count, expected := outcome.Count, outcome.Expected
+
+	log.Printf("[inventory] robot status received order=%s type=%s robot=%s aisle=%s status=%s count=%d/%d", orderID, orderType, req.GetRobotId(), req.GetAisle(), status, count, expected)
+	h.publishProgress(ctx, isRestock, &pb.OrderProgressEvent{
+		OrderId:         orderID,
+		EventType:       progressRobotReport,
+		Aisle:           req.GetAisle(),
+		RobotId:         req.GetRobotId(),
+		Status:          status,
+		ProcessedItems:  picked,
+		ReportsReceived: int32(count),
+		ReportsExpected: int32(expected),
+	})
+
+	// The workflow row is what a restart resumes from; redis stays the source of truth while running.
+	if err := h.workflows.RecordWorkflowReport(ctx, orderTypeFor(isRestock), orderID, req.GetRobotId(), picked); err != nil && !errors.Is(err, store.ErrWorkflowNotFound) {
+		log.Printf("[inventory] WARN workflow report write failed order=%s type=%s err=%v", orderID, orderType, err)
 	}
 
-	resp, err := h.pricingClient.CalculateBill(ctx, &pb.CalculateBillRequest{Items: cartItems})
-	finalPrice := 0.0
-	if err == nil {
-		finalPrice = resp.GetGrandTotal()
-		log.Printf("[inventory] pricing bill success order=%s total=%.2f", orderID, finalPrice)
-	} else {
-		log.Printf("[inventory] pricing bill failed order=%s err=%v", orderID, err)
+	if outcome.Finalize {
+		return h.finalizeReportedOrder(ctx, orderID, isRestock)
 	}
-
-	h.callWebhook(orderID, finalPrice, h.orderWebhookURL, "total_price")
-	h.memoryStore.DeleteOrderData(ctx, orderID, false)
-	log.Printf("[inventory] finalize-client cleanup complete order=%s", orderID)
+	return &pb.ReportJobStatusResponse{Success: true}, nil
 }
 
-// callWebhook posts completion updates to ordering internal endpoints.
-func (h *InventoryHandler) callWebhook(orderID string, price float64, url string, priceKey string) {
-	payload := map[string]interface{}{
-		"order_id": orderID,
-		"status":   "COMPLETED",
-		priceKey:   price,
-	}
-	jsonBytes, _ := json.Marshal(payload)
-	log.Printf("[inventory] webhook sending order=%s url=%s payload=%s", orderID, url, string(jsonBytes))
-
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonBytes))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Internal-Secret", os.Getenv("INTERNAL_SECRET"))
-
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
+// finalizeReportedOrder claims the workflow of an order whose last report claimed finalization and finishes it.
+func (h *InventoryHandler) finalizeReportedOrder(ctx context.Context, orderID string, isRestock bool) (*pb.ReportJobStatusResponse, error) {
+	wf, err := h.claimWorkflow(ctx, orderID, isRestock, "", "")
 	if err != nil {
-		log.Printf("Webhook failed for %s: %v", orderID, err)
-		return
+		log.Printf("[inventory] ERROR failed to claim order finalization order=%s err=%v", orderID, err)
+		return nil, err
+	}
+	if wf != nil {
+		log.Printf("[inventory] INFO robot threshold reached order=%s finalizing", orderID)
+		go h.finishWorkflow(context.Background(), wf)
 	}
-	defer resp.Body.Close()
-	log.Printf("[inventory] webhook response order=%s url=%s status=%d", orderID, url, resp.StatusCode)
+	return &pb.ReportJobStatusResponse{Success: true}, nil
 }
 
-// prepareRobotItems joins sku quantities with aisle metadata for robot routing.
-func (h *InventoryHandler) prepareRobotItems(ctx context.Context, items map[string]int32) map[string]mq.ItemDetails {
+// webhookUpdate is the final order state inventory reports to ordering.
+type webhookUpdate struct {
+	OrderID string
+	Status  string
+	Amount  float64 // total_price for client orders, total_cost for restocks
+	Lines   []fulfillmentLine
+	Reason  string
+	// ReasonCode classifies FAILED updates, e.g. ROBOT_FAILED or SLA_TIMEOUT.
+	ReasonCode string
+}
+
+// prepareRobotItems joins sku quantities with aisle metadata and the order's reserved lots for robot routing.
+func (h *InventoryHandler) prepareRobotItems(ctx context.Context, orderID string, items map[string]int32) map[string]mq.ItemDetails {
 	skus := make([]string, 0, len(items))
 	for sku := range items {
 		skus = append(skus, sku)
 	}
 
 	dbItems, _ := h.store.GetBatchItems(ctx, skus)
+	reservedLots, err := h.store.GetReservedLots(ctx, orderID)
+	if err != nil {
+		log.Printf("[inventory] WARN reserved lot lookup failed order=%s err=%v (dispatching without lots)", orderID, err)
+	}
 	robotItems := make(map[string]mq.ItemDetails)
 	for sku, qty := range items {
 		aisle := "Unknown"
@@ -386,7 +483,11 @@ func (h *InventoryHandler) prepareRobotItems(ctx context.Context, items map[stri
 		} else {
 			log.Printf("[inventory] aisle lookup missing sku=%s, defaulting Unknown", sku)
 		}
-		robotItems[sku] = mq.ItemDetails{Quantity: qty, Aisle: aisle}
+		var lots []mq.LotPick
+		for _, lot := range reservedLots[sku] {
+			lots = append(lots, mq.LotPick{LotID: lot.LotID, Quantity: lot.Quantity})
+		}
+		robotItems[sku] = mq.ItemDetails{Quantity: qty, Aisle: aisle, Lots: lots}
 	}
 	log.Printf("[inventory] prepared robot map items=%v", robotItems)
 	return robotItems
@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc"
+)
+
+func TestReportJobStatusFinalizesClientOrder(t *testing.T) {
+	type report struct {
+		robotID string
+		aisle   string
+		status  pb.JobStatus
+		items   map[string]int32
+	}
+	tests := []struct {
+		name     string
+		reports  []report
+		status   string
+		picked   map[string]int32
+		billed   map[string]int32
+		released map[string]int32
+	}{
+		{
+			name: "every robot confirms its aisle",
+			reports: []report{
+				{"robot-1", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+				{"robot-2", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+				{"robot-3", "dairy", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"milk": 2}},
+			},
+			status: statusCompleted,
+			picked: map[string]int32{"apple": 3, "milk": 2},
+			billed: map[string]int32{"apple": 3, "milk": 2},
+		},
+		{
+			name: "retried callback counts once",
+			reports: []report{
+				{"robot-1", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+				{"robot-1", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+				{"robot-3", "dairy", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"milk": 2}},
+				{"robot-2", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+			},
+			status: statusCompleted,
+			picked: map[string]int32{"apple": 3, "milk": 2},
+			billed: map[string]int32{"apple": 3, "milk": 2},
+		},
+		{
+			name: "over-report does not hide a short pick on the same aisle",
+			reports: []report{
+				{"robot-1", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 5}},
+				{"robot-2", "produce", pb.JobStatus_JOB_STATUS_PARTIAL, map[string]int32{"apple": 1}},
+				{"robot-3", "dairy", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"milk": 2}},
+			},
+			status:   statusPartiallyFulfilled,
+			picked:   map[string]int32{"apple": 1, "milk": 2},
+			billed:   map[string]int32{"apple": 1, "milk": 2},
+			released: map[string]int32{"apple": 2},
+		},
+		{
+			name: "skus outside the robot's aisle are not credited",
+			reports: []report{
+				{"robot-1", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3, "milk": 2}},
+				{"robot-2", "produce", pb.JobStatus_JOB_STATUS_SUCCESS, map[string]int32{"apple": 3}},
+				{"robot-3", "dairy", pb.JobStatus_JOB_STATUS_NO_OP, nil},
+			},
+			status:   statusPartiallyFulfilled,
+			picked:   map[string]int32{"apple": 3, "milk": 0},
+			billed:   map[string]int32{"apple": 3},
+			released: map[string]int32{"milk": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			const orderID = "order-1"
+			items := map[string]int32{"apple": 3, "milk": 2}
+			robotItems := map[string]mq.ItemDetails{
+				"apple": {Quantity: 3, Aisle: "produce"},
+				"milk":  {Quantity: 2, Aisle: "dairy"},
+			}
+
+			ms := store.NewLocalMemoryStore()
+			ms.SaveOrderItems(ctx, orderID, items)
+			ms.SaveDispatchLedger(ctx, orderID, false, robotItems, mq.AisleSet(robotItems), time.Now())
+			ms.SaveExpectedReports(ctx, orderID, false, mq.AisleSet(robotItems), 3)
+
+			webhooks := make(chan map[string]any, 1)
+			ordering := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var update map[string]any
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &update); err != nil {
+					t.Errorf("webhook payload %q: %v", body, err)
+				}
+				webhooks <- update
+			}))
+			defer ordering.Close()
+
+			workflows := newFakeWorkflows(&store.Workflow{
+				OrderID:         orderID,
+				OrderType:       store.OrderTypeCustomer,
+				Stage:           store.WorkflowPicking,
+				Items:           items,
+				RobotItems:      robotItems,
+				ExpectedReports: 3,
+			})
+			pricing := &fakePricing{bills: make(chan map[string]int32, 1)}
+			h := NewInventoryHandler(nil, workflows, ms, nil, pricing, ordering.URL, ordering.URL,
+				time.Minute, WebhookRetryPolicy{MaxAttempts: 1, BaseBackoff: time.Second, MaxBackoff: time.Second}, 3, time.Minute, 0)
+
+			for i, r := range tt.reports {
+				if workflows.claims() != 0 {
+					t.Fatalf("order claimed before report %d", i)
+				}
+				resp, err := h.ReportJobStatus(ctx, &pb.ReportJobStatusRequest{
+					OrderId:        orderID,
+					OrderType:      "CUSTOMER",
+					RobotId:        r.robotID,
+					Aisle:          r.aisle,
+					JobStatus:      r.status,
+					ProcessedItems: r.items,
+				})
+				if err != nil || !resp.GetSuccess() {
+					t.Fatalf("ReportJobStatus report %d = %v, %v", i, resp, err)
+				}
+			}
+			if workflows.claims() != 1 {
+				t.Fatalf("order claimed %d times, want 1", workflows.claims())
+			}
+
+			select {
+			case billed := <-pricing.bills:
+				if !maps.Equal(billed, tt.billed) {
+					t.Errorf("billed %v, want %v", billed, tt.billed)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("order was never billed")
+			}
+			select {
+			case update := <-webhooks:
+				if update["order_id"] != orderID || update["status"] != tt.status {
+					t.Errorf("webhook = %v, want order %s status %s", update, orderID, tt.status)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("ordering was never notified")
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			for workflows.stage(store.OrderTypeCustomer, orderID) != store.WorkflowDone {
+				if time.Now().After(deadline) {
+					t.Fatal("workflow never reached DONE")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			wf, _ := workflows.GetWorkflow(ctx, store.OrderTypeCustomer, orderID)
+			if !maps.Equal(wf.Picked, tt.picked) {
+				t.Errorf("claimed picked = %v, want %v", wf.Picked, tt.picked)
+			}
+			if released := workflows.settledRelease(); !maps.Equal(released, tt.released) {
+				t.Errorf("released %v, want %v", released, tt.released)
+			}
+			if _, _, err := ms.GetExpectedReports(ctx, orderID, false); !errors.Is(err, store.ErrOrderNotTracked) {
+				t.Errorf("redis state kept after finalize, err = %v", err)
+			}
+		})
+	}
+}
+
+// fakePricing bills every sku at one unit and records what it billed.
+type fakePricing struct {
+	pb.PricingServiceClient
+	bills chan map[string]int32
+}
+
+func (p *fakePricing) CalculateBill(ctx context.Context, req *pb.CalculateBillRequest, opts ...grpc.CallOption) (*pb.CalculateBillResponse, error) {
+	billed := make(map[string]int32)
+	total := 0.0
+	for _, item := range req.GetItems() {
+		billed[item.GetSku()] += item.GetQuantity()
+		total += float64(item.GetQuantity())
+	}
+	p.bills <- billed
+	return &pb.CalculateBillResponse{GrandTotal: total}, nil
+}
+
+// fakeWorkflows keeps workflows in memory with the stage transitions of the postgres store and counts claims.
+type fakeWorkflows struct {
+	mu        sync.Mutex
+	workflows map[string]*store.Workflow
+	claimed   int
+	released  map[string]int32
+	webhooks  int64
+}
+
+var _ store.WorkflowStore = (*fakeWorkflows)(nil)
+
+func newFakeWorkflows(wfs ...*store.Workflow) *fakeWorkflows {
+	f := &fakeWorkflows{workflows: make(map[string]*store.Workflow)}
+	for _, wf := range wfs {
+		f.CreateWorkflow(context.Background(), wf)
+	}
+	return f
+}
+
+func (f *fakeWorkflows) claims() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.claimed
+}
+
+func (f *fakeWorkflows) settledRelease() map[string]int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.released
+}
+
+func (f *fakeWorkflows) stage(orderType, orderID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if wf, ok := f.workflows[orderType+":"+orderID]; ok {
+		return wf.Stage
+	}
+	return ""
+}
+
+// lookup returns the stored workflow when it is in one of stages, or any stage when none are given.
+func (f *fakeWorkflows) lookup(orderType, orderID string, stages ...string) (*store.Workflow, error) {
+	wf, ok := f.workflows[orderType+":"+orderID]
+	if !ok || (len(stages) > 0 && !slices.Contains(stages, wf.Stage)) {
+		return nil, store.ErrWorkflowNotFound
+	}
+	return wf, nil
+}
+
+func (f *fakeWorkflows) CreateWorkflow(ctx context.Context, wf *store.Workflow) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := wf.OrderType + ":" + wf.OrderID
+	if _, ok := f.workflows[key]; ok {
+		return nil
+	}
+	stored := *wf
+	if stored.Stage == "" {
+		stored.Stage = store.WorkflowDispatched
+	}
+	stored.Picked = maps.Clone(wf.Picked)
+	if stored.Picked == nil {
+		stored.Picked = make(map[string]int32)
+	}
+	stored.DispatchedAt = time.Now()
+	f.workflows[key] = &stored
+	return nil
+}
+
+func (f *fakeWorkflows) CommitCustomerWorkflow(ctx context.Context, wf *store.Workflow) error {
+	return f.CreateWorkflow(ctx, wf)
+}
+
+func (f *fakeWorkflows) GetWorkflow(ctx context.Context, orderType string, orderID string) (*store.Workflow, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(orderType, orderID)
+	if err != nil {
+		return nil, err
+	}
+	loaded := *wf
+	loaded.Picked = maps.Clone(wf.Picked)
+	loaded.Reporters = slices.Clone(wf.Reporters)
+	return &loaded, nil
+}
+
+func (f *fakeWorkflows) ListOpenWorkflows(ctx context.Context) ([]*store.Workflow, error) {
+	f.mu.Lock()
+	var open []*store.Workflow
+	for _, wf := range f.workflows {
+		if wf.Stage != store.WorkflowDone && wf.Stage != store.WorkflowDead {
+			open = append(open, wf)
+		}
+	}
+	f.mu.Unlock()
+
+	var loaded []*store.Workflow
+	for _, wf := range open {
+		if wf, err := f.GetWorkflow(ctx, wf.OrderType, wf.OrderID); err == nil {
+			loaded = append(loaded, wf)
+		}
+	}
+	return loaded, nil
+}
+
+func (f *fakeWorkflows) RecordWorkflowReport(ctx context.Context, orderType string, orderID string, robotID string, picked map[string]int32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(orderType, orderID, store.WorkflowDispatched, store.WorkflowPicking)
+	if err != nil {
+		return err
+	}
+	for sku, qty := range picked {
+		if current, ok := wf.Picked[sku]; !ok || qty < current {
+			wf.Picked[sku] = qty
+		}
+	}
+	wf.Stage = store.WorkflowPicking
+	wf.Reports++
+	if robotID != "" {
+		wf.Reporters = append(wf.Reporters, robotID)
+	}
+	return nil
+}
+
+func (f *fakeWorkflows) ClaimWorkflow(ctx context.Context, orderType string, orderID string, picked map[string]int32, failCode string, failReason string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(orderType, orderID)
+	if err != nil {
+		return false, err
+	}
+	if wf.Stage != store.WorkflowDispatched && wf.Stage != store.WorkflowPicking {
+		return false, nil
+	}
+	f.claimed++
+	wf.Stage = store.WorkflowBilling
+	if picked != nil {
+		wf.Picked = maps.Clone(picked)
+	}
+	wf.FailCode, wf.FailReason = failCode, failReason
+	return true, nil
+}
+
+func (f *fakeWorkflows) AbandonOrder(ctx context.Context, orderType string, orderID string, failCode string, failReason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if wf, err := f.lookup(orderType, orderID, store.WorkflowDispatched, store.WorkflowPicking); err == nil {
+		wf.Stage, wf.FailCode, wf.FailReason = store.WorkflowDead, failCode, failReason
+	}
+	return nil
+}
+
+func (f *fakeWorkflows) SettleClientOrder(ctx context.Context, orderID string, release map[string]int32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(store.OrderTypeCustomer, orderID, store.WorkflowBilling)
+	if err != nil || wf.StockSettled {
+		return err
+	}
+	f.released = maps.Clone(release)
+	wf.StockSettled = true
+	return nil
+}
+
+func (f *fakeWorkflows) SettleRestock(ctx context.Context, orderID string, lots []store.StockItem) ([]int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(store.OrderTypeRestock, orderID, store.WorkflowBilling)
+	if err != nil || wf.StockSettled {
+		return nil, err
+	}
+	lotIDs := make([]int64, len(lots))
+	for i := range lots {
+		lotIDs[i] = int64(i + 1)
+	}
+	wf.StockSettled = true
+	return lotIDs, nil
+}
+
+func (f *fakeWorkflows) ClaimDueSettlements(ctx context.Context, limit int, lease time.Duration) ([]*store.Workflow, error) {
+	return nil, nil
+}
+
+func (f *fakeWorkflows) MarkSettleFailed(ctx context.Context, orderType string, orderID string, lastError string, retryIn time.Duration, dead bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if wf, err := f.lookup(orderType, orderID, store.WorkflowBilling); err == nil {
+		wf.SettleAttempts++
+		if dead {
+			wf.Stage = store.WorkflowDead
+		}
+	}
+	return nil
+}
+
+func (f *fakeWorkflows) NotifyWorkflow(ctx context.Context, orderType string, orderID string, targetURL string, payload []byte, lease time.Duration) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, err := f.lookup(orderType, orderID, store.WorkflowBilling)
+	if err != nil {
+		return 0, err
+	}
+	wf.Stage = store.WorkflowNotified
+	f.webhooks++
+	return f.webhooks, nil
+}
+
+func (f *fakeWorkflows) MarkWebhookDelivered(ctx context.Context, id int64) error { return nil }
+
+func (f *fakeWorkflows) MarkWebhookFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error {
+	return nil
+}
+
+func (f *fakeWorkflows) CompleteWorkflow(ctx context.Context, orderType string, orderID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if wf, err := f.lookup(orderType, orderID, store.WorkflowNotified); err == nil {
+		wf.Stage = store.WorkflowDone
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultHistoryWindow = 30 * 24 * time.Hour
+	defaultHistoryLimit  = 500
+	maxHistoryLimit      = 5000
+)
+
+// GetStockHistory returns the journaled quantity changes of one sku within a time range.
+func (h *InventoryHandler) GetStockHistory(ctx context.Context, req *pb.GetStockHistoryRequest) (*pb.GetStockHistoryResponse, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+	to := time.Now().UTC()
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	from := to.Add(-defaultHistoryWindow)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	limit = min(limit, maxHistoryLimit)
+
+	movements, err := h.store.ListStockMovements(ctx, req.GetSku(), from, to, limit)
+	if err != nil {
+		log.Printf("[inventory] ERROR stock history failed sku=%s err=%v", req.GetSku(), err)
+		return nil, err
+	}
+
+	resp := &pb.GetStockHistoryResponse{}
+	for _, m := range movements {
+		resp.Movements = append(resp.Movements, &pb.StockMovement{
+			Id:           m.ID,
+			Sku:          m.SKU,
+			Delta:        int32(m.Delta),
+			Reason:       m.Reason,
+			OrderId:      m.OrderID,
+			BalanceAfter: int32(m.BalanceAfter),
+			At:           timestamppb.New(m.CreatedAt),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reason codes sent to ordering with FAILED and EXPIRED webhooks.
+const (
+	reasonRobotFailed        = "ROBOT_FAILED"
+	reasonSLATimeout         = "SLA_TIMEOUT"
+	reasonReservationExpired = "RESERVATION_EXPIRED"
+)
+
+// parseJobStatus reads the typed job status, falling back to the legacy status string.
+func parseJobStatus(req *pb.ReportJobStatusRequest) (pb.JobStatus, error) {
+	if js := req.GetJobStatus(); js != pb.JobStatus_JOB_STATUS_UNSPECIFIED {
+		if _, ok := pb.JobStatus_name[int32(js)]; !ok {
+			return js, status.Errorf(codes.InvalidArgument, "unknown job_status %d", js)
+		}
+		return js, nil
+	}
+	legacy := strings.ToUpper(strings.TrimSpace(req.GetStatus()))
+	if js, ok := pb.JobStatus_value["JOB_STATUS_"+legacy]; ok && legacy != "UNSPECIFIED" {
+		return pb.JobStatus(js), nil
+	}
+	return pb.JobStatus_JOB_STATUS_UNSPECIFIED, status.Errorf(codes.InvalidArgument, "unknown job status %q", req.GetStatus())
+}
+
+// jobStatusName returns the short status name (SUCCESS, NO_OP, FAILED, PARTIAL) used in logs and events.
+func jobStatusName(js pb.JobStatus) string {
+	return strings.TrimPrefix(js.String(), "JOB_STATUS_")
+}
+
+// handleFailedAisle re-dispatches a failed aisle task, or fails the order once the aisle's retries are spent.
+func (h *InventoryHandler) handleFailedAisle(ctx context.Context, req *pb.ReportJobStatusRequest, isRestock bool) error {
+	orderID, aisle := req.GetOrderId(), req.GetAisle()
+	orderType := orderTypeFor(isRestock)
+
+	if aisle == "" {
+		// Without an aisle there is nothing to re-dispatch.
+		return h.failFromRobot(ctx, orderID, isRestock, fmt.Sprintf("robot %s reported FAILED", req.GetRobotId()))
+	}
+
+	failures, counted, err := h.memoryStore.RecordAisleFailure(ctx, orderID, isRestock, aisle, req.GetRetry())
+	if err != nil {
+		log.Printf("[inventory] ERROR failed to record aisle failure order=%s aisle=%s err=%v", orderID, aisle, err)
+		return err
+	}
+	if !counted {
+		log.Printf("[inventory] duplicate FAILED report ignored order=%s aisle=%s robot=%s retry=%d", orderID, aisle, req.GetRobotId(), req.GetRetry())
+		return nil
+	}
+
+	if failures > int64(h.aisleRetryLimit) {
+		reason := fmt.Sprintf("aisle %s failed %d times (robot %s)", aisle, failures, req.GetRobotId())
+		return h.failFromRobot(ctx, orderID, isRestock, reason)
+	}
+
+	items, err := h.memoryStore.GetDispatchItems(ctx, orderID, isRestock)
+	if err != nil {
+		log.Printf("[inventory] ERROR cannot load dispatch items to retry order=%s aisle=%s err=%v", orderID, aisle, err)
+		return err
+	}
+	aisleItems := make(map[string]mq.ItemDetails)
+	for sku, detail := range items {
+		if detail.Aisle == aisle {
+			aisleItems[sku] = detail
+		}
+	}
+
+	if err := h.memoryStore.AddDispatchTask(ctx, orderID, isRestock, aisle, time.Now()); err != nil {
+		log.Printf("[inventory] WARN dispatch ledger write failed order=%s aisle=%s err=%v (no redelivery)", orderID, aisle, err)
+	}
+	if err := h.publisher.SendAisleCommand(orderID, orderType, aisle, aisleItems, mq.AisleSet(items), int32(failures)); err != nil {
+		log.Printf("[inventory] ERROR zmq re-dispatch failed order=%s aisle=%s err=%v", orderID, aisle, err)
+	}
+	log.Printf("[inventory] WARN aisle failed, re-dispatched order=%s type=%s aisle=%s robot=%s retry=%d/%d", orderID, orderType, aisle, req.GetRobotId(), failures, h.aisleRetryLimit)
+
+	h.publishProgress(ctx, isRestock, &pb.OrderProgressEvent{
+		OrderId:   orderID,
+		EventType: progressRobotReport,
+		Aisle:     aisle,
+		RobotId:   req.GetRobotId(),
+		Status:    jobStatusName(pb.JobStatus_JOB_STATUS_FAILED),
+		Reason:    fmt.Sprintf("re-dispatched aisle %s (retry %d/%d)", aisle, failures, h.aisleRetryLimit),
+	})
+	return nil
+}
+
+// failFromRobot claims the order and fails it with compensation after an unrecoverable robot failure.
+func (h *InventoryHandler) failFromRobot(ctx context.Context, orderID string, isRestock bool, reason string) error {
+	wf, err := h.claimFinalization(ctx, orderID, isRestock, reasonRobotFailed, reason)
+	if err != nil {
+		log.Printf("[inventory] ERROR failed to claim order finalization order=%s err=%v", orderID, err)
+		return err
+	}
+	if wf == nil {
+		// Already finalized or failed by another report or the sweeper.
+		return nil
+	}
+	log.Printf("[inventory] WARN failing order=%s restock=%t reason=%q", orderID, isRestock, reason)
+	go h.finishWorkflow(context.Background(), wf)
+	return nil
+}
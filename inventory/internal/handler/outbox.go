@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WebhookRetryPolicy controls how undelivered ordering webhooks are retried.
+type WebhookRetryPolicy struct {
+	MaxAttempts int           // attempts before an entry is dead-lettered
+	BaseBackoff time.Duration // delay after the first failure, doubled per attempt
+	MaxBackoff  time.Duration
+}
+
+const (
+	// webhookLease keeps other dispatchers off an entry while one attempt is in progress.
+	webhookLease     = 30 * time.Second
+	webhookBatchSize = 50
+)
+
+// webhookRequest builds the ordering webhook for an update: target url, order type and json payload.
+func (h *InventoryHandler) webhookRequest(isRestock bool, update webhookUpdate) (string, string, []byte) {
+	url, orderType, amountKey := h.orderWebhookURL, "CUSTOMER", "total_price"
+	if isRestock {
+		url, orderType, amountKey = h.restockWebhookURL, "RESTOCK", "total_cost"
+	}
+
+	payload := map[string]interface{}{
+		"order_id": update.OrderID,
+		"status":   update.Status,
+		amountKey:  update.Amount,
+		"lines":    update.Lines,
+	}
+	if update.Reason != "" {
+		payload["reason"] = update.Reason
+	}
+	if update.ReasonCode != "" {
+		payload["reason_code"] = update.ReasonCode
+	}
+	jsonBytes, _ := json.Marshal(payload)
+	log.Printf("[inventory] webhook sending order=%s url=%s payload=%s", update.OrderID, url, string(jsonBytes))
+	return url, orderType, jsonBytes
+}
+
+// callWebhook records a completion update in the outbox and makes the first delivery attempt.
+func (h *InventoryHandler) callWebhook(isRestock bool, update webhookUpdate) {
+	url, orderType, jsonBytes := h.webhookRequest(isRestock, update)
+	orderID := update.OrderID
+
+	ctx := context.Background()
+	id, err := h.store.EnqueueWebhook(ctx, orderID, orderType, url, jsonBytes, webhookLease)
+	if err != nil {
+		// Without an outbox row there is nothing to retry from; fall back to a single attempt.
+		log.Printf("[inventory] ERROR webhook outbox write failed order=%s err=%v", orderID, err)
+		if err := postWebhook(url, jsonBytes); err != nil {
+			log.Printf("[inventory] ERROR webhook lost order=%s url=%s err=%v", orderID, url, err)
+		}
+		return
+	}
+
+	h.deliverWebhook(ctx, store.WebhookOutboxEntry{
+		ID:        id,
+		OrderID:   orderID,
+		OrderType: orderType,
+		TargetURL: url,
+		Payload:   jsonBytes,
+	})
+}
+
+// RunWebhookDispatcher periodically retries outbox entries whose backoff has elapsed.
+func (h *InventoryHandler) RunWebhookDispatcher(ctx context.Context, interval time.Duration) {
+	log.Printf("[inventory-outbox] started interval=%s max_attempts=%d", interval, h.webhookRetry.MaxAttempts)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[inventory-outbox] stopped")
+			return
+		case <-ticker.C:
+			entries, err := h.store.ClaimDueWebhooks(ctx, webhookBatchSize, webhookLease)
+			if err != nil {
+				log.Printf("[inventory-outbox] ERROR claim due webhooks err=%v", err)
+				continue
+			}
+			for _, entry := range entries {
+				h.deliverWebhook(ctx, entry)
+			}
+		}
+	}
+}
+
+// deliverWebhook attempts one delivery and records the outcome in the outbox.
+func (h *InventoryHandler) deliverWebhook(ctx context.Context, entry store.WebhookOutboxEntry) {
+	err := postWebhook(entry.TargetURL, entry.Payload)
+	if err == nil {
+		if err := h.workflows.MarkWebhookDelivered(ctx, entry.ID); err != nil {
+			log.Printf("[inventory-outbox] ERROR mark delivered id=%d order=%s err=%v", entry.ID, entry.OrderID, err)
+		}
+		log.Printf("[inventory] webhook delivered id=%d order=%s url=%s", entry.ID, entry.OrderID, entry.TargetURL)
+		return
+	}
+
+	attempts := entry.Attempts + 1
+	dead := attempts >= h.webhookRetry.MaxAttempts
+	retryIn := h.webhookBackoff(attempts)
+	if err := h.workflows.MarkWebhookFailed(ctx, entry.ID, err.Error(), retryIn, dead); err != nil {
+		log.Printf("[inventory-outbox] ERROR mark failed id=%d order=%s err=%v", entry.ID, entry.OrderID, err)
+	}
+	if dead {
+		log.Printf("[inventory-outbox] ERROR webhook dead-lettered id=%d order=%s attempts=%d err=%v", entry.ID, entry.OrderID, attempts, err)
+		return
+	}
+	log.Printf("[inventory-outbox] WARN webhook attempt failed id=%d order=%s attempts=%d retry_in=%s err=%v", entry.ID, entry.OrderID, attempts, retryIn, err)
+}
+
+// webhookBackoff returns the exponential delay before the next attempt.
+func (h *InventoryHandler) webhookBackoff(attempts int) time.Duration {
+	backoff := h.webhookRetry.BaseBackoff
+	for i := 1; i < attempts && backoff < h.webhookRetry.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, h.webhookRetry.MaxBackoff)
+}
+
+// postWebhook sends one authenticated webhook request; any non-2xx response is an error.
+func postWebhook(url string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", os.Getenv("INTERNAL_SECRET"))
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ListUndeliveredWebhooks returns pending and dead-lettered outbox entries for operators.
+func (h *InventoryHandler) ListUndeliveredWebhooks(ctx context.Context, req *pb.ListUndeliveredWebhooksRequest) (*pb.ListUndeliveredWebhooksResponse, error) {
+	if err := validateOutboxOrderType(req.GetOrderType()); err != nil {
+		return nil, err
+	}
+	for _, s := range req.GetStatuses() {
+		if s != store.WebhookPending && s != store.WebhookDead {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported status %q", s)
+		}
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = 100
+	}
+
+	entries, err := h.store.ListUndeliveredWebhooks(ctx, req.GetOrderType(), req.GetStatuses(), limit)
+	if err != nil {
+		log.Printf("[inventory-outbox] ERROR list webhooks err=%v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListUndeliveredWebhooksResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &pb.WebhookOutboxEntry{
+			Id:            e.ID,
+			OrderId:       e.OrderID,
+			OrderType:     e.OrderType,
+			TargetUrl:     e.TargetURL,
+			Payload:       string(e.Payload),
+			Status:        e.Status,
+			Attempts:      int32(e.Attempts),
+			LastError:     e.LastError,
+			NextAttemptAt: timestamppb.New(e.NextAttemptAt),
+			CreatedAt:     timestamppb.New(e.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// ReplayWebhooks resets undelivered outbox entries so the dispatcher retries them immediately.
+func (h *InventoryHandler) ReplayWebhooks(ctx context.Context, req *pb.ReplayWebhooksRequest) (*pb.ReplayWebhooksResponse, error) {
+	if err := validateOutboxOrderType(req.GetOrderType()); err != nil {
+		return nil, err
+	}
+
+	replayed, err := h.store.ReplayWebhooks(ctx, req.GetIds(), req.GetOrderType())
+	if err != nil {
+		log.Printf("[inventory-outbox] ERROR replay webhooks ids=%v order_type=%s err=%v", req.GetIds(), req.GetOrderType(), err)
+		return nil, err
+	}
+	log.Printf("[inventory-outbox] replay requested ids=%v order_type=%s replayed=%d", req.GetIds(), req.GetOrderType(), replayed)
+	return &pb.ReplayWebhooksResponse{Success: true, Replayed: int32(replayed)}, nil
+}
+
+// validateOutboxOrderType rejects order types other than CUSTOMER, RESTOCK, or empty.
+func validateOutboxOrderType(orderType string) error {
+	switch orderType {
+	case "", "CUSTOMER", "RESTOCK":
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "unsupported order_type %q", orderType)
+}
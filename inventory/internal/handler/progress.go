@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Progress event types emitted on WatchOrderProgress.
+const (
+	progressSnapshot    = "SNAPSHOT"
+	progressRobotReport = "ROBOT_REPORT"
+	progressBilled      = "BILLED"
+	progressFinalized   = "FINALIZED"
+)
+
+// WatchOrderProgress streams fulfillment events for one order until it is finalized.
+func (h *InventoryHandler) WatchOrderProgress(req *pb.WatchOrderProgressRequest, stream pb.InventoryService_WatchOrderProgressServer) error {
+	orderID := req.GetOrderId()
+	if orderID == "" {
+		return status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	isRestock := req.GetOrderType() == "RESTOCK"
+	orderType := orderTypeFor(isRestock)
+	ctx := stream.Context()
+
+	// Subscribe before reading the snapshot so nothing between the two is lost.
+	events, closeSub, err := h.memoryStore.SubscribeOrderProgress(ctx, orderType, orderID)
+	if err != nil {
+		log.Printf("[inventory] ERROR progress subscribe failed order=%s err=%v", orderID, err)
+		return err
+	}
+	defer closeSub()
+
+	aisles, expected, err := h.memoryStore.GetExpectedReports(ctx, orderID, isRestock)
+	if errors.Is(err, store.ErrOrderNotTracked) {
+		return status.Errorf(codes.NotFound, "order %s is not in fulfillment", orderID)
+	} else if err != nil {
+		return err
+	}
+	count, err := h.memoryStore.GetRobotCount(ctx, orderID, isRestock)
+	if err != nil {
+		return err
+	}
+	picked, err := h.memoryStore.GetPickedItems(ctx, orderID, isRestock)
+	if err != nil {
+		return err
+	}
+	log.Printf("[inventory] progress watcher attached order=%s type=%s aisles=%v count=%d/%d", orderID, orderType, aisles, count, expected)
+
+	snapshot := &pb.OrderProgressEvent{
+		OrderId:         orderID,
+		OrderType:       orderType,
+		EventType:       progressSnapshot,
+		ProcessedItems:  picked,
+		ReportsReceived: int32(count),
+		ReportsExpected: int32(expected),
+		At:              timestamppb.Now(),
+	}
+	if err := stream.Send(snapshot); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case payload, ok := <-events:
+			if !ok {
+				return nil
+			}
+			var event pb.OrderProgressEvent
+			if err := proto.Unmarshal(payload, &event); err != nil {
+				log.Printf("[inventory] WARN dropping malformed progress event order=%s err=%v", orderID, err)
+				continue
+			}
+			if err := stream.Send(&event); err != nil {
+				return err
+			}
+			if event.GetEventType() == progressFinalized {
+				return nil
+			}
+		}
+	}
+}
+
+// publishProgress stamps and broadcasts a progress event; watchers are best-effort and never block fulfillment.
+func (h *InventoryHandler) publishProgress(ctx context.Context, isRestock bool, event *pb.OrderProgressEvent) {
+	event.OrderType = orderTypeFor(isRestock)
+	event.At = timestamppb.Now()
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		log.Printf("[inventory] WARN progress event encode failed order=%s err=%v", event.GetOrderId(), err)
+		return
+	}
+	if err := h.memoryStore.PublishOrderProgress(ctx, event.GetOrderType(), event.GetOrderId(), payload); err != nil {
+		log.Printf("[inventory] WARN progress event publish failed order=%s type=%s err=%v", event.GetOrderId(), event.GetEventType(), err)
+	}
+}
+
+// orderTypeFor maps the flow flag to the order_type string robots and RPCs use.
+func orderTypeFor(isRestock bool) string {
+	if isRestock {
+		return store.OrderTypeRestock
+	}
+	return store.OrderTypeCustomer
+}
+
+// parseOrderType validates an order_type sent by a robot and returns the flow flag.
+func parseOrderType(orderType string) (bool, error) {
+	switch orderType {
+	case store.OrderTypeCustomer:
+		return false, nil
+	case store.OrderTypeRestock:
+		return true, nil
+	}
+	return false, status.Errorf(codes.InvalidArgument, "unknown order_type %q", orderType)
+}
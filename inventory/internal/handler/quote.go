@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "auto_grocery/inventory/proto"
+)
+
+// addQuote asks pricing to lock the prices of a live reservation's reserved quantities. A failure leaves
+// the response without a quote, and the order is then billed at the prices current when it is picked.
+func (h *InventoryHandler) addQuote(ctx context.Context, resp *pb.ReserveItemsResponse) {
+	if !resp.GetSuccess() {
+		return
+	}
+	var cart []*pb.CartItem
+	for _, line := range resp.GetLines() {
+		if line.GetReserved() > 0 {
+			cart = append(cart, &pb.CartItem{Sku: line.GetSku(), Quantity: line.GetReserved()})
+		}
+	}
+	if len(cart) == 0 {
+		return
+	}
+
+	quoteCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	quote, err := h.pricingClient.CreateQuote(quoteCtx, &pb.CreateQuoteRequest{Items: cart})
+	if err != nil {
+		log.Printf("[inventory] WARN price quote unavailable order=%s err=%v", resp.GetOrderId(), err)
+		return
+	}
+
+	resp.Quote = &pb.PriceQuote{
+		QuoteId:   quote.GetQuoteId(),
+		Total:     quote.GetGrandTotal(),
+		ExpiresAt: quote.GetExpiresAt(),
+	}
+	for _, item := range quote.GetItems() {
+		resp.Quote.Lines = append(resp.Quote.Lines, &pb.QuotedLine{
+			Sku:        item.GetSku(),
+			Quantity:   item.GetQuantity(),
+			UnitPrice:  item.GetUnitPrice(),
+			TotalPrice: item.GetTotalPrice(),
+		})
+	}
+	log.Printf("[inventory] price quote order=%s quote=%s total=%.2f", resp.GetOrderId(), quote.GetQuoteId(), quote.GetGrandTotal())
+}
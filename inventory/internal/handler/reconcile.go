@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"sort"
+
+	"auto_grocery/inventory/internal/mq"
+)
+
+const (
+	statusCompleted          = "COMPLETED"
+	statusPartiallyFulfilled = "PARTIALLY_FULFILLED"
+	statusFailed             = "FAILED"
+	statusExpired            = "EXPIRED"
+)
+
+// fulfillmentLine compares one requested sku with what the robots actually handled.
+type fulfillmentLine struct {
+	Sku       string `json:"sku"`
+	Requested int32  `json:"requested"`
+	Picked    int32  `json:"picked"`
+	Short     int32  `json:"short"`
+}
+
+// reconcileItems caps robot-reported quantities at the requested amount and derives the order status.
+func reconcileItems(requested map[string]int32, picked map[string]int32) ([]fulfillmentLine, string) {
+	skus := make([]string, 0, len(requested))
+	for sku := range requested {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	status := statusCompleted
+	lines := make([]fulfillmentLine, 0, len(skus))
+	for _, sku := range skus {
+		want := requested[sku]
+		// Reports are reconciled per aisle as they arrive; the cap only guards totals recorded before that.
+		got := min(max(picked[sku], 0), want)
+		line := fulfillmentLine{Sku: sku, Requested: want, Picked: got, Short: want - got}
+		if line.Short > 0 {
+			status = statusPartiallyFulfilled
+		}
+		lines = append(lines, line)
+	}
+	return lines, status
+}
+
+// aisleReport reconciles one robot's processed items with its aisle assignment: every sku of the aisle is
+// reported, capped at the dispatched quantity, and skus outside it are dropped. A robot that predates aisle
+// reporting only has the skus it named capped.
+func aisleReport(items map[string]mq.ItemDetails, aisle string, processed map[string]int32) map[string]int32 {
+	report := make(map[string]int32)
+	for sku, detail := range items {
+		qty, named := processed[sku]
+		if aisle == "" && !named {
+			continue
+		}
+		if aisle != "" && detail.Aisle != aisle {
+			continue
+		}
+		report[sku] = min(max(qty, 0), detail.Quantity)
+	}
+	return report
+}
+
+// pickedOnly returns the sku quantities that robots actually handled.
+func pickedOnly(lines []fulfillmentLine) map[string]int32 {
+	picked := make(map[string]int32)
+	for _, line := range lines {
+		if line.Picked > 0 {
+			picked[line.Sku] = line.Picked
+		}
+	}
+	return picked
+}
+
+// shortOnly returns the sku quantities that robots failed to handle.
+func shortOnly(lines []fulfillmentLine) map[string]int32 {
+	short := make(map[string]int32)
+	for _, line := range lines {
+		if line.Short > 0 {
+			short[line.Sku] = line.Short
+		}
+	}
+	return short
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetReorderPoint stores a sku's reorder point and restock target level.
+func (h *InventoryHandler) SetReorderPoint(ctx context.Context, req *pb.SetReorderPointRequest) (*pb.SetReorderPointResponse, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+	if req.GetReorderPoint() < 0 || req.GetTargetLevel() < req.GetReorderPoint() {
+		return nil, status.Error(codes.InvalidArgument, "need 0 <= reorder_point <= target_level")
+	}
+
+	err := h.store.SetReorderPoint(ctx, req.GetSku(), int(req.GetReorderPoint()), int(req.GetTargetLevel()))
+	if errors.Is(err, store.ErrUnknownSKU) {
+		return nil, status.Errorf(codes.NotFound, "sku %s not stocked", req.GetSku())
+	} else if err != nil {
+		log.Printf("[inventory] ERROR set reorder point failed sku=%s err=%v", req.GetSku(), err)
+		return nil, err
+	}
+	log.Printf("[inventory] reorder point set sku=%s reorder_point=%d target_level=%d", req.GetSku(), req.GetReorderPoint(), req.GetTargetLevel())
+	return &pb.SetReorderPointResponse{Success: true}, nil
+}
+
+// ListLowStock returns the skus below their reorder point and how much would bring each back to target.
+func (h *InventoryHandler) ListLowStock(ctx context.Context, req *pb.ListLowStockRequest) (*pb.ListLowStockResponse, error) {
+	items, err := h.store.ListLowStock(ctx)
+	if err != nil {
+		log.Printf("[inventory] ERROR list low stock failed err=%v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListLowStockResponse{}
+	for _, i := range items {
+		item := &pb.LowStockItem{
+			Sku:               i.SKU,
+			Name:              i.Name,
+			AisleType:         i.AisleType,
+			Quantity:          int32(i.Quantity),
+			ReorderPoint:      int32(i.ReorderPoint),
+			TargetLevel:       int32(i.TargetLevel),
+			SuggestedQuantity: int32(max(i.TargetLevel-i.Quantity, 0)),
+		}
+		if !i.LastAlertAt.IsZero() {
+			item.LastAlertAt = timestamppb.New(i.LastAlertAt)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+// logLowStock reports the skus a reserve took below their reorder point.
+func logLowStock(events []store.LowStockEvent) {
+	for _, e := range events {
+		log.Printf("[inventory] WARN low stock sku=%s quantity=%d reorder_point=%d target_level=%d order=%s",
+			e.SKU, e.Quantity, e.ReorderPoint, e.TargetLevel, e.OrderID)
+	}
+}
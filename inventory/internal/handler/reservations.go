@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// reservationExpiryBatch caps how many holds one tick releases so a backlog cannot stall the loop.
+const reservationExpiryBatch = 100
+
+// RunReservationExpiry periodically releases stock holds whose time box has passed.
+func (h *InventoryHandler) RunReservationExpiry(ctx context.Context, interval time.Duration) {
+	log.Printf("[inventory-reservations] started interval=%s ttl=%s", interval, h.reservationTTL)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[inventory-reservations] stopped")
+			return
+		case <-ticker.C:
+			h.expireReservations(ctx)
+		}
+	}
+}
+
+// expireReservations releases overdue holds one at a time and tells ordering each order expired.
+func (h *InventoryHandler) expireReservations(ctx context.Context) {
+	for range reservationExpiryBatch {
+		orderID, released, err := h.store.ExpireNextReservation(ctx)
+		if err != nil {
+			log.Printf("[inventory-reservations] ERROR expiry failed err=%v", err)
+			return
+		}
+		if orderID == "" {
+			return
+		}
+		log.Printf("[inventory-reservations] hold expired order=%s released=%v", orderID, released)
+
+		h.callWebhook(false, webhookUpdate{
+			OrderID:    orderID,
+			Status:     statusExpired,
+			Reason:     "stock hold expired before the order was confirmed",
+			ReasonCode: reasonReservationExpired,
+		})
+	}
+}
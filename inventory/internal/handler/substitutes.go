@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+)
+
+const (
+	// maxSubstitutes is how many suggestions each short sku gets.
+	maxSubstitutes = 3
+	// substituteCandidateLimit caps the same-aisle items scored per short sku.
+	substituteCandidateLimit = 50
+)
+
+// addSubstitutes suggests in-stock replacements from the same aisle type for every short line.
+func (h *InventoryHandler) addSubstitutes(ctx context.Context, resp *pb.ReserveItemsResponse) {
+	var short []string
+	var requested []string
+	for _, line := range resp.GetLines() {
+		requested = append(requested, line.GetSku())
+		if line.GetShortfall() > 0 {
+			short = append(short, line.GetSku())
+		}
+	}
+	if len(short) == 0 {
+		return
+	}
+
+	items, err := h.store.GetBatchItems(ctx, short)
+	if err != nil {
+		log.Printf("[inventory] WARN substitute lookup failed order=%s err=%v", resp.GetOrderId(), err)
+		return
+	}
+
+	for _, line := range resp.GetLines() {
+		item, ok := items[line.GetSku()]
+		if line.GetShortfall() == 0 || !ok {
+			// Unknown skus have no aisle to suggest from.
+			continue
+		}
+		candidates, err := h.store.ListInStockByAisle(ctx, item.AisleType, requested, substituteCandidateLimit)
+		if err != nil {
+			log.Printf("[inventory] WARN substitute candidates failed sku=%s aisle=%s err=%v", item.SKU, item.AisleType, err)
+			continue
+		}
+		line.Substitutes = h.rankSubstitutes(ctx, item, candidates, line.GetShortfall())
+	}
+}
+
+// rankSubstitutes orders candidates by similarity to the short item, then by closeness in price.
+func (h *InventoryHandler) rankSubstitutes(ctx context.Context, short *store.StockItem, candidates []store.StockItem, shortfall int32) []*pb.Substitute {
+	target := tokens(short.SKU + " " + short.Name)
+	subs := make([]*pb.Substitute, 0, len(candidates))
+	for _, c := range candidates {
+		subs = append(subs, &pb.Substitute{
+			Sku:               c.SKU,
+			Name:              c.Name,
+			Available:         int32(c.Quantity),
+			UnitPrice:         c.UnitCost,
+			Similarity:        jaccard(target, tokens(c.SKU+" "+c.Name)),
+			SuggestedQuantity: min(shortfall, int32(c.Quantity)),
+		})
+	}
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].Similarity > subs[j].Similarity })
+
+	// Only the shortlist is priced; a similar enough item is worth one pricing call, the rest are not.
+	shortlist := subs[:min(len(subs), 2*maxSubstitutes)]
+	targetPrice := h.currentPrice(ctx, short.SKU, short.UnitCost)
+	for _, s := range shortlist {
+		s.UnitPrice = h.currentPrice(ctx, s.Sku, s.UnitPrice)
+	}
+	sort.SliceStable(shortlist, func(i, j int) bool {
+		a, b := shortlist[i], shortlist[j]
+		if math.Abs(a.Similarity-b.Similarity) > 0.01 {
+			return a.Similarity > b.Similarity
+		}
+		return math.Abs(a.UnitPrice-targetPrice) < math.Abs(b.UnitPrice-targetPrice)
+	})
+	return shortlist[:min(len(shortlist), maxSubstitutes)]
+}
+
+// currentPrice asks pricing for a sku's unit price, falling back when pricing is unavailable.
+func (h *InventoryHandler) currentPrice(ctx context.Context, sku string, fallback float64) float64 {
+	priceCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	resp, err := h.pricingClient.GetPrice(priceCtx, &pb.GetPriceRequest{Sku: sku})
+	if err != nil {
+		return fallback
+	}
+	return resp.GetUnitPrice()
+}
+
+// tokens splits a sku or name into lower-case alphanumeric words.
+func tokens(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, f := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		set[f] = struct{}{}
+	}
+	return set
+}
+
+// jaccard is the share of words two token sets have in common.
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
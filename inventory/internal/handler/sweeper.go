@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "auto_grocery/inventory/proto"
+)
+
+// sweepMaxClaimFailures is how many sweeps may fail to claim a stuck order before it is dead-lettered.
+const sweepMaxClaimFailures = 5
+
+// RunOrderSweeper periodically fails orders that have been in flight longer than sla and retries
+// settlements that failed.
+func (h *InventoryHandler) RunOrderSweeper(ctx context.Context, interval time.Duration, sla time.Duration) {
+	log.Printf("[inventory-sweeper] started interval=%s sla=%s", interval, sla)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[inventory-sweeper] stopped")
+			return
+		case <-ticker.C:
+			h.sweepStuckOrders(ctx, sla, false)
+			h.sweepStuckOrders(ctx, sla, true)
+			h.retrySettlements(ctx)
+		}
+	}
+}
+
+// sweepStuckOrders fails every order of one flow that was dispatched before the sla cutoff.
+func (h *InventoryHandler) sweepStuckOrders(ctx context.Context, sla time.Duration, isRestock bool) {
+	orderIDs, err := h.memoryStore.ListInFlightBefore(ctx, isRestock, time.Now().Add(-sla))
+	if err != nil {
+		log.Printf("[inventory-sweeper] ERROR list in-flight orders restock=%t err=%v", isRestock, err)
+		return
+	}
+
+	for _, orderID := range orderIDs {
+		// Claim the order so a late robot report cannot finalize it concurrently.
+		reason := h.timeoutReason(ctx, orderID, isRestock, sla)
+		wf, err := h.reclaimFinalization(ctx, orderID, isRestock, reasonSLATimeout, reason)
+		if err != nil {
+			h.recordSweepFailure(ctx, orderID, isRestock, reason, err)
+			continue
+		}
+		if wf == nil {
+			// Finalized elsewhere; whoever claimed it cleans up, the sweeper just stops retrying it.
+			if err := h.memoryStore.ForgetInFlight(ctx, orderID, isRestock); err != nil {
+				log.Printf("[inventory-sweeper] WARN forget in-flight order failed order=%s err=%v", orderID, err)
+			}
+			continue
+		}
+
+		log.Printf("[inventory-sweeper] WARN failing stuck order=%s restock=%t reason=%q", orderID, isRestock, reason)
+		h.finishWorkflow(ctx, wf)
+	}
+}
+
+// recordSweepFailure counts a failed claim of a stuck order and dead-letters the order once the claim has
+// failed sweepMaxClaimFailures times, so it is neither retried forever nor left holding stock.
+func (h *InventoryHandler) recordSweepFailure(ctx context.Context, orderID string, isRestock bool, reason string, claimErr error) {
+	failures, err := h.memoryStore.RecordSweepFailure(ctx, orderID, isRestock)
+	if err != nil {
+		log.Printf("[inventory-sweeper] ERROR claim failed order=%s err=%v (failure not counted: %v)", orderID, claimErr, err)
+		return
+	}
+	if failures < sweepMaxClaimFailures {
+		log.Printf("[inventory-sweeper] WARN claim failed order=%s attempts=%d/%d err=%v", orderID, failures, sweepMaxClaimFailures, claimErr)
+		return
+	}
+	h.deadLetterStuckOrder(ctx, orderID, isRestock, fmt.Sprintf("%s; finalization failed %d times: %v", reason, failures, claimErr))
+}
+
+// deadLetterStuckOrder fails an order that cannot be claimed: its held stock goes back on the shelf, its
+// workflow moves to DEAD and ordering gets the failure webhook.
+func (h *InventoryHandler) deadLetterStuckOrder(ctx context.Context, orderID string, isRestock bool, reason string) {
+	orderType := orderTypeFor(isRestock)
+	if err := h.workflows.AbandonOrder(ctx, orderType, orderID, reasonSLATimeout, reason); err != nil {
+		log.Printf("[inventory-sweeper] ERROR dead-letter failed order=%s type=%s err=%v (retried next sweep)", orderID, orderType, err)
+		return
+	}
+	log.Printf("[inventory-sweeper] ERROR order dead-lettered order=%s type=%s reason=%q", orderID, orderType, reason)
+
+	update := webhookUpdate{OrderID: orderID, Status: statusFailed, Reason: reason, ReasonCode: reasonSLATimeout}
+	h.callWebhook(isRestock, update)
+	h.publishProgress(ctx, isRestock, &pb.OrderProgressEvent{
+		OrderId:    orderID,
+		EventType:  progressFinalized,
+		Status:     update.Status,
+		Reason:     update.Reason,
+		ReasonCode: update.ReasonCode,
+	})
+	h.memoryStore.DeleteOrderData(ctx, orderID, isRestock)
+}
+
+// timeoutReason describes how far an order got before it exceeded its sla.
+func (h *InventoryHandler) timeoutReason(ctx context.Context, orderID string, isRestock bool, sla time.Duration) string {
+	count, err := h.memoryStore.GetRobotCount(ctx, orderID, isRestock)
+	if err != nil {
+		return fmt.Sprintf("timed out after %s waiting for robot reports", sla)
+	}
+	aisles, expected, err := h.memoryStore.GetExpectedReports(ctx, orderID, isRestock)
+	if err != nil {
+		return fmt.Sprintf("timed out after %s waiting for robot reports (%d received)", sla, count)
+	}
+	return fmt.Sprintf("timed out after %s waiting for robot reports (%d/%d received, aisles %v)", sla, count, expected, aisles)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "auto_grocery/inventory/proto"
+
+	"github.com/robfig/cron/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultWasteWindow is how far back GetWasteSummary looks when no from is given.
+const defaultWasteWindow = 30 * 24 * time.Hour
+
+// RunExpiryWriteOff writes off expired stock each time the cron schedule fires.
+func (h *InventoryHandler) RunExpiryWriteOff(ctx context.Context, schedule cron.Schedule) {
+	next := schedule.Next(time.Now())
+	log.Printf("[inventory-expiry] started next_run=%s", next.Format(time.RFC3339))
+
+	for {
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Printf("[inventory-expiry] stopped")
+			return
+		case <-timer.C:
+			h.writeOffExpiredStock(ctx)
+			next = schedule.Next(time.Now())
+			log.Printf("[inventory-expiry] next_run=%s", next.Format(time.RFC3339))
+		}
+	}
+}
+
+// writeOffExpiredStock clears expired lots and pushes the lowered stock levels to pricing.
+func (h *InventoryHandler) writeOffExpiredStock(ctx context.Context) {
+	records, err := h.store.ClearExpiredStock(ctx)
+	if err != nil {
+		log.Printf("[inventory-expiry] ERROR write-off failed err=%v", err)
+		return
+	}
+	if len(records) == 0 {
+		log.Printf("[inventory-expiry] nothing expired")
+		return
+	}
+
+	seen := make(map[string]struct{})
+	var skus []string
+	for _, r := range records {
+		log.Printf("[inventory-expiry] wrote off lot=%d sku=%s aisle=%s qty=%d unit_cost=%.2f expiry=%s",
+			r.LotID, r.SKU, r.AisleType, r.Quantity, r.UnitCost, r.ExpiryDate.Format(time.DateOnly))
+		if _, ok := seen[r.SKU]; !ok {
+			seen[r.SKU] = struct{}{}
+			skus = append(skus, r.SKU)
+		}
+	}
+
+	current, err := h.store.GetBatchItems(ctx, skus)
+	if err != nil {
+		log.Printf("[inventory-expiry] WARN failed to fetch stock for pricing update err=%v", err)
+		return
+	}
+	var metrics []*pb.StockMetric
+	for _, sku := range skus {
+		item, ok := current[sku]
+		if !ok {
+			continue
+		}
+		metrics = append(metrics, &pb.StockMetric{
+			Sku:      sku,
+			Quantity: int32(item.Quantity),
+			UnitCost: item.UnitCost,
+		})
+	}
+
+	pricingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := h.pricingClient.UpdateStockMetrics(pricingCtx, &pb.UpdateStockMetricsRequest{Updates: metrics}); err != nil {
+		log.Printf("[inventory-expiry] WARN pricing metric update failed skus=%v err=%v", skus, err)
+		return
+	}
+	log.Printf("[inventory-expiry] write-off complete lots=%d skus=%d", len(records), len(skus))
+}
+
+// GetWasteSummary reports written-off stock per day and aisle.
+func (h *InventoryHandler) GetWasteSummary(ctx context.Context, req *pb.GetWasteSummaryRequest) (*pb.GetWasteSummaryResponse, error) {
+	to := time.Now().UTC()
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime()
+	}
+	from := to.Add(-defaultWasteWindow)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	totals, err := h.store.SumWasteByDayAndAisle(ctx, from, to, req.GetAisleType())
+	if err != nil {
+		log.Printf("[inventory] ERROR waste summary failed err=%v", err)
+		return nil, err
+	}
+
+	resp := &pb.GetWasteSummaryResponse{}
+	for _, t := range totals {
+		resp.Totals = append(resp.Totals, &pb.WasteTotal{
+			Day:       t.Day,
+			AisleType: t.AisleType,
+			Quantity:  int32(t.Quantity),
+			TotalCost: t.TotalCost,
+			Lots:      int32(t.Lots),
+		})
+		resp.TotalQuantity += int32(t.Quantity)
+		resp.TotalCost += t.TotalCost
+	}
+	return resp, nil
+}
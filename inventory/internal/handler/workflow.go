@@ -0,0 +1,457 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	"auto_grocery/inventory/internal/store"
+	pb "auto_grocery/inventory/proto"
+)
+
+// Settlement retries: a BILLING workflow whose settlement fails is retried by the sweeper with doubling
+// backoff and dead-lettered after settleMaxAttempts.
+const (
+	settleMaxAttempts = 10
+	settleBaseBackoff = 10 * time.Second
+	settleMaxBackoff  = 10 * time.Minute
+	// settleLease keeps the sweeper off a workflow while another caller is settling it.
+	settleLease     = 2 * time.Minute
+	settleBatchSize = 50
+)
+
+// ResumeWorkflows picks up every workflow a previous run left unfinished: orders still being picked get
+// their redis state back, and orders that were being finalized are finished.
+func (h *InventoryHandler) ResumeWorkflows(ctx context.Context) {
+	workflows, err := h.workflows.ListOpenWorkflows(ctx)
+	if err != nil {
+		log.Printf("[inventory-workflow] ERROR list open workflows failed err=%v", err)
+		return
+	}
+	log.Printf("[inventory-workflow] resuming open workflows count=%d", len(workflows))
+
+	for _, wf := range workflows {
+		switch wf.Stage {
+		case store.WorkflowDispatched, store.WorkflowPicking:
+			if err := h.restoreWorkflowState(ctx, wf); err != nil {
+				log.Printf("[inventory-workflow] ERROR restore failed order=%s type=%s err=%v", wf.OrderID, wf.OrderType, err)
+				continue
+			}
+			// Every report was counted but the order never reached BILLING: its finalization was lost.
+			if wf.ExpectedReports > 0 && wf.Reports >= wf.ExpectedReports {
+				h.resumeFinalization(ctx, wf)
+			}
+		default:
+			// BILLING workflows that already failed wait for their retry in the sweeper.
+			if wf.Stage == store.WorkflowBilling && wf.SettleAttempts > 0 {
+				continue
+			}
+			log.Printf("[inventory-workflow] resuming order=%s type=%s stage=%s", wf.OrderID, wf.OrderType, wf.Stage)
+			h.finishWorkflow(ctx, wf)
+		}
+	}
+}
+
+// resumeFinalization finalizes a fully reported order whose claim was lost before it reached BILLING.
+func (h *InventoryHandler) resumeFinalization(ctx context.Context, open *store.Workflow) {
+	isRestock := open.OrderType == store.OrderTypeRestock
+	wf, err := h.reclaimFinalization(ctx, open.OrderID, isRestock, "", "")
+	if err != nil {
+		log.Printf("[inventory-workflow] ERROR finalize claim failed order=%s type=%s err=%v", open.OrderID, open.OrderType, err)
+		return
+	}
+	if wf == nil {
+		return
+	}
+	log.Printf("[inventory-workflow] WARN finalizing fully reported order=%s type=%s reports=%d/%d",
+		open.OrderID, open.OrderType, open.Reports, open.ExpectedReports)
+	h.finishWorkflow(ctx, wf)
+}
+
+// expectedReports loads the reports an order waits for, restoring its redis state from the workflow
+// if redis lost it while the order was being picked.
+func (h *InventoryHandler) expectedReports(ctx context.Context, orderID string, isRestock bool) ([]string, int, error) {
+	aisles, expected, err := h.memoryStore.GetExpectedReports(ctx, orderID, isRestock)
+	if !errors.Is(err, store.ErrOrderNotTracked) {
+		return aisles, expected, err
+	}
+	wf, wfErr := h.workflows.GetWorkflow(ctx, orderTypeFor(isRestock), orderID)
+	if wfErr != nil || (wf.Stage != store.WorkflowDispatched && wf.Stage != store.WorkflowPicking) {
+		return nil, 0, err
+	}
+	if err := h.restoreWorkflowState(ctx, wf); err != nil {
+		return nil, 0, err
+	}
+	return mq.AisleSet(wf.RobotItems), wf.ExpectedReports, nil
+}
+
+// robotAssignment loads the items an order dispatched to robots, from redis or else from its workflow.
+func (h *InventoryHandler) robotAssignment(ctx context.Context, orderID string, isRestock bool) (map[string]mq.ItemDetails, error) {
+	items, err := h.memoryStore.GetDispatchItems(ctx, orderID, isRestock)
+	if !errors.Is(err, store.ErrOrderNotTracked) {
+		return items, err
+	}
+	wf, err := h.workflows.GetWorkflow(ctx, orderTypeFor(isRestock), orderID)
+	if err != nil {
+		return nil, err
+	}
+	return wf.RobotItems, nil
+}
+
+// restoreWorkflowState rebuilds the redis state of an order being picked unless redis still has it.
+func (h *InventoryHandler) restoreWorkflowState(ctx context.Context, wf *store.Workflow) error {
+	isRestock := wf.OrderType == store.OrderTypeRestock
+	if _, _, err := h.memoryStore.GetExpectedReports(ctx, wf.OrderID, isRestock); !errors.Is(err, store.ErrOrderNotTracked) {
+		return err
+	}
+
+	var err error
+	if isRestock {
+		err = h.memoryStore.SaveRestockItems(ctx, wf.OrderID, wf.RestockItems)
+	} else {
+		err = h.memoryStore.SaveOrderItems(ctx, wf.OrderID, wf.Items)
+	}
+	if err != nil {
+		return err
+	}
+	if err := h.memoryStore.SetPickedItems(ctx, wf.OrderID, isRestock, wf.Picked); err != nil {
+		return err
+	}
+	for _, robotID := range wf.Reporters {
+		if _, err := h.memoryStore.RecordReporter(ctx, wf.OrderID, isRestock, robotID); err != nil {
+			return err
+		}
+	}
+	if err := h.memoryStore.SetRobotCount(ctx, wf.OrderID, isRestock, int64(wf.Reports)); err != nil {
+		return err
+	}
+	// Robots already hold their tasks, so the ledger gets the items for failure retries but no pending
+	// aisle: re-broadcasting could make a robot pick an aisle twice.
+	if err := h.memoryStore.SaveDispatchLedger(ctx, wf.OrderID, isRestock, wf.RobotItems, nil, wf.DispatchedAt); err != nil {
+		return err
+	}
+	// Expected reports last: they are what marks the order as tracked.
+	if err := h.memoryStore.SaveExpectedReports(ctx, wf.OrderID, isRestock, mq.AisleSet(wf.RobotItems), wf.ExpectedReports); err != nil {
+		return err
+	}
+	if err := h.memoryStore.MarkInFlight(ctx, wf.OrderID, isRestock, wf.DispatchedAt); err != nil {
+		return err
+	}
+	log.Printf("[inventory-workflow] WARN restored redis state order=%s type=%s stage=%s reports=%d/%d",
+		wf.OrderID, wf.OrderType, wf.Stage, wf.Reports, wf.ExpectedReports)
+	return nil
+}
+
+// claimFinalization claims an order's one-time finalization, first in redis so racing reports are settled
+// cheaply, then durably in its workflow. A non-empty failCode fails the order instead of billing it.
+// It returns nil when another caller already claimed the order.
+func (h *InventoryHandler) claimFinalization(ctx context.Context, orderID string, isRestock bool, failCode string, failReason string) (*store.Workflow, error) {
+	claimed, err := h.memoryStore.TryMarkOrderFinalized(ctx, orderID, isRestock)
+	if err != nil || !claimed {
+		return nil, err
+	}
+	return h.claimWorkflow(ctx, orderID, isRestock, failCode, failReason)
+}
+
+// reclaimFinalization claims an order like claimFinalization, but also when redis already marks it finalized
+// and the order has a workflow: a mark left behind by a crash must not keep the order open for good, and the
+// workflow claim still lets exactly one caller through.
+func (h *InventoryHandler) reclaimFinalization(ctx context.Context, orderID string, isRestock bool, failCode string, failReason string) (*store.Workflow, error) {
+	claimed, err := h.memoryStore.TryMarkOrderFinalized(ctx, orderID, isRestock)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		// Without a workflow only the redis mark guards the order, so leave it to whoever holds the mark.
+		if _, err := h.workflows.GetWorkflow(ctx, orderTypeFor(isRestock), orderID); errors.Is(err, store.ErrWorkflowNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return h.claimWorkflow(ctx, orderID, isRestock, failCode, failReason)
+}
+
+// claimWorkflow durably claims the workflow of an order whose finalization was already claimed in redis.
+// If the durable claim fails the redis claim is given back, so the next report, sweep or restart retries.
+func (h *InventoryHandler) claimWorkflow(ctx context.Context, orderID string, isRestock bool, failCode string, failReason string) (*store.Workflow, error) {
+	wf, err := h.claimWorkflowOnce(ctx, orderID, isRestock, failCode, failReason)
+	if err != nil {
+		if clearErr := h.memoryStore.ClearOrderFinalized(ctx, orderID, isRestock); clearErr != nil {
+			log.Printf("[inventory-workflow] WARN finalize mark not cleared order=%s err=%v", orderID, clearErr)
+		}
+	}
+	return wf, err
+}
+
+// claimWorkflowOnce moves the order's workflow to BILLING with the picked items redis counted.
+func (h *InventoryHandler) claimWorkflowOnce(ctx context.Context, orderID string, isRestock bool, failCode string, failReason string) (*store.Workflow, error) {
+	picked, err := h.memoryStore.GetPickedItems(ctx, orderID, isRestock)
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) == 0 {
+		// Redis lost or never had the picked items; keep the ones the workflow recorded.
+		picked = nil
+	}
+
+	orderType := orderTypeFor(isRestock)
+	claimed, err := h.workflows.ClaimWorkflow(ctx, orderType, orderID, picked, failCode, failReason)
+	if errors.Is(err, store.ErrWorkflowNotFound) {
+		return h.adoptWorkflow(ctx, orderID, isRestock, picked, failCode, failReason)
+	}
+	if err != nil || !claimed {
+		return nil, err
+	}
+	return h.workflows.GetWorkflow(ctx, orderType, orderID)
+}
+
+// adoptWorkflow records a claimed workflow from redis for an order dispatched before workflows were recorded.
+func (h *InventoryHandler) adoptWorkflow(ctx context.Context, orderID string, isRestock bool, picked map[string]int32, failCode string, failReason string) (*store.Workflow, error) {
+	wf := &store.Workflow{
+		OrderID:    orderID,
+		OrderType:  orderTypeFor(isRestock),
+		Stage:      store.WorkflowBilling,
+		Picked:     picked,
+		FailCode:   failCode,
+		FailReason: failReason,
+	}
+	var err error
+	if isRestock {
+		wf.RestockItems, err = h.memoryStore.GetRestockItems(ctx, orderID)
+	} else {
+		wf.Items, err = h.memoryStore.GetOrderItems(ctx, orderID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot adopt order %s: %w", orderID, err)
+	}
+	if wf.RobotItems, err = h.memoryStore.GetDispatchItems(ctx, orderID, isRestock); err != nil {
+		wf.RobotItems = map[string]mq.ItemDetails{}
+	}
+	_, wf.ExpectedReports, _ = h.memoryStore.GetExpectedReports(ctx, orderID, isRestock)
+
+	if err := h.workflows.CreateWorkflow(ctx, wf); err != nil {
+		return nil, err
+	}
+	log.Printf("[inventory-workflow] WARN adopted untracked order=%s type=%s from redis", orderID, wf.OrderType)
+	return h.workflows.GetWorkflow(ctx, wf.OrderType, orderID)
+}
+
+// finishWorkflow drives a claimed workflow from BILLING through NOTIFIED to DONE. Each step is safe to repeat,
+// so a workflow interrupted by a crash is finished again on the next startup.
+func (h *InventoryHandler) finishWorkflow(ctx context.Context, wf *store.Workflow) {
+	isRestock := wf.OrderType == store.OrderTypeRestock
+	if wf.Stage == store.WorkflowBilling {
+		var update webhookUpdate
+		var err error
+		switch {
+		case wf.FailCode != "":
+			update, err = h.settleFailedOrder(ctx, wf)
+		case isRestock:
+			update, err = h.settleRestock(ctx, wf)
+		default:
+			update, err = h.settleClientOrder(ctx, wf)
+		}
+		if err != nil {
+			h.recordSettleFailure(ctx, wf, fmt.Errorf("settle: %w", err))
+			return
+		}
+
+		url, orderType, payload := h.webhookRequest(isRestock, update)
+		id, err := h.workflows.NotifyWorkflow(ctx, orderType, wf.OrderID, url, payload, webhookLease)
+		if errors.Is(err, store.ErrWorkflowNotFound) {
+			log.Printf("[inventory-workflow] order already notified order=%s type=%s", wf.OrderID, wf.OrderType)
+			return
+		} else if err != nil {
+			h.recordSettleFailure(ctx, wf, fmt.Errorf("notify: %w", err))
+			return
+		}
+		h.deliverWebhook(ctx, store.WebhookOutboxEntry{
+			ID:        id,
+			OrderID:   wf.OrderID,
+			OrderType: orderType,
+			TargetURL: url,
+			Payload:   payload,
+		})
+		h.publishProgress(ctx, isRestock, &pb.OrderProgressEvent{
+			OrderId:        wf.OrderID,
+			EventType:      progressFinalized,
+			Status:         update.Status,
+			ProcessedItems: pickedOnly(update.Lines),
+			Amount:         update.Amount,
+			Reason:         update.Reason,
+			ReasonCode:     update.ReasonCode,
+		})
+	}
+
+	// NOTIFIED: ordering's webhook is in the outbox, only cleanup is left.
+	h.memoryStore.DeleteOrderData(ctx, wf.OrderID, isRestock)
+	if err := h.workflows.CompleteWorkflow(ctx, wf.OrderType, wf.OrderID); err != nil {
+		log.Printf("[inventory-workflow] ERROR complete failed order=%s type=%s err=%v", wf.OrderID, wf.OrderType, err)
+		return
+	}
+	log.Printf("[inventory-workflow] order done order=%s type=%s", wf.OrderID, wf.OrderType)
+}
+
+// recordSettleFailure leaves a workflow in BILLING for the sweeper to retry, or dead-letters it once it
+// has failed settleMaxAttempts times.
+func (h *InventoryHandler) recordSettleFailure(ctx context.Context, wf *store.Workflow, err error) {
+	attempts := wf.SettleAttempts + 1
+	dead := attempts >= settleMaxAttempts
+	retryIn := settleBackoff(attempts)
+	if markErr := h.workflows.MarkSettleFailed(ctx, wf.OrderType, wf.OrderID, err.Error(), retryIn, dead); markErr != nil {
+		log.Printf("[inventory-workflow] ERROR mark settle failed order=%s type=%s err=%v", wf.OrderID, wf.OrderType, markErr)
+	}
+	if dead {
+		log.Printf("[inventory-workflow] ERROR workflow dead-lettered order=%s type=%s attempts=%d err=%v", wf.OrderID, wf.OrderType, attempts, err)
+		return
+	}
+	log.Printf("[inventory-workflow] WARN settle failed order=%s type=%s attempts=%d retry_in=%s err=%v", wf.OrderID, wf.OrderType, attempts, retryIn, err)
+}
+
+// settleBackoff returns the exponential delay before the next settlement attempt.
+func settleBackoff(attempts int) time.Duration {
+	backoff := settleBaseBackoff
+	for i := 1; i < attempts && backoff < settleMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, settleMaxBackoff)
+}
+
+// retrySettlements finishes BILLING workflows whose settlement failed earlier or was abandoned mid-way.
+func (h *InventoryHandler) retrySettlements(ctx context.Context) {
+	workflows, err := h.workflows.ClaimDueSettlements(ctx, settleBatchSize, settleLease)
+	if err != nil {
+		log.Printf("[inventory-workflow] ERROR claim due settlements err=%v", err)
+		return
+	}
+	for _, wf := range workflows {
+		log.Printf("[inventory-workflow] retrying settlement order=%s type=%s attempts=%d", wf.OrderID, wf.OrderType, wf.SettleAttempts)
+		h.finishWorkflow(ctx, wf)
+	}
+}
+
+// settleClientOrder releases unpicked stock and bills the picked quantities.
+func (h *InventoryHandler) settleClientOrder(ctx context.Context, wf *store.Workflow) (webhookUpdate, error) {
+	orderID := wf.OrderID
+	lines, status := reconcileItems(wf.Items, wf.Picked)
+	log.Printf("[inventory] finalize-client reconciled order=%s status=%s lines=%v", orderID, status, lines)
+
+	// Whatever is still held after the shortfall is released has been picked and leaves with the customer.
+	short := shortOnly(lines)
+	if err := h.workflows.SettleClientOrder(ctx, orderID, short); err != nil {
+		return webhookUpdate{}, err
+	}
+	if len(short) > 0 {
+		log.Printf("[inventory] released unpicked stock order=%s items=%v", orderID, short)
+	}
+
+	var cartItems []*pb.CartItem
+	for sku, qty := range pickedOnly(lines) {
+		cartItems = append(cartItems, &pb.CartItem{Sku: sku, Quantity: qty})
+	}
+
+	finalPrice := 0.0
+	if len(cartItems) > 0 {
+		// Orders confirmed from a quote are billed at the prices the customer saw, not today's. Without a
+		// bill the order must not be reported, or the customer would be charged nothing: the workflow stays
+		// in BILLING and is retried. Its stock is already settled, so a retry only bills.
+		resp, err := h.pricingClient.CalculateBill(ctx, &pb.CalculateBillRequest{Items: cartItems, QuoteId: wf.QuoteID})
+		if err != nil {
+			log.Printf("[inventory] pricing bill failed order=%s quote=%s err=%v", orderID, wf.QuoteID, err)
+			return webhookUpdate{}, fmt.Errorf("failed to bill order %s: %w", orderID, err)
+		}
+		finalPrice = resp.GetGrandTotal()
+		log.Printf("[inventory] pricing bill success order=%s quote=%s total=%.2f", orderID, wf.QuoteID, finalPrice)
+		h.publishProgress(ctx, false, &pb.OrderProgressEvent{
+			OrderId:        orderID,
+			EventType:      progressBilled,
+			ProcessedItems: pickedOnly(lines),
+			Amount:         finalPrice,
+		})
+	}
+
+	return webhookUpdate{OrderID: orderID, Status: status, Amount: finalPrice, Lines: lines}, nil
+}
+
+// settleRestock shelves what the robots offloaded and pushes the new stock levels to pricing.
+func (h *InventoryHandler) settleRestock(ctx context.Context, wf *store.Workflow) (webhookUpdate, error) {
+	orderID := wf.OrderID
+	requested := make(map[string]int32)
+	for _, pi := range wf.RestockItems {
+		requested[pi.GetSku()] += pi.GetQuantity()
+	}
+	lines, status := reconcileItems(requested, wf.Picked)
+	remaining := pickedOnly(lines)
+	log.Printf("[inventory] finalize-restock reconciled order=%s status=%s lines=%v", orderID, status, lines)
+
+	var totalCost float64
+	var lots []store.StockItem
+	for _, pi := range wf.RestockItems {
+		// Only shelve what the robots actually offloaded; manifest lines for one sku share its picked total.
+		qty := min(pi.GetQuantity(), remaining[pi.GetSku()])
+		if qty <= 0 {
+			continue
+		}
+		remaining[pi.GetSku()] -= qty
+		totalCost += pi.GetUnitCost() * float64(qty)
+
+		lots = append(lots, store.StockItem{
+			SKU:        pi.GetSku(),
+			Name:       pi.GetName(),
+			AisleType:  pi.GetAisleType(),
+			Quantity:   int(qty),
+			UnitCost:   pi.GetUnitCost(),
+			MfdDate:    pi.GetMfdDate().AsTime(),
+			ExpiryDate: pi.GetExpiryDate().AsTime(),
+		})
+	}
+
+	lotIDs, err := h.workflows.SettleRestock(ctx, orderID, lots)
+	if err != nil {
+		return webhookUpdate{}, err
+	}
+	// lotIDs is nil when an earlier attempt already shelved the lots.
+	for i, lotID := range lotIDs {
+		log.Printf("[inventory] finalize-restock received lot order=%s sku=%s lot=%d qty=%d expiry=%s",
+			orderID, lots[i].SKU, lotID, lots[i].Quantity, lots[i].ExpiryDate.Format(time.DateOnly))
+	}
+
+	var skus []string
+	for _, lot := range lots {
+		if !slices.Contains(skus, lot.SKU) {
+			skus = append(skus, lot.SKU)
+		}
+	}
+	if len(skus) > 0 {
+		go h.pushStockMetrics(context.Background(), skus)
+	}
+
+	return webhookUpdate{OrderID: orderID, Status: status, Amount: totalCost, Lines: lines}, nil
+}
+
+// settleFailedOrder releases any reservation held by a failed order.
+func (h *InventoryHandler) settleFailedOrder(ctx context.Context, wf *store.Workflow) (webhookUpdate, error) {
+	// Restocks reserve nothing; stock is only shelved on successful finalization.
+	var err error
+	if wf.OrderType == store.OrderTypeRestock {
+		_, err = h.workflows.SettleRestock(ctx, wf.OrderID, nil)
+	} else {
+		err = h.workflows.SettleClientOrder(ctx, wf.OrderID, wf.Items)
+	}
+	if err != nil {
+		return webhookUpdate{}, err
+	}
+	log.Printf("[inventory-workflow] WARN order failed order=%s type=%s code=%s reason=%q", wf.OrderID, wf.OrderType, wf.FailCode, wf.FailReason)
+
+	return webhookUpdate{
+		OrderID:    wf.OrderID,
+		Status:     statusFailed,
+		Reason:     wf.FailReason,
+		ReasonCode: wf.FailCode,
+	}, nil
+}
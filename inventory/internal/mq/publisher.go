@@ -3,6 +3,8 @@ package mq
 import (
 	"auto_grocery/inventory/fbs/RobotMessages"
 	"log"
+	"sort"
+	"sync"
 
 	flatbuffers "github.com/google/flatbuffers/go"
 	zmq "github.com/pebbe/zmq4"
@@ -11,9 +13,17 @@ import (
 type ItemDetails struct {
 	Quantity int32
 	Aisle    string
+	Lots     []LotPick // reserved lots to pick from, earliest expiry first; empty for restocks
+}
+
+// LotPick is the quantity a picker should take from one stock lot.
+type LotPick struct {
+	LotID    int64
+	Quantity int32
 }
 
 type Publisher struct {
+	mu     sync.Mutex // serializes multipart sends on the non-thread-safe socket
 	socket *zmq.Socket
 }
 
@@ -30,21 +40,88 @@ func NewPublisher(bindAddr string) (*Publisher, error) {
 	return &Publisher{socket: sock}, nil
 }
 
-// SendRobotCommand serializes and broadcasts robot commands for a specific order type.
+// AisleSet returns the sorted, de-duplicated aisles an order's items are routed to.
+func AisleSet(items map[string]ItemDetails) []string {
+	seen := make(map[string]struct{})
+	for _, detail := range items {
+		seen[detail.Aisle] = struct{}{}
+	}
+	aisles := make([]string, 0, len(seen))
+	for aisle := range seen {
+		aisles = append(aisles, aisle)
+	}
+	sort.Strings(aisles)
+	return aisles
+}
+
+// SendRobotCommand publishes one multipart message per aisle: the aisle name as the topic frame,
+// followed by an OrderBroadcast holding only that aisle's items plus the order's full aisle set.
 func (p *Publisher) SendRobotCommand(orderID string, orderType string, items map[string]ItemDetails) error {
-	log.Printf("[inventory-pub] building broadcast order=%s type=%s items=%d", orderID, orderType, len(items))
+	aisles := AisleSet(items)
+	byAisle := make(map[string]map[string]ItemDetails, len(aisles))
+	for sku, detail := range items {
+		if byAisle[detail.Aisle] == nil {
+			byAisle[detail.Aisle] = make(map[string]ItemDetails)
+		}
+		byAisle[detail.Aisle][sku] = detail
+	}
+	log.Printf("[inventory-pub] building broadcast order=%s type=%s items=%d aisles=%v", orderID, orderType, len(items), aisles)
+
+	for _, aisle := range aisles {
+		if err := p.SendAisleCommand(orderID, orderType, aisle, byAisle[aisle], aisles, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendAisleCommand publishes a single aisle's share of an order under that aisle's topic.
+// retry is 0 for the first dispatch and counts re-dispatches after robot failures.
+func (p *Publisher) SendAisleCommand(orderID string, orderType string, aisle string, items map[string]ItemDetails, aisles []string, retry int32) error {
+	payload := buildOrderBroadcast(orderID, orderType, items, aisles, retry)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.socket.Send(aisle, zmq.SNDMORE); err != nil {
+		log.Printf("[inventory-pub] send topic failed order=%s aisle=%s err=%v", orderID, aisle, err)
+		return err
+	}
+	if _, err := p.socket.SendBytes(payload, 0); err != nil {
+		log.Printf("[inventory-pub] send failed order=%s aisle=%s err=%v", orderID, aisle, err)
+		return err
+	}
+	log.Printf("[inventory-pub] broadcast sent order=%s aisle=%s retry=%d items=%d bytes=%d", orderID, aisle, retry, len(items), len(payload))
+	return nil
+}
+
+// buildOrderBroadcast serializes one aisle's share of an order.
+func buildOrderBroadcast(orderID string, orderType string, items map[string]ItemDetails, aisles []string, retry int32) []byte {
 	builder := flatbuffers.NewBuilder(1024)
 
 	var itemOffsets []flatbuffers.UOffsetT
 	for sku, detail := range items {
-		log.Printf("[inventory-pub] item order=%s sku=%s qty=%d aisle=%s", orderID, sku, detail.Quantity, detail.Aisle)
+		log.Printf("[inventory-pub] item order=%s sku=%s qty=%d aisle=%s lots=%v", orderID, sku, detail.Quantity, detail.Aisle, detail.Lots)
 		s := builder.CreateString(sku)
 		a := builder.CreateString(detail.Aisle)
 
+		lotOffsets := make([]flatbuffers.UOffsetT, len(detail.Lots))
+		for i, lot := range detail.Lots {
+			RobotMessages.LotPickStart(builder)
+			RobotMessages.LotPickAddLotId(builder, lot.LotID)
+			RobotMessages.LotPickAddQuantity(builder, lot.Quantity)
+			lotOffsets[i] = RobotMessages.LotPickEnd(builder)
+		}
+		RobotMessages.ItemStartLotsVector(builder, len(lotOffsets))
+		for i := len(lotOffsets) - 1; i >= 0; i-- {
+			builder.PrependUOffsetT(lotOffsets[i])
+		}
+		lotsVec := builder.EndVector(len(lotOffsets))
+
 		RobotMessages.ItemStart(builder)
 		RobotMessages.ItemAddSku(builder, s)
 		RobotMessages.ItemAddQuantity(builder, detail.Quantity)
 		RobotMessages.ItemAddAisle(builder, a)
+		RobotMessages.ItemAddLots(builder, lotsVec)
 		itemOffsets = append(itemOffsets, RobotMessages.ItemEnd(builder))
 	}
 
@@ -54,6 +131,16 @@ func (p *Publisher) SendRobotCommand(orderID string, orderType string, items map
 	}
 	itemsVec := builder.EndVector(len(itemOffsets))
 
+	aisleOffsets := make([]flatbuffers.UOffsetT, len(aisles))
+	for i, aisle := range aisles {
+		aisleOffsets[i] = builder.CreateString(aisle)
+	}
+	RobotMessages.OrderBroadcastStartAislesVector(builder, len(aisleOffsets))
+	for i := len(aisleOffsets) - 1; i >= 0; i-- {
+		builder.PrependUOffsetT(aisleOffsets[i])
+	}
+	aislesVec := builder.EndVector(len(aisleOffsets))
+
 	oid := builder.CreateString(orderID)
 	otype := builder.CreateString(orderType) // Create string for orderType
 
@@ -61,18 +148,12 @@ func (p *Publisher) SendRobotCommand(orderID string, orderType string, items map
 	RobotMessages.OrderBroadcastAddOrderId(builder, oid)
 	RobotMessages.OrderBroadcastAddOrderType(builder, otype) // Add orderType to builder
 	RobotMessages.OrderBroadcastAddItems(builder, itemsVec)
+	RobotMessages.OrderBroadcastAddAisles(builder, aislesVec)
+	RobotMessages.OrderBroadcastAddRetry(builder, retry)
 	order := RobotMessages.OrderBroadcastEnd(builder)
 
 	builder.Finish(order)
-	payload := builder.FinishedBytes()
-
-	_, err := p.socket.SendBytes(payload, 0)
-	if err != nil {
-		log.Printf("[inventory-pub] send failed order=%s err=%v", orderID, err)
-		return err
-	}
-	log.Printf("[inventory-pub] broadcast sent order=%s bytes=%d", orderID, len(payload))
-	return err
+	return builder.FinishedBytes()
 }
 
 // Close releases publisher socket resources.
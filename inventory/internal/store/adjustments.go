@@ -0,0 +1,277 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Adjustment reasons; each is also the inventory_movements reason of an applied adjustment.
+const (
+	AdjustmentCycleCount = "CYCLE_COUNT"
+	AdjustmentDamage     = "DAMAGE"
+	AdjustmentTheft      = "THEFT"
+	AdjustmentFound      = "FOUND"
+)
+
+// Adjustment lifecycle states.
+const (
+	AdjustmentPendingApproval = "PENDING_APPROVAL"
+	AdjustmentApplied         = "APPLIED"
+	AdjustmentRejected        = "REJECTED"
+)
+
+var (
+	// ErrInsufficientStock is returned when an adjustment would take a sku below zero.
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrAdjustmentNotPending is returned when deciding an adjustment that is unknown or already decided.
+	ErrAdjustmentNotPending = errors.New("adjustment not pending approval")
+	// ErrSelfApproval is returned when the requester tries to decide their own adjustment.
+	ErrSelfApproval = errors.New("adjustment must be decided by a second person")
+)
+
+// StockAdjustment is one manual correction of a sku's stock.
+type StockAdjustment struct {
+	ID              int64
+	SKU             string
+	Reason          string
+	Delta           int
+	CountedQuantity *int // set for cycle counts
+	Status          string
+	RequestedBy     string
+	DecidedBy       string
+	Note            string
+	QuantityAfter   int // set once APPLIED
+	CreatedAt       time.Time
+}
+
+// CreateAdjustment records a correction and applies it at once unless |delta| exceeds approvalThreshold
+// (0 disables approval). For cycle counts the delta is derived from CountedQuantity and the stock that
+// should be on the shelf: sellable stock plus units orders hold that robots have not picked yet.
+func (s *Store) CreateAdjustment(ctx context.Context, adj StockAdjustment, approvalThreshold int) (*StockAdjustment, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := lockStockQuantity(ctx, tx, adj.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if adj.CountedQuantity != nil {
+		unpicked, err := unpickedHeldQuantity(ctx, tx, adj.SKU)
+		if err != nil {
+			return nil, err
+		}
+		adj.Delta = *adj.CountedQuantity - (current + unpicked)
+	}
+	if current+adj.Delta < 0 {
+		return nil, ErrInsufficientStock
+	}
+
+	adj.Status = AdjustmentApplied
+	if approvalThreshold > 0 && max(adj.Delta, -adj.Delta) > approvalThreshold {
+		adj.Status = AdjustmentPendingApproval
+	}
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO stock_adjustments (sku, reason, delta, counted_quantity, status, requested_by, note)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at
+    `, adj.SKU, adj.Reason, adj.Delta, adj.CountedQuantity, adj.Status, adj.RequestedBy, adj.Note).Scan(&adj.ID, &adj.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record adjustment: %w", err)
+	}
+
+	if adj.Status == AdjustmentApplied {
+		if err := applyAdjustment(ctx, tx, &adj); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+	return &adj, nil
+}
+
+// DecideAdjustment approves (applying it) or rejects a pending adjustment on behalf of a second person.
+func (s *Store) DecideAdjustment(ctx context.Context, id int64, decidedBy string, approve bool) (*StockAdjustment, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide adjustment: %w", err)
+	}
+	defer tx.Rollback()
+
+	var adj StockAdjustment
+	var counted sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+        SELECT id, sku, reason, delta, counted_quantity, status, requested_by, note, created_at
+        FROM stock_adjustments
+        WHERE id = $1
+        FOR UPDATE
+    `, id).Scan(&adj.ID, &adj.SKU, &adj.Reason, &adj.Delta, &counted, &adj.Status, &adj.RequestedBy, &adj.Note, &adj.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAdjustmentNotPending
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load adjustment: %w", err)
+	}
+	if counted.Valid {
+		n := int(counted.Int64)
+		adj.CountedQuantity = &n
+	}
+	if adj.Status != AdjustmentPendingApproval {
+		return nil, ErrAdjustmentNotPending
+	}
+	if decidedBy == adj.RequestedBy {
+		return nil, ErrSelfApproval
+	}
+	adj.DecidedBy = decidedBy
+
+	if approve {
+		// The delta was fixed when the adjustment was requested; stock may have moved since.
+		current, err := lockStockQuantity(ctx, tx, adj.SKU)
+		if err != nil {
+			return nil, err
+		}
+		if current+adj.Delta < 0 {
+			return nil, ErrInsufficientStock
+		}
+		if err := applyAdjustment(ctx, tx, &adj); err != nil {
+			return nil, err
+		}
+		adj.Status = AdjustmentApplied
+	} else {
+		adj.Status = AdjustmentRejected
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE stock_adjustments
+        SET status = $2, decided_by = $3, decided_at = NOW()
+        WHERE id = $1
+    `, adj.ID, adj.Status, adj.DecidedBy); err != nil {
+		return nil, fmt.Errorf("failed to decide adjustment: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to decide adjustment: %w", err)
+	}
+	return &adj, nil
+}
+
+// unpickedHeldQuantity counts a sku's units that reservations took out of its lots but that are still on
+// the shelf: each order's held units less what its robots have reported picked. The caller must hold the
+// sku's stock row lock, which every reserve and release takes first.
+func unpickedHeldQuantity(ctx context.Context, tx *sql.Tx, sku string) (int, error) {
+	var unpicked int
+	err := tx.QueryRowContext(ctx, `
+        SELECT COALESCE(SUM(h.held - LEAST(COALESCE((w.picked->>$1)::int, 0), h.held)), 0)::int
+        FROM (
+            SELECT r.order_id, SUM(r.quantity)::int AS held
+            FROM lot_reservations r
+            JOIN stock_lots l ON l.id = r.lot_id
+            WHERE l.sku = $1
+            GROUP BY r.order_id
+        ) h
+        LEFT JOIN fulfillment_workflows w ON w.order_type = $2 AND w.order_id = h.order_id
+    `, sku, OrderTypeCustomer).Scan(&unpicked)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count held stock: %w", err)
+	}
+	return unpicked, nil
+}
+
+// lockStockQuantity locks a sku's stock row and returns its quantity.
+func lockStockQuantity(ctx context.Context, tx *sql.Tx, sku string) (int, error) {
+	var quantity int
+	err := tx.QueryRowContext(ctx, `
+        SELECT quantity FROM available_stock WHERE sku = $1 FOR UPDATE
+    `, sku).Scan(&quantity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrUnknownSKU
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to lock stock row: %w", err)
+	}
+	return quantity, nil
+}
+
+// applyAdjustment changes the sku's lots by adj.Delta, journals it and records the resulting quantity.
+// Added units go to the newest lot; removed units come from the earliest-expiring lots first.
+func applyAdjustment(ctx context.Context, tx *sql.Tx, adj *StockAdjustment) error {
+	if adj.Delta > 0 {
+		if err := returnToNewestLot(ctx, tx, adj.SKU, int32(adj.Delta)); err != nil {
+			return fmt.Errorf("failed to apply adjustment: %w", err)
+		}
+	} else if adj.Delta < 0 {
+		if err := removeFromLots(ctx, tx, adj.SKU, int32(-adj.Delta)); err != nil {
+			return fmt.Errorf("failed to apply adjustment: %w", err)
+		}
+	}
+
+	balances, err := refreshStockTotals(ctx, tx, []string{adj.SKU})
+	if err != nil {
+		return fmt.Errorf("failed to apply adjustment: %w", err)
+	}
+	deltas := map[string]int32{adj.SKU: int32(adj.Delta)}
+	if err := recordMovements(ctx, tx, adj.Reason, "", deltas, balances); err != nil {
+		return fmt.Errorf("failed to apply adjustment: %w", err)
+	}
+	adj.QuantityAfter = balances[adj.SKU]
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE stock_adjustments SET quantity_after = $2 WHERE id = $1
+    `, adj.ID, adj.QuantityAfter); err != nil {
+		return fmt.Errorf("failed to apply adjustment: %w", err)
+	}
+	return nil
+}
+
+// removeFromLots takes qty units out of a sku's lots, earliest expiry first, expired lots included.
+func removeFromLots(ctx context.Context, tx *sql.Tx, sku string, qty int32) error {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, quantity
+        FROM stock_lots
+        WHERE sku = $1 AND quantity > 0
+        ORDER BY expiry_date ASC NULLS LAST, id
+        FOR UPDATE
+    `, sku)
+	if err != nil {
+		return err
+	}
+
+	var lotIDs []int64
+	var removed []int32
+	for rows.Next() && qty > 0 {
+		var lotID int64
+		var quantity int32
+		if err := rows.Scan(&lotID, &quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		take := min(quantity, qty)
+		qty -= take
+		lotIDs = append(lotIDs, lotID)
+		removed = append(removed, take)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if qty > 0 {
+		return ErrInsufficientStock
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE stock_lots l
+        SET quantity = l.quantity - d.removed
+        FROM (
+            SELECT unnest($1::bigint[]) as id, unnest($2::int[]) as removed
+        ) as d
+        WHERE l.id = d.id
+    `, pq.Array(lotIDs), pq.Array(removed))
+	return err
+}
@@ -2,120 +2,135 @@ package store
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"time"
 
+	"auto_grocery/inventory/internal/mq"
 	pb "auto_grocery/inventory/proto"
-
-	"github.com/redis/go-redis/v9"
 )
 
-type MemoryStore struct {
-	clientClient  *redis.Client // Database 0 (Clients)
-	restockClient *redis.Client // Database 1 (Restocks)
+// ErrOrderNotTracked is returned when the memory store holds no workflow state for an order.
+var ErrOrderNotTracked = errors.New("order not tracked")
+
+// MemoryStore holds the short-lived workflow state of dispatched orders, the robot fleet registry and
+// order progress fan-out. isRestock selects the order flow; order keys are namespaced by flow (see orderKey).
+type MemoryStore interface {
+	// Ping verifies the backend is reachable.
+	Ping(ctx context.Context) error
+
+	// SaveOrderItems stores a client order's items until it is finalized.
+	SaveOrderItems(ctx context.Context, orderID string, items map[string]int32) error
+	// GetOrderItems loads a client order's items, or returns ErrOrderNotTracked.
+	GetOrderItems(ctx context.Context, orderID string) (map[string]int32, error)
+	// SaveRestockItems stores a restock order's items until it is finalized.
+	SaveRestockItems(ctx context.Context, orderID string, items []*pb.RestockItem) error
+	// GetRestockItems loads a restock order's items, or returns ErrOrderNotTracked.
+	GetRestockItems(ctx context.Context, orderID string) ([]*pb.RestockItem, error)
+
+	// RegisterRobot records a robot's aisle and marks it alive for ttl.
+	RegisterRobot(ctx context.Context, robotID string, aisle string, ttl time.Duration) error
+	// TouchRobot extends a registered robot's liveness and reports whether it was still registered.
+	TouchRobot(ctx context.Context, robotID string, ttl time.Duration) (bool, error)
+	// LiveFleet returns the number of live robots per aisle.
+	LiveFleet(ctx context.Context, ttl time.Duration) (map[string]int, error)
+
+	// SaveExpectedReports stores the aisles and report count a dispatched order waits for.
+	SaveExpectedReports(ctx context.Context, orderID string, isRestock bool, aisles []string, expected int) error
+	// GetExpectedReports loads them, or returns ErrOrderNotTracked.
+	GetExpectedReports(ctx context.Context, orderID string, isRestock bool) ([]string, int, error)
+	// SetPickedItems replaces an order's picked quantities per sku, as when restoring them from its workflow.
+	SetPickedItems(ctx context.Context, orderID string, isRestock bool, items map[string]int32) error
+	// GetPickedItems loads the reconciled picked quantities per sku.
+	GetPickedItems(ctx context.Context, orderID string, isRestock bool) (map[string]int32, error)
+	// RecordRobotReport atomically counts one robot report: it records the reporter, lowers each sku's picked
+	// quantity to the report's when smaller, checks the count against the expected reports and claims
+	// finalization if this report completed the order. A repeat report from the same robot changes no counts,
+	// but claims finalization again if the order is complete and its claim was cleared.
+	// It returns ErrOrderNotTracked for unknown orders.
+	RecordRobotReport(ctx context.Context, orderID string, isRestock bool, robotID string, picked map[string]int32) (ReportOutcome, error)
+	// RecordReporter adds a robot to the order's reporters and reports whether it is new.
+	RecordReporter(ctx context.Context, orderID string, isRestock bool, robotID string) (bool, error)
+	// GetRobotCount returns how many robot reports have been counted.
+	GetRobotCount(ctx context.Context, orderID string, isRestock bool) (int64, error)
+	// SetRobotCount restores the counted reports of an order resumed from its workflow.
+	SetRobotCount(ctx context.Context, orderID string, isRestock bool, count int64) error
+	// MarkInFlight records when an order was dispatched.
+	MarkInFlight(ctx context.Context, orderID string, isRestock bool, dispatchedAt time.Time) error
+	// ListInFlightBefore returns orders dispatched at or before cutoff and not yet deleted.
+	ListInFlightBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error)
+	// ForgetInFlight removes an order from the in-flight index once it no longer needs sweeping.
+	ForgetInFlight(ctx context.Context, orderID string, isRestock bool) error
+	// RecordSweepFailure counts a failed sweeper claim of an order and returns the order's total.
+	RecordSweepFailure(ctx context.Context, orderID string, isRestock bool) (int64, error)
+	// TryMarkOrderFinalized claims the order's one-time finalization and reports whether this caller won.
+	TryMarkOrderFinalized(ctx context.Context, orderID string, isRestock bool) (bool, error)
+	// ClearOrderFinalized gives back a finalization claim whose durable workflow claim failed.
+	ClearOrderFinalized(ctx context.Context, orderID string, isRestock bool) error
+	// DeleteOrderData drops every piece of state kept for the order.
+	DeleteOrderData(ctx context.Context, orderID string, isRestock bool)
+
+	// PublishOrderProgress sends a serialized progress event to the order's watchers.
+	PublishOrderProgress(ctx context.Context, orderType string, orderID string, payload []byte) error
+	// SubscribeOrderProgress streams the order's progress events until ctx ends or close is called.
+	SubscribeOrderProgress(ctx context.Context, orderType string, orderID string) (<-chan []byte, func() error, error)
+
+	// SaveDispatchLedger records every aisle task of an order as sent once and unacknowledged.
+	SaveDispatchLedger(ctx context.Context, orderID string, isRestock bool, items map[string]mq.ItemDetails, aisles []string, sentAt time.Time) error
+	// RemoveDispatchTask acknowledges an aisle task and reports whether it was still pending.
+	RemoveDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string) (bool, error)
+	// ListDispatchesSentBefore returns orders with unacknowledged tasks last sent at or before cutoff.
+	ListDispatchesSentBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error)
+	// GetPendingDispatch loads send attempts per unacknowledged aisle and the order's robot items.
+	GetPendingDispatch(ctx context.Context, orderID string, isRestock bool) (map[string]int, map[string]mq.ItemDetails, error)
+	// MarkRedelivered bumps the send attempts of re-broadcast aisles.
+	MarkRedelivered(ctx context.Context, orderID string, isRestock bool, aisles []string, sentAt time.Time) error
+	// AddDispatchTask puts an aisle task back in the ledger as freshly sent.
+	AddDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string, sentAt time.Time) error
+	// GetDispatchItems loads the robot items recorded at dispatch, or returns ErrOrderNotTracked.
+	GetDispatchItems(ctx context.Context, orderID string, isRestock bool) (map[string]mq.ItemDetails, error)
+	// RecordAisleFailure counts the first FAILED report per aisle retry and returns the aisle's total.
+	RecordAisleFailure(ctx context.Context, orderID string, isRestock bool, aisle string, retry int32) (int64, bool, error)
+	// GetAisleFailures returns FAILED report counts per aisle.
+	GetAisleFailures(ctx context.Context, orderID string, isRestock bool) (map[string]int, error)
+	// ForgetDispatch removes an order from redelivery tracking.
+	ForgetDispatch(ctx context.Context, orderID string, isRestock bool) error
 }
 
-// NewMemoryStore creates redis clients for client and restock data partitions.
-func NewMemoryStore(addr string, password string) *MemoryStore {
-	return &MemoryStore{
-		clientClient: redis.NewClient(&redis.Options{
-			Addr:     addr,
-			Password: password,
-			DB:       0,
-		}),
-		restockClient: redis.NewClient(&redis.Options{
-			Addr:     addr,
-			Password: password,
-			DB:       1,
-		}),
-	}
-}
+// orderStateTTL bounds how long workflow state outlives an order that is never finalized.
+const orderStateTTL = 1 * time.Hour
 
-// Ping verifies connectivity and credentials for both redis databases.
-func (m *MemoryStore) Ping(ctx context.Context) error {
-	if err := m.clientClient.Ping(ctx).Err(); err != nil {
-		return err
-	}
-	if err := m.restockClient.Ping(ctx).Err(); err != nil {
-		return err
-	}
-	return nil
+// ReportOutcome is the result of counting one robot report.
+type ReportOutcome struct {
+	Duplicate bool  // the robot had already reported; nothing was recorded
+	Count     int64 // counted reports, including this one
+	Expected  int
+	Finalize  bool // this report completed the order and claimed its one-time finalization
 }
 
-// --- Client Methods (DB 0) ---
-
-// SaveOrderItems stores client-order items in redis with a bounded ttl.
-func (m *MemoryStore) SaveOrderItems(ctx context.Context, orderID string, items map[string]int32) error {
-	data, err := json.Marshal(items)
-	if err != nil {
-		return err
-	}
-	// Key: orderID:items
-	return m.clientClient.Set(ctx, orderID+":items", data, 1*time.Hour).Err()
+// orderKey names one piece of an order's state: order:<type>:<order_id>:<suffix>.
+func orderKey(isRestock bool, orderID string, suffix string) string {
+	return "order:" + orderFlow(isRestock) + ":" + orderID + ":" + suffix
 }
 
-// GetOrderItems loads client-order items from redis.
-func (m *MemoryStore) GetOrderItems(ctx context.Context, orderID string) (map[string]int32, error) {
-	val, err := m.clientClient.Get(ctx, orderID+":items").Result()
-	if err != nil {
-		return nil, err
-	}
-	var items map[string]int32
-	err = json.Unmarshal([]byte(val), &items)
-	return items, err
-}
-
-// IncrementClientRobotCount increments completed robot callbacks for client orders.
-func (m *MemoryStore) IncrementClientRobotCount(ctx context.Context, orderID string) (int64, error) {
-	key := orderID + ":count"
-	return m.clientClient.Incr(ctx, key).Result()
+// orderIndexKey names an index over every order of one flow: orders:<type>:<name>.
+func orderIndexKey(isRestock bool, name string) string {
+	return "orders:" + orderFlow(isRestock) + ":" + name
 }
 
-// --- Restock Methods (DB 1) ---
-
-// SaveRestockItems stores restock-order items in redis with a bounded ttl.
-func (m *MemoryStore) SaveRestockItems(ctx context.Context, orderID string, items []*pb.RestockItem) error {
-	data, err := json.Marshal(items)
-	if err != nil {
-		return err
-	}
-	// Key: orderID:items
-	return m.restockClient.Set(ctx, orderID+":items", data, 1*time.Hour).Err()
-}
-
-// GetRestockItems loads restock-order items from redis.
-func (m *MemoryStore) GetRestockItems(ctx context.Context, orderID string) ([]*pb.RestockItem, error) {
-	val, err := m.restockClient.Get(ctx, orderID+":items").Result()
-	if err != nil {
-		return nil, err
-	}
-	var items []*pb.RestockItem
-	err = json.Unmarshal([]byte(val), &items)
-	return items, err
-}
-
-// IncrementRestockRobotCount increments completed robot callbacks for restock orders.
-func (m *MemoryStore) IncrementRestockRobotCount(ctx context.Context, orderID string) (int64, error) {
-	key := orderID + ":count"
-	return m.restockClient.Incr(ctx, key).Result()
-}
-
-// --- Lifecycle Management ---
-
-// DeleteOrderData clears transient redis keys for either order flow.
-func (m *MemoryStore) DeleteOrderData(ctx context.Context, orderID string, isRestock bool) {
+// orderFlow maps the flow flag to its order type.
+func orderFlow(isRestock bool) string {
 	if isRestock {
-		m.restockClient.Del(ctx, orderID+":items", orderID+":count", orderID+":finalized")
-	} else {
-		m.clientClient.Del(ctx, orderID+":items", orderID+":count", orderID+":finalized")
+		return OrderTypeRestock
 	}
+	return OrderTypeCustomer
 }
 
-// TryMarkOrderFinalized sets a one-time finalize marker using SETNX semantics.
-func (m *MemoryStore) TryMarkOrderFinalized(ctx context.Context, orderID string, isRestock bool) (bool, error) {
-	key := orderID + ":finalized"
-	if isRestock {
-		return m.restockClient.SetNX(ctx, key, "1", 1*time.Hour).Result()
-	}
-	return m.clientClient.SetNX(ctx, key, "1", 1*time.Hour).Result()
-}
+// orderStateSuffixes lists every per-order key, so an order's state can be dropped as a whole.
+var orderStateSuffixes = []string{"items", "count", "finalized", "aisles", "expected", "picked", "reporters",
+	"dispatch", "dispatch_items", "aisle_failures", "failed_tasks", "sweep_failures"}
+
+// Order index names.
+const (
+	inFlightIndex        = "inflight"         // dispatched orders by dispatch time
+	dispatchPendingIndex = "dispatch_pending" // orders with unacknowledged aisle tasks by last send time
+)
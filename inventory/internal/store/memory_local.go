@@ -0,0 +1,595 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	pb "auto_grocery/inventory/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var _ MemoryStore = (*LocalMemoryStore)(nil)
+
+// localSubscriberBuffer is how many progress events a slow watcher may fall behind before events are dropped.
+const localSubscriberBuffer = 64
+
+// localEntry is one key of the in-process keyspace; a zero expiresAt never expires.
+type localEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// LocalMemoryStore keeps workflow state in process memory, for development and tests.
+// It mirrors the redis keyspace key for key, including per-key expiry, but nothing survives a restart.
+type LocalMemoryStore struct {
+	mu        sync.Mutex
+	keys      map[string]localEntry
+	subs      map[string]map[chan []byte]struct{}
+	lastSweep time.Time
+}
+
+// NewLocalMemoryStore creates an empty in-process memory store.
+func NewLocalMemoryStore() *LocalMemoryStore {
+	return &LocalMemoryStore{
+		keys:      make(map[string]localEntry),
+		subs:      make(map[string]map[chan []byte]struct{}),
+		lastSweep: time.Now(),
+	}
+}
+
+// Ping always succeeds.
+func (m *LocalMemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// --- Client and Restock Items ---
+
+// SaveOrderItems stores client-order items with a bounded ttl.
+func (m *LocalMemoryStore) SaveOrderItems(ctx context.Context, orderID string, items map[string]int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(orderKey(false, orderID, "items"), maps.Clone(items), orderStateTTL)
+	return nil
+}
+
+// GetOrderItems loads client-order items.
+func (m *LocalMemoryStore) GetOrderItems(ctx context.Context, orderID string) (map[string]int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items, ok := m.get(orderKey(false, orderID, "items")).(map[string]int32)
+	if !ok {
+		return nil, ErrOrderNotTracked
+	}
+	return maps.Clone(items), nil
+}
+
+// SaveRestockItems stores restock-order items with a bounded ttl.
+func (m *LocalMemoryStore) SaveRestockItems(ctx context.Context, orderID string, items []*pb.RestockItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(orderKey(true, orderID, "items"), cloneRestockItems(items), orderStateTTL)
+	return nil
+}
+
+// GetRestockItems loads restock-order items.
+func (m *LocalMemoryStore) GetRestockItems(ctx context.Context, orderID string) ([]*pb.RestockItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items, ok := m.get(orderKey(true, orderID, "items")).([]*pb.RestockItem)
+	if !ok {
+		return nil, ErrOrderNotTracked
+	}
+	return cloneRestockItems(items), nil
+}
+
+// --- Fleet Registry ---
+
+// RegisterRobot records a robot's aisle and marks it alive as of now.
+func (m *LocalMemoryStore) RegisterRobot(ctx context.Context, robotID string, aisle string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(fleetRobotKeyBase+robotID, aisle, ttl)
+	m.zset(fleetMembersKey)[robotID] = time.Now()
+	return nil
+}
+
+// TouchRobot refreshes a robot heartbeat and reports whether the robot is still registered.
+func (m *LocalMemoryStore) TouchRobot(ctx context.Context, robotID string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.expire(fleetRobotKeyBase+robotID, ttl) {
+		return false, nil
+	}
+	m.zset(fleetMembersKey)[robotID] = time.Now()
+	return true, nil
+}
+
+// LiveFleet returns the number of robots per aisle that heartbeated within ttl.
+func (m *LocalMemoryStore) LiveFleet(ctx context.Context, ttl time.Duration) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cutoff := time.Now().Add(-ttl)
+	members := m.zset(fleetMembersKey)
+	fleet := make(map[string]int)
+	for robotID, seen := range members {
+		if seen.Before(cutoff) {
+			delete(members, robotID)
+			continue
+		}
+		if aisle, ok := m.get(fleetRobotKeyBase + robotID).(string); ok {
+			fleet[aisle]++
+		}
+	}
+	return fleet, nil
+}
+
+// --- Lifecycle Management ---
+
+// SaveExpectedReports stores how many robot reports an order needs and which aisles they come from.
+func (m *LocalMemoryStore) SaveExpectedReports(ctx context.Context, orderID string, isRestock bool, aisles []string, expected int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(orderKey(isRestock, orderID, "aisles"), slices.Clone(aisles), orderStateTTL)
+	m.set(orderKey(isRestock, orderID, "expected"), expected, orderStateTTL)
+	return nil
+}
+
+// GetExpectedReports loads the aisle set and report count captured at dispatch time.
+func (m *LocalMemoryStore) GetExpectedReports(ctx context.Context, orderID string, isRestock bool) ([]string, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	aisles, ok := m.get(orderKey(isRestock, orderID, "aisles")).([]string)
+	if !ok {
+		return nil, 0, ErrOrderNotTracked
+	}
+	expected, ok := m.get(orderKey(isRestock, orderID, "expected")).(int)
+	if !ok {
+		return nil, 0, ErrOrderNotTracked
+	}
+	return slices.Clone(aisles), expected, nil
+}
+
+// SetPickedItems replaces an order's picked quantities per sku.
+func (m *LocalMemoryStore) SetPickedItems(ctx context.Context, orderID string, isRestock bool, items map[string]int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "picked")
+	if len(items) == 0 {
+		delete(m.keys, key)
+		return nil
+	}
+	m.set(key, maps.Clone(items), orderStateTTL)
+	return nil
+}
+
+// GetPickedItems loads the reconciled picked quantities for an order.
+func (m *LocalMemoryStore) GetPickedItems(ctx context.Context, orderID string, isRestock bool) (map[string]int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	picked, _ := m.get(orderKey(isRestock, orderID, "picked")).(map[string]int32)
+	if picked == nil {
+		return map[string]int32{}, nil
+	}
+	return maps.Clone(picked), nil
+}
+
+// RecordRobotReport records the reporter, its picked items and the report count, and claims finalization
+// once the count reaches the expected reports, all under one lock.
+func (m *LocalMemoryStore) RecordRobotReport(ctx context.Context, orderID string, isRestock bool, robotID string, picked map[string]int32) (ReportOutcome, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.get(orderKey(isRestock, orderID, "aisles")) == nil {
+		return ReportOutcome{}, ErrOrderNotTracked
+	}
+	expected, _ := m.get(orderKey(isRestock, orderID, "expected")).(int)
+	countKey := orderKey(isRestock, orderID, "count")
+	count, _ := m.get(countKey).(int64)
+	finalizedKey := orderKey(isRestock, orderID, "finalized")
+	if robotID != "" && !m.sadd(orderKey(isRestock, orderID, "reporters"), robotID, orderStateTTL) {
+		outcome := ReportOutcome{Duplicate: true, Count: count, Expected: expected}
+		if count >= int64(expected) && m.get(finalizedKey) == nil {
+			m.set(finalizedKey, true, orderStateTTL)
+			outcome.Finalize = true
+		}
+		return outcome, nil
+	}
+
+	if len(picked) > 0 {
+		pickedKey := orderKey(isRestock, orderID, "picked")
+		total, ok := m.get(pickedKey).(map[string]int32)
+		if !ok {
+			total = make(map[string]int32)
+		}
+		for sku, qty := range picked {
+			if current, ok := total[sku]; !ok || qty < current {
+				total[sku] = qty
+			}
+		}
+		m.set(pickedKey, total, orderStateTTL)
+	}
+	count++
+	m.set(countKey, count, orderStateTTL)
+
+	outcome := ReportOutcome{Count: count, Expected: expected}
+	if count >= int64(expected) && m.get(finalizedKey) == nil {
+		m.set(finalizedKey, true, orderStateTTL)
+		outcome.Finalize = true
+	}
+	return outcome, nil
+}
+
+// RecordReporter adds a robot to the order's reporter set and reports whether it is reporting for the first time.
+func (m *LocalMemoryStore) RecordReporter(ctx context.Context, orderID string, isRestock bool, robotID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sadd(orderKey(isRestock, orderID, "reporters"), robotID, orderStateTTL), nil
+}
+
+// GetRobotCount returns how many counted robot reports an order has received.
+func (m *LocalMemoryStore) GetRobotCount(ctx context.Context, orderID string, isRestock bool) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count, _ := m.get(orderKey(isRestock, orderID, "count")).(int64)
+	return count, nil
+}
+
+// SetRobotCount overwrites the counted robot reports, e.g. when restoring an order from its workflow.
+func (m *LocalMemoryStore) SetRobotCount(ctx context.Context, orderID string, isRestock bool, count int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(orderKey(isRestock, orderID, "count"), count, orderStateTTL)
+	return nil
+}
+
+// MarkInFlight records when an order was dispatched so stuck orders can be swept.
+func (m *LocalMemoryStore) MarkInFlight(ctx context.Context, orderID string, isRestock bool, dispatchedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.zset(orderIndexKey(isRestock, inFlightIndex))[orderID] = dispatchedAt
+	return nil
+}
+
+// ListInFlightBefore returns orders dispatched before the cutoff that have not been cleaned up.
+func (m *LocalMemoryStore) ListInFlightBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return zrangeBefore(m.zset(orderIndexKey(isRestock, inFlightIndex)), cutoff), nil
+}
+
+// ForgetInFlight drops an order from the in-flight index.
+func (m *LocalMemoryStore) ForgetInFlight(ctx context.Context, orderID string, isRestock bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.zset(orderIndexKey(isRestock, inFlightIndex)), orderID)
+	return nil
+}
+
+// RecordSweepFailure counts one failed sweeper claim and returns the order's total.
+func (m *LocalMemoryStore) RecordSweepFailure(ctx context.Context, orderID string, isRestock bool) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "sweep_failures")
+	failures, _ := m.get(key).(int64)
+	failures++
+	m.set(key, failures, orderStateTTL)
+	return failures, nil
+}
+
+// TryMarkOrderFinalized sets a one-time finalize marker with SETNX semantics.
+func (m *LocalMemoryStore) TryMarkOrderFinalized(ctx context.Context, orderID string, isRestock bool) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "finalized")
+	if m.get(key) != nil {
+		return false, nil
+	}
+	m.set(key, true, orderStateTTL)
+	return true, nil
+}
+
+// ClearOrderFinalized removes the finalize marker so the order can be claimed again.
+func (m *LocalMemoryStore) ClearOrderFinalized(ctx context.Context, orderID string, isRestock bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, orderKey(isRestock, orderID, "finalized"))
+	return nil
+}
+
+// DeleteOrderData clears transient keys for either order flow.
+func (m *LocalMemoryStore) DeleteOrderData(ctx context.Context, orderID string, isRestock bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, suffix := range orderStateSuffixes {
+		delete(m.keys, orderKey(isRestock, orderID, suffix))
+	}
+	delete(m.zset(orderIndexKey(isRestock, inFlightIndex)), orderID)
+	delete(m.zset(orderIndexKey(isRestock, dispatchPendingIndex)), orderID)
+}
+
+// --- Progress Events ---
+
+// PublishOrderProgress fans a serialized progress event out to every watcher of the order.
+// A watcher whose buffer is full misses the event, as a lagging redis subscriber would.
+func (m *LocalMemoryStore) PublishOrderProgress(ctx context.Context, orderType string, orderID string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for ch := range m.subs[progressChannel(orderType, orderID)] {
+		select {
+		case ch <- slices.Clone(payload):
+		default:
+		}
+	}
+	return nil
+}
+
+// SubscribeOrderProgress streams serialized progress events for an order until ctx ends or close is called.
+func (m *LocalMemoryStore) SubscribeOrderProgress(ctx context.Context, orderType string, orderID string) (<-chan []byte, func() error, error) {
+	channel := progressChannel(orderType, orderID)
+	events := make(chan []byte, localSubscriberBuffer)
+
+	m.mu.Lock()
+	if m.subs[channel] == nil {
+		m.subs[channel] = make(map[chan []byte]struct{})
+	}
+	m.subs[channel][events] = struct{}{}
+	m.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() error {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			delete(m.subs[channel], events)
+			if len(m.subs[channel]) == 0 {
+				delete(m.subs, channel)
+			}
+			close(events)
+		})
+		return nil
+	}
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return events, unsubscribe, nil
+}
+
+// --- Dispatch Ledger ---
+
+// SaveDispatchLedger records every aisle task of an order as sent once and awaiting acknowledgement.
+func (m *LocalMemoryStore) SaveDispatchLedger(ctx context.Context, orderID string, isRestock bool, items map[string]mq.ItemDetails, aisles []string, sentAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(orderKey(isRestock, orderID, "dispatch_items"), maps.Clone(items), orderStateTTL)
+	key := orderKey(isRestock, orderID, "dispatch")
+	attempts, ok := m.get(key).(map[string]int)
+	if !ok {
+		attempts = make(map[string]int)
+	}
+	for _, aisle := range aisles {
+		attempts[aisle] = 1
+	}
+	m.set(key, attempts, orderStateTTL)
+	m.zset(orderIndexKey(isRestock, dispatchPendingIndex))[orderID] = sentAt
+	return nil
+}
+
+// RemoveDispatchTask drops an aisle task from the ledger and reports whether it was still pending.
+func (m *LocalMemoryStore) RemoveDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "dispatch")
+	attempts, _ := m.get(key).(map[string]int)
+	_, removed := attempts[aisle]
+	delete(attempts, aisle)
+	if len(attempts) == 0 {
+		delete(m.keys, key)
+		delete(m.zset(orderIndexKey(isRestock, dispatchPendingIndex)), orderID)
+	}
+	return removed, nil
+}
+
+// ListDispatchesSentBefore returns orders with pending aisle tasks last sent before the cutoff.
+func (m *LocalMemoryStore) ListDispatchesSentBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return zrangeBefore(m.zset(orderIndexKey(isRestock, dispatchPendingIndex)), cutoff), nil
+}
+
+// GetPendingDispatch loads send attempts per unacknowledged aisle and the order's robot items.
+func (m *LocalMemoryStore) GetPendingDispatch(ctx context.Context, orderID string, isRestock bool) (map[string]int, map[string]mq.ItemDetails, error) {
+	items, err := m.GetDispatchItems(ctx, orderID, isRestock)
+	if err != nil {
+		return nil, nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	attempts, _ := m.get(orderKey(isRestock, orderID, "dispatch")).(map[string]int)
+	if attempts == nil {
+		return map[string]int{}, items, nil
+	}
+	return maps.Clone(attempts), items, nil
+}
+
+// MarkRedelivered bumps the send attempts of re-broadcast aisles and restarts the order's redelivery clock.
+func (m *LocalMemoryStore) MarkRedelivered(ctx context.Context, orderID string, isRestock bool, aisles []string, sentAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "dispatch")
+	attempts, ok := m.get(key).(map[string]int)
+	if !ok {
+		// Like HINCRBY on a missing hash, this recreates the ledger without a ttl.
+		attempts = make(map[string]int)
+		m.set(key, attempts, 0)
+	}
+	for _, aisle := range aisles {
+		attempts[aisle]++
+	}
+	m.zset(orderIndexKey(isRestock, dispatchPendingIndex))[orderID] = sentAt
+	return nil
+}
+
+// AddDispatchTask puts an aisle task back in the ledger as freshly sent, e.g. after a failure re-dispatch.
+func (m *LocalMemoryStore) AddDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string, sentAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "dispatch")
+	attempts, ok := m.get(key).(map[string]int)
+	if !ok {
+		attempts = make(map[string]int)
+	}
+	attempts[aisle] = 1
+	m.set(key, attempts, orderStateTTL)
+	m.zset(orderIndexKey(isRestock, dispatchPendingIndex))[orderID] = sentAt
+	return nil
+}
+
+// GetDispatchItems loads the robot items recorded when the order was dispatched.
+func (m *LocalMemoryStore) GetDispatchItems(ctx context.Context, orderID string, isRestock bool) (map[string]mq.ItemDetails, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items, ok := m.get(orderKey(isRestock, orderID, "dispatch_items")).(map[string]mq.ItemDetails)
+	if !ok {
+		return nil, ErrOrderNotTracked
+	}
+	return maps.Clone(items), nil
+}
+
+// RecordAisleFailure counts a FAILED report for one retry of an aisle task and returns the aisle's total.
+// Only the first FAILED report per aisle retry counts; later ones return counted=false.
+func (m *LocalMemoryStore) RecordAisleFailure(ctx context.Context, orderID string, isRestock bool, aisle string, retry int32) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := orderKey(isRestock, orderID, "aisle_failures")
+	failures, ok := m.get(key).(map[string]int)
+	if !ok {
+		failures = make(map[string]int)
+	}
+	if !m.sadd(orderKey(isRestock, orderID, "failed_tasks"), fmt.Sprintf("%s#%d", aisle, retry), orderStateTTL) {
+		return int64(failures[aisle]), false, nil
+	}
+	failures[aisle]++
+	m.set(key, failures, orderStateTTL)
+	return int64(failures[aisle]), true, nil
+}
+
+// GetAisleFailures returns FAILED report counts per aisle, which is also each aisle's current retry number.
+func (m *LocalMemoryStore) GetAisleFailures(ctx context.Context, orderID string, isRestock bool) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	failures, _ := m.get(orderKey(isRestock, orderID, "aisle_failures")).(map[string]int)
+	if failures == nil {
+		return map[string]int{}, nil
+	}
+	return maps.Clone(failures), nil
+}
+
+// ForgetDispatch removes an order from redelivery tracking.
+func (m *LocalMemoryStore) ForgetDispatch(ctx context.Context, orderID string, isRestock bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, orderKey(isRestock, orderID, "dispatch"))
+	delete(m.keys, orderKey(isRestock, orderID, "dispatch_items"))
+	delete(m.zset(orderIndexKey(isRestock, dispatchPendingIndex)), orderID)
+	return nil
+}
+
+// --- Keyspace ---
+// The helpers below expect m.mu to be held.
+
+// get returns a key's value, or nil once it is missing or expired.
+func (m *LocalMemoryStore) get(key string) any {
+	entry, ok := m.keys[key]
+	if !ok {
+		return nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(m.keys, key)
+		return nil
+	}
+	return entry.value
+}
+
+// set stores a value under key; ttl 0 keeps it until deleted.
+func (m *LocalMemoryStore) set(key string, value any, ttl time.Duration) {
+	m.sweepExpired()
+	entry := localEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	m.keys[key] = entry
+}
+
+// expire restarts a live key's ttl and reports whether the key exists.
+func (m *LocalMemoryStore) expire(key string, ttl time.Duration) bool {
+	value := m.get(key)
+	if value == nil {
+		return false
+	}
+	m.set(key, value, ttl)
+	return true
+}
+
+// sadd adds a member to a set key, restarts its ttl and reports whether the member is new.
+func (m *LocalMemoryStore) sadd(key string, member string, ttl time.Duration) bool {
+	set, ok := m.get(key).(map[string]struct{})
+	if !ok {
+		set = make(map[string]struct{})
+	}
+	_, seen := set[member]
+	set[member] = struct{}{}
+	m.set(key, set, ttl)
+	return !seen
+}
+
+// zset returns the never-expiring index stored under key, creating it if needed.
+func (m *LocalMemoryStore) zset(key string) map[string]time.Time {
+	if entry, ok := m.keys[key]; ok {
+		return entry.value.(map[string]time.Time)
+	}
+	index := make(map[string]time.Time)
+	m.keys[key] = localEntry{value: index}
+	return index
+}
+
+// sweepExpired drops expired keys at most once a minute so keys nobody reads again do not pile up.
+func (m *LocalMemoryStore) sweepExpired() {
+	now := time.Now()
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+	for key, entry := range m.keys {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(m.keys, key)
+		}
+	}
+}
+
+// zrangeBefore returns the members scored at or before cutoff, oldest first.
+func zrangeBefore(index map[string]time.Time, cutoff time.Time) []string {
+	var members []string
+	for member, at := range index {
+		if !at.After(cutoff) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return index[members[i]].Before(index[members[j]]) })
+	return members
+}
+
+// cloneRestockItems deep-copies restock items so callers never share state with the store.
+func cloneRestockItems(items []*pb.RestockItem) []*pb.RestockItem {
+	out := make([]*pb.RestockItem, len(items))
+	for i, item := range items {
+		out[i] = proto.Clone(item).(*pb.RestockItem)
+	}
+	return out
+}
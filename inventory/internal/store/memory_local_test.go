@@ -0,0 +1,354 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocalMemoryStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		setup func(m *LocalMemoryStore)
+		check func(t *testing.T, m *LocalMemoryStore)
+	}{
+		{
+			name: "robot heartbeat lapses after its ttl",
+			setup: func(m *LocalMemoryStore) {
+				m.RegisterRobot(ctx, "robot-1", "produce", 20*time.Millisecond)
+				time.Sleep(40 * time.Millisecond)
+			},
+			check: func(t *testing.T, m *LocalMemoryStore) {
+				if alive, _ := m.TouchRobot(ctx, "robot-1", time.Minute); alive {
+					t.Fatal("TouchRobot revived an expired robot")
+				}
+				if fleet, _ := m.LiveFleet(ctx, time.Minute); fleet["produce"] != 0 {
+					t.Fatalf("LiveFleet = %v, want no produce robot", fleet)
+				}
+			},
+		},
+		{
+			name: "heartbeat restarts the ttl",
+			setup: func(m *LocalMemoryStore) {
+				m.RegisterRobot(ctx, "robot-1", "produce", 30*time.Millisecond)
+				time.Sleep(20 * time.Millisecond)
+				m.TouchRobot(ctx, "robot-1", 30*time.Millisecond)
+				time.Sleep(20 * time.Millisecond)
+			},
+			check: func(t *testing.T, m *LocalMemoryStore) {
+				if fleet, _ := m.LiveFleet(ctx, time.Minute); fleet["produce"] != 1 {
+					t.Fatalf("LiveFleet = %v, want one produce robot", fleet)
+				}
+			},
+		},
+		{
+			name: "robot without a recent heartbeat leaves the fleet",
+			setup: func(m *LocalMemoryStore) {
+				m.RegisterRobot(ctx, "robot-1", "produce", time.Minute)
+				time.Sleep(20 * time.Millisecond)
+			},
+			check: func(t *testing.T, m *LocalMemoryStore) {
+				if fleet, _ := m.LiveFleet(ctx, 10*time.Millisecond); fleet["produce"] != 0 {
+					t.Fatalf("LiveFleet = %v, want no produce robot", fleet)
+				}
+			},
+		},
+		{
+			name: "expired order state is untracked",
+			setup: func(m *LocalMemoryStore) {
+				m.SaveOrderItems(ctx, "order-1", map[string]int32{"apple": 2})
+				m.SaveExpectedReports(ctx, "order-1", false, []string{"produce"}, 1)
+				m.backdate(orderKey(false, "order-1", "items"))
+				m.backdate(orderKey(false, "order-1", "aisles"))
+			},
+			check: func(t *testing.T, m *LocalMemoryStore) {
+				if _, err := m.GetOrderItems(ctx, "order-1"); !errors.Is(err, ErrOrderNotTracked) {
+					t.Fatalf("GetOrderItems err = %v, want ErrOrderNotTracked", err)
+				}
+				if _, err := m.RecordRobotReport(ctx, "order-1", false, "robot-1", nil); !errors.Is(err, ErrOrderNotTracked) {
+					t.Fatalf("RecordRobotReport err = %v, want ErrOrderNotTracked", err)
+				}
+			},
+		},
+		{
+			name: "sweep drops expired keys nobody reads",
+			setup: func(m *LocalMemoryStore) {
+				m.SaveOrderItems(ctx, "order-1", map[string]int32{"apple": 2})
+				m.backdate(orderKey(false, "order-1", "items"))
+				m.lastSweep = time.Now().Add(-2 * time.Minute)
+				m.SaveOrderItems(ctx, "order-2", map[string]int32{"milk": 1})
+			},
+			check: func(t *testing.T, m *LocalMemoryStore) {
+				if _, ok := m.keys[orderKey(false, "order-1", "items")]; ok {
+					t.Fatal("expired key survived the sweep")
+				}
+				if _, err := m.GetOrderItems(ctx, "order-2"); err != nil {
+					t.Fatalf("GetOrderItems err = %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewLocalMemoryStore()
+			tt.setup(m)
+			tt.check(t, m)
+		})
+	}
+}
+
+func TestLocalMemoryStoreRecordRobotReport(t *testing.T) {
+	ctx := context.Background()
+	type report struct {
+		robotID string
+		picked  map[string]int32
+	}
+	tests := []struct {
+		name       string
+		expected   int
+		reports    []report
+		count      int64
+		duplicates int
+		finalized  bool
+		picked     map[string]int32
+	}{
+		{
+			name:     "every robot reports once",
+			expected: 3,
+			reports: []report{
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-2", map[string]int32{"apple": 3}},
+				{"robot-3", map[string]int32{"milk": 2}},
+			},
+			count:     3,
+			finalized: true,
+			picked:    map[string]int32{"apple": 3, "milk": 2},
+		},
+		{
+			name:     "retried callbacks count once",
+			expected: 2,
+			reports: []report{
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-2", map[string]int32{"milk": 2}},
+				{"robot-2", map[string]int32{"milk": 2}},
+			},
+			count:      2,
+			duplicates: 3,
+			finalized:  true,
+			picked:     map[string]int32{"apple": 3, "milk": 2},
+		},
+		{
+			name:     "short pick on a shared aisle wins",
+			expected: 2,
+			reports: []report{
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-2", map[string]int32{"apple": 1}},
+			},
+			count:     2,
+			finalized: true,
+			picked:    map[string]int32{"apple": 1},
+		},
+		{
+			name:     "missing reports leave the order open",
+			expected: 3,
+			reports: []report{
+				{"robot-1", map[string]int32{"apple": 3}},
+				{"robot-1", map[string]int32{"apple": 3}},
+			},
+			count:      1,
+			duplicates: 1,
+			picked:     map[string]int32{"apple": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewLocalMemoryStore()
+			m.SaveExpectedReports(ctx, "order-1", false, []string{"produce", "dairy"}, tt.expected)
+
+			var mu sync.Mutex
+			var finalizes, duplicates int
+			var wg sync.WaitGroup
+			for _, r := range tt.reports {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					outcome, err := m.RecordRobotReport(ctx, "order-1", false, r.robotID, r.picked)
+					if err != nil {
+						t.Errorf("RecordRobotReport err = %v", err)
+						return
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					if outcome.Duplicate {
+						duplicates++
+					}
+					if outcome.Finalize {
+						finalizes++
+					}
+				}()
+			}
+			wg.Wait()
+
+			if count, _ := m.GetRobotCount(ctx, "order-1", false); count != tt.count {
+				t.Errorf("count = %d, want %d", count, tt.count)
+			}
+			if duplicates != tt.duplicates {
+				t.Errorf("duplicates = %d, want %d", duplicates, tt.duplicates)
+			}
+			wantFinalizes := 0
+			if tt.finalized {
+				wantFinalizes = 1
+			}
+			if finalizes != wantFinalizes {
+				t.Errorf("finalize claimed %d times, want %d", finalizes, wantFinalizes)
+			}
+			if picked, _ := m.GetPickedItems(ctx, "order-1", false); !maps.Equal(picked, tt.picked) {
+				t.Errorf("picked = %v, want %v", picked, tt.picked)
+			}
+			if claimed, _ := m.TryMarkOrderFinalized(ctx, "order-1", false); claimed == tt.finalized {
+				t.Errorf("TryMarkOrderFinalized = %t after the reports, want %t", claimed, !tt.finalized)
+			}
+		})
+	}
+}
+
+func TestLocalMemoryStoreSetPickedItems(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		sets   []map[string]int32
+		picked map[string]int32
+	}{
+		{
+			name:   "restore sets the picked quantities",
+			sets:   []map[string]int32{{"apple": 3, "milk": 2}},
+			picked: map[string]int32{"apple": 3, "milk": 2},
+		},
+		{
+			name:   "restoring twice does not sum",
+			sets:   []map[string]int32{{"apple": 3, "milk": 2}, {"apple": 3}},
+			picked: map[string]int32{"apple": 3},
+		},
+		{
+			name:   "empty restore clears the picked quantities",
+			sets:   []map[string]int32{{"apple": 3}, nil},
+			picked: map[string]int32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewLocalMemoryStore()
+			for _, items := range tt.sets {
+				if err := m.SetPickedItems(ctx, "order-1", false, items); err != nil {
+					t.Fatalf("SetPickedItems err = %v", err)
+				}
+			}
+			if picked, _ := m.GetPickedItems(ctx, "order-1", false); !maps.Equal(picked, tt.picked) {
+				t.Errorf("picked = %v, want %v", picked, tt.picked)
+			}
+		})
+	}
+}
+
+func TestLocalMemoryStoreOrderProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		subscribers int
+		publishTo   string
+		delivered   bool
+	}{
+		{name: "single watcher", subscribers: 1, publishTo: "order-1", delivered: true},
+		{name: "every watcher gets the event", subscribers: 3, publishTo: "order-1", delivered: true},
+		{name: "other orders stay silent", subscribers: 2, publishTo: "order-2", delivered: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := NewLocalMemoryStore()
+
+			var streams []<-chan []byte
+			for range tt.subscribers {
+				events, unsubscribe, err := m.SubscribeOrderProgress(ctx, OrderTypeCustomer, "order-1")
+				if err != nil {
+					t.Fatalf("SubscribeOrderProgress err = %v", err)
+				}
+				defer unsubscribe()
+				streams = append(streams, events)
+			}
+
+			if err := m.PublishOrderProgress(ctx, OrderTypeCustomer, tt.publishTo, []byte("picked")); err != nil {
+				t.Fatalf("PublishOrderProgress err = %v", err)
+			}
+			for i, events := range streams {
+				select {
+				case payload := <-events:
+					if !tt.delivered {
+						t.Fatalf("watcher %d got %q for another order", i, payload)
+					}
+					if string(payload) != "picked" {
+						t.Fatalf("watcher %d got %q, want %q", i, payload, "picked")
+					}
+				case <-time.After(50 * time.Millisecond):
+					if tt.delivered {
+						t.Fatalf("watcher %d got no event", i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLocalMemoryStoreOrderProgressUnsubscribe(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(cancel context.CancelFunc, unsubscribe func() error)
+	}{
+		{name: "close", stop: func(_ context.CancelFunc, unsubscribe func() error) { unsubscribe() }},
+		{name: "context ends", stop: func(cancel context.CancelFunc, _ func() error) { cancel() }},
+		{name: "close twice", stop: func(_ context.CancelFunc, unsubscribe func() error) { unsubscribe(); unsubscribe() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			m := NewLocalMemoryStore()
+			events, unsubscribe, err := m.SubscribeOrderProgress(ctx, OrderTypeCustomer, "order-1")
+			if err != nil {
+				t.Fatalf("SubscribeOrderProgress err = %v", err)
+			}
+
+			tt.stop(cancel, unsubscribe)
+			select {
+			case _, open := <-events:
+				if open {
+					t.Fatal("got an event after unsubscribing")
+				}
+			case <-time.After(time.Second):
+				t.Fatal("event stream was not closed")
+			}
+			if err := m.PublishOrderProgress(context.Background(), OrderTypeCustomer, "order-1", []byte("late")); err != nil {
+				t.Fatalf("PublishOrderProgress err = %v", err)
+			}
+		})
+	}
+}
+
+// backdate makes a key look expired without waiting out its ttl.
+func (m *LocalMemoryStore) backdate(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := m.keys[key]
+	entry.expiresAt = time.Now().Add(-time.Second)
+	m.keys[key] = entry
+}
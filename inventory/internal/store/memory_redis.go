@@ -0,0 +1,554 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	pb "auto_grocery/inventory/proto"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var _ MemoryStore = (*RedisMemoryStore)(nil)
+
+// RedisMemoryStore keeps workflow state in a single redis database, with every order key namespaced by flow.
+type RedisMemoryStore struct {
+	client *redis.Client
+	opts   redis.Options // kept to reach the legacy per-flow databases during migration
+}
+
+// NewRedisMemoryStore creates the redis client for workflow state, the fleet registry and progress events.
+func NewRedisMemoryStore(addr string, password string) *RedisMemoryStore {
+	opts := redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       0,
+	}
+	return &RedisMemoryStore{client: redis.NewClient(&opts), opts: opts}
+}
+
+// Ping verifies connectivity and credentials.
+func (m *RedisMemoryStore) Ping(ctx context.Context) error {
+	return m.client.Ping(ctx).Err()
+}
+
+// --- Client and Restock Items ---
+
+// SaveOrderItems stores client-order items in redis with a bounded ttl.
+func (m *RedisMemoryStore) SaveOrderItems(ctx context.Context, orderID string, items map[string]int32) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return m.client.Set(ctx, orderKey(false, orderID, "items"), data, orderStateTTL).Err()
+}
+
+// GetOrderItems loads client-order items from redis.
+func (m *RedisMemoryStore) GetOrderItems(ctx context.Context, orderID string) (map[string]int32, error) {
+	val, err := m.client.Get(ctx, orderKey(false, orderID, "items")).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOrderNotTracked
+	} else if err != nil {
+		return nil, err
+	}
+	var items map[string]int32
+	err = json.Unmarshal([]byte(val), &items)
+	return items, err
+}
+
+// SaveRestockItems stores restock-order items in redis with a bounded ttl.
+func (m *RedisMemoryStore) SaveRestockItems(ctx context.Context, orderID string, items []*pb.RestockItem) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return m.client.Set(ctx, orderKey(true, orderID, "items"), data, orderStateTTL).Err()
+}
+
+// GetRestockItems loads restock-order items from redis.
+func (m *RedisMemoryStore) GetRestockItems(ctx context.Context, orderID string) ([]*pb.RestockItem, error) {
+	val, err := m.client.Get(ctx, orderKey(true, orderID, "items")).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOrderNotTracked
+	} else if err != nil {
+		return nil, err
+	}
+	var items []*pb.RestockItem
+	err = json.Unmarshal([]byte(val), &items)
+	return items, err
+}
+
+// --- Fleet Registry ---
+
+const (
+	fleetMembersKey   = "fleet:robots"
+	fleetRobotKeyBase = "fleet:robot:"
+)
+
+// RegisterRobot records a robot's aisle and marks it alive as of now.
+func (m *RedisMemoryStore) RegisterRobot(ctx context.Context, robotID string, aisle string, ttl time.Duration) error {
+	pipe := m.client.TxPipeline()
+	pipe.Set(ctx, fleetRobotKeyBase+robotID, aisle, ttl)
+	pipe.ZAdd(ctx, fleetMembersKey, redis.Z{Score: float64(time.Now().Unix()), Member: robotID})
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TouchRobot refreshes a robot heartbeat and reports whether the robot is still registered.
+func (m *RedisMemoryStore) TouchRobot(ctx context.Context, robotID string, ttl time.Duration) (bool, error) {
+	alive, err := m.client.Expire(ctx, fleetRobotKeyBase+robotID, ttl).Result()
+	if err != nil || !alive {
+		return false, err
+	}
+	err = m.client.ZAdd(ctx, fleetMembersKey, redis.Z{Score: float64(time.Now().Unix()), Member: robotID}).Err()
+	return err == nil, err
+}
+
+// LiveFleet returns the number of robots per aisle that heartbeated within ttl.
+func (m *RedisMemoryStore) LiveFleet(ctx context.Context, ttl time.Duration) (map[string]int, error) {
+	cutoff := time.Now().Add(-ttl).Unix()
+	// Drop robots whose heartbeat lapsed so the member set does not grow forever.
+	if err := m.client.ZRemRangeByScore(ctx, fleetMembersKey, "-inf", "("+strconv.FormatInt(cutoff, 10)).Err(); err != nil {
+		return nil, err
+	}
+	robotIDs, err := m.client.ZRange(ctx, fleetMembersKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	fleet := make(map[string]int)
+	if len(robotIDs) == 0 {
+		return fleet, nil
+	}
+	keys := make([]string, 0, len(robotIDs))
+	for _, id := range robotIDs {
+		keys = append(keys, fleetRobotKeyBase+id)
+	}
+	aisles, err := m.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aisles {
+		if aisle, ok := a.(string); ok {
+			fleet[aisle]++
+		}
+	}
+	return fleet, nil
+}
+
+// --- Lifecycle Management ---
+
+// SaveExpectedReports stores how many robot reports an order needs and which aisles they come from.
+func (m *RedisMemoryStore) SaveExpectedReports(ctx context.Context, orderID string, isRestock bool, aisles []string, expected int) error {
+	data, err := json.Marshal(aisles)
+	if err != nil {
+		return err
+	}
+	pipe := m.client.TxPipeline()
+	pipe.Set(ctx, orderKey(isRestock, orderID, "aisles"), data, orderStateTTL)
+	pipe.Set(ctx, orderKey(isRestock, orderID, "expected"), expected, orderStateTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetExpectedReports loads the aisle set and report count captured at dispatch time.
+func (m *RedisMemoryStore) GetExpectedReports(ctx context.Context, orderID string, isRestock bool) ([]string, int, error) {
+	val, err := m.client.Get(ctx, orderKey(isRestock, orderID, "aisles")).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, 0, ErrOrderNotTracked
+	} else if err != nil {
+		return nil, 0, err
+	}
+	var aisles []string
+	if err := json.Unmarshal([]byte(val), &aisles); err != nil {
+		return nil, 0, err
+	}
+	expected, err := m.client.Get(ctx, orderKey(isRestock, orderID, "expected")).Int()
+	if err != nil {
+		return nil, 0, err
+	}
+	return aisles, expected, nil
+}
+
+// SetPickedItems replaces an order's picked quantities per sku.
+func (m *RedisMemoryStore) SetPickedItems(ctx context.Context, orderID string, isRestock bool, items map[string]int32) error {
+	key := orderKey(isRestock, orderID, "picked")
+	pipe := m.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(items) > 0 {
+		fields := make(map[string]any, len(items))
+		for sku, qty := range items {
+			fields[sku] = qty
+		}
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, orderStateTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetPickedItems loads the reconciled picked quantities for an order.
+func (m *RedisMemoryStore) GetPickedItems(ctx context.Context, orderID string, isRestock bool) (map[string]int32, error) {
+	vals, err := m.client.HGetAll(ctx, orderKey(isRestock, orderID, "picked")).Result()
+	if err != nil {
+		return nil, err
+	}
+	picked := make(map[string]int32, len(vals))
+	for sku, v := range vals {
+		qty, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		picked[sku] = int32(qty)
+	}
+	return picked, nil
+}
+
+// recordReportScript counts one robot report in a single round trip, so concurrent reports can neither
+// double-count nor both miss the completing report. Robots on one aisle all confirm the same task, so each
+// sku keeps the smallest quantity any of them reported.
+// KEYS: aisles, expected, reporters, picked, count, finalized. ARGV: robot_id, ttl seconds, then sku/qty pairs.
+// Returns {tracked, duplicate, count, expected, finalize}.
+var recordReportScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return {0, 0, 0, 0, 0}
+end
+local ttl = tonumber(ARGV[2])
+local expected = tonumber(redis.call('GET', KEYS[2]) or '0')
+if ARGV[1] ~= '' then
+	if redis.call('SADD', KEYS[3], ARGV[1]) == 0 then
+		local count = tonumber(redis.call('GET', KEYS[5]) or '0')
+		local finalize = 0
+		if count >= expected and redis.call('SET', KEYS[6], '1', 'NX', 'EX', ttl) then
+			finalize = 1
+		end
+		return {1, 1, count, expected, finalize}
+	end
+	redis.call('EXPIRE', KEYS[3], ttl)
+end
+for i = 3, #ARGV, 2 do
+	local current = redis.call('HGET', KEYS[4], ARGV[i])
+	if not current or tonumber(ARGV[i + 1]) < tonumber(current) then
+		redis.call('HSET', KEYS[4], ARGV[i], ARGV[i + 1])
+	end
+end
+if #ARGV > 2 then
+	redis.call('EXPIRE', KEYS[4], ttl)
+end
+local count = redis.call('INCR', KEYS[5])
+redis.call('EXPIRE', KEYS[5], ttl)
+local finalize = 0
+if count >= expected and redis.call('SET', KEYS[6], '1', 'NX', 'EX', ttl) then
+	finalize = 1
+end
+return {1, 0, count, expected, finalize}
+`)
+
+// RecordRobotReport records the reporter, its picked items and the report count, and claims finalization
+// once the count reaches the expected reports, all in one server-side script.
+func (m *RedisMemoryStore) RecordRobotReport(ctx context.Context, orderID string, isRestock bool, robotID string, picked map[string]int32) (ReportOutcome, error) {
+	keys := []string{
+		orderKey(isRestock, orderID, "aisles"),
+		orderKey(isRestock, orderID, "expected"),
+		orderKey(isRestock, orderID, "reporters"),
+		orderKey(isRestock, orderID, "picked"),
+		orderKey(isRestock, orderID, "count"),
+		orderKey(isRestock, orderID, "finalized"),
+	}
+	args := []any{robotID, int64(orderStateTTL / time.Second)}
+	for sku, qty := range picked {
+		args = append(args, sku, qty)
+	}
+
+	res, err := recordReportScript.Run(ctx, m.client, keys, args...).Int64Slice()
+	if err != nil {
+		return ReportOutcome{}, err
+	}
+	if len(res) != 5 {
+		return ReportOutcome{}, fmt.Errorf("unexpected record report reply %v", res)
+	}
+	if res[0] == 0 {
+		return ReportOutcome{}, ErrOrderNotTracked
+	}
+	return ReportOutcome{
+		Duplicate: res[1] == 1,
+		Count:     res[2],
+		Expected:  int(res[3]),
+		Finalize:  res[4] == 1,
+	}, nil
+}
+
+// RecordReporter adds a robot to the order's reporter set and reports whether it is reporting for the first time.
+func (m *RedisMemoryStore) RecordReporter(ctx context.Context, orderID string, isRestock bool, robotID string) (bool, error) {
+	key := orderKey(isRestock, orderID, "reporters")
+	pipe := m.client.TxPipeline()
+	added := pipe.SAdd(ctx, key, robotID)
+	pipe.Expire(ctx, key, orderStateTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return added.Val() == 1, nil
+}
+
+// GetRobotCount returns how many counted robot reports an order has received.
+func (m *RedisMemoryStore) GetRobotCount(ctx context.Context, orderID string, isRestock bool) (int64, error) {
+	count, err := m.client.Get(ctx, orderKey(isRestock, orderID, "count")).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// SetRobotCount overwrites the counted robot reports, e.g. when restoring an order from its workflow.
+func (m *RedisMemoryStore) SetRobotCount(ctx context.Context, orderID string, isRestock bool, count int64) error {
+	return m.client.Set(ctx, orderKey(isRestock, orderID, "count"), count, orderStateTTL).Err()
+}
+
+// MarkInFlight records when an order was dispatched so stuck orders can be swept.
+func (m *RedisMemoryStore) MarkInFlight(ctx context.Context, orderID string, isRestock bool, dispatchedAt time.Time) error {
+	return m.client.ZAdd(ctx, orderIndexKey(isRestock, inFlightIndex), redis.Z{Score: float64(dispatchedAt.Unix()), Member: orderID}).Err()
+}
+
+// ListInFlightBefore returns orders dispatched before the cutoff that have not been cleaned up.
+func (m *RedisMemoryStore) ListInFlightBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error) {
+	return m.client.ZRangeByScore(ctx, orderIndexKey(isRestock, inFlightIndex), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(cutoff.Unix(), 10),
+	}).Result()
+}
+
+// --- Progress Events ---
+
+// progressChannel names the pub/sub channel for an order; pub/sub ignores the DB index so the type is part of the name.
+func progressChannel(orderType string, orderID string) string {
+	return "progress:" + orderType + ":" + orderID
+}
+
+// PublishOrderProgress fans a serialized progress event out to every watcher of the order.
+func (m *RedisMemoryStore) PublishOrderProgress(ctx context.Context, orderType string, orderID string, payload []byte) error {
+	return m.client.Publish(ctx, progressChannel(orderType, orderID), payload).Err()
+}
+
+// SubscribeOrderProgress streams serialized progress events for an order until ctx ends or close is called.
+func (m *RedisMemoryStore) SubscribeOrderProgress(ctx context.Context, orderType string, orderID string) (<-chan []byte, func() error, error) {
+	sub := m.client.Subscribe(ctx, progressChannel(orderType, orderID))
+	// Wait for the subscription to be active so no event published after this call is missed.
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+
+	events := make(chan []byte)
+	go func() {
+		defer close(events)
+		for msg := range sub.Channel() {
+			select {
+			case events <- []byte(msg.Payload):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, sub.Close, nil
+}
+
+// --- Dispatch Ledger ---
+
+// SaveDispatchLedger records every aisle task of an order as sent once and awaiting acknowledgement.
+func (m *RedisMemoryStore) SaveDispatchLedger(ctx context.Context, orderID string, isRestock bool, items map[string]mq.ItemDetails, aisles []string, sentAt time.Time) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	key := orderKey(isRestock, orderID, "dispatch")
+	pipe := m.client.TxPipeline()
+	pipe.Set(ctx, orderKey(isRestock, orderID, "dispatch_items"), data, orderStateTTL)
+	for _, aisle := range aisles {
+		pipe.HSet(ctx, key, aisle, 1)
+	}
+	pipe.Expire(ctx, key, orderStateTTL)
+	pipe.ZAdd(ctx, orderIndexKey(isRestock, dispatchPendingIndex), redis.Z{Score: float64(sentAt.Unix()), Member: orderID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RemoveDispatchTask drops an aisle task from the ledger and reports whether it was still pending.
+func (m *RedisMemoryStore) RemoveDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string) (bool, error) {
+	key := orderKey(isRestock, orderID, "dispatch")
+	removed, err := m.client.HDel(ctx, key, aisle).Result()
+	if err != nil {
+		return false, err
+	}
+	remaining, err := m.client.HLen(ctx, key).Result()
+	if err != nil {
+		return removed == 1, err
+	}
+	if remaining == 0 {
+		err = m.client.ZRem(ctx, orderIndexKey(isRestock, dispatchPendingIndex), orderID).Err()
+	}
+	return removed == 1, err
+}
+
+// ListDispatchesSentBefore returns orders with pending aisle tasks last sent before the cutoff.
+func (m *RedisMemoryStore) ListDispatchesSentBefore(ctx context.Context, isRestock bool, cutoff time.Time) ([]string, error) {
+	return m.client.ZRangeByScore(ctx, orderIndexKey(isRestock, dispatchPendingIndex), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(cutoff.Unix(), 10),
+	}).Result()
+}
+
+// GetPendingDispatch loads send attempts per unacknowledged aisle and the order's robot items.
+func (m *RedisMemoryStore) GetPendingDispatch(ctx context.Context, orderID string, isRestock bool) (map[string]int, map[string]mq.ItemDetails, error) {
+	items, err := m.GetDispatchItems(ctx, orderID, isRestock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vals, err := m.client.HGetAll(ctx, orderKey(isRestock, orderID, "dispatch")).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts := make(map[string]int, len(vals))
+	for aisle, v := range vals {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		attempts[aisle] = n
+	}
+	return attempts, items, nil
+}
+
+// MarkRedelivered bumps the send attempts of re-broadcast aisles and restarts the order's redelivery clock.
+func (m *RedisMemoryStore) MarkRedelivered(ctx context.Context, orderID string, isRestock bool, aisles []string, sentAt time.Time) error {
+	key := orderKey(isRestock, orderID, "dispatch")
+	pipe := m.client.TxPipeline()
+	for _, aisle := range aisles {
+		pipe.HIncrBy(ctx, key, aisle, 1)
+	}
+	pipe.ZAdd(ctx, orderIndexKey(isRestock, dispatchPendingIndex), redis.Z{Score: float64(sentAt.Unix()), Member: orderID})
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// AddDispatchTask puts an aisle task back in the ledger as freshly sent, e.g. after a failure re-dispatch.
+func (m *RedisMemoryStore) AddDispatchTask(ctx context.Context, orderID string, isRestock bool, aisle string, sentAt time.Time) error {
+	key := orderKey(isRestock, orderID, "dispatch")
+	pipe := m.client.TxPipeline()
+	pipe.HSet(ctx, key, aisle, 1)
+	pipe.Expire(ctx, key, orderStateTTL)
+	pipe.ZAdd(ctx, orderIndexKey(isRestock, dispatchPendingIndex), redis.Z{Score: float64(sentAt.Unix()), Member: orderID})
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetDispatchItems loads the robot items recorded when the order was dispatched.
+func (m *RedisMemoryStore) GetDispatchItems(ctx context.Context, orderID string, isRestock bool) (map[string]mq.ItemDetails, error) {
+	val, err := m.client.Get(ctx, orderKey(isRestock, orderID, "dispatch_items")).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOrderNotTracked
+	} else if err != nil {
+		return nil, err
+	}
+	var items map[string]mq.ItemDetails
+	if err := json.Unmarshal([]byte(val), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// RecordAisleFailure counts a FAILED report for one retry of an aisle task and returns the aisle's total.
+// Only the first FAILED report per aisle retry counts; later ones return counted=false.
+func (m *RedisMemoryStore) RecordAisleFailure(ctx context.Context, orderID string, isRestock bool, aisle string, retry int32) (int64, bool, error) {
+	seenKey := orderKey(isRestock, orderID, "failed_tasks")
+	added, err := m.client.SAdd(ctx, seenKey, fmt.Sprintf("%s#%d", aisle, retry)).Result()
+	if err != nil {
+		return 0, false, err
+	}
+	m.client.Expire(ctx, seenKey, orderStateTTL)
+	if added == 0 {
+		failures, err := m.client.HGet(ctx, orderKey(isRestock, orderID, "aisle_failures"), aisle).Int64()
+		if errors.Is(err, redis.Nil) {
+			err = nil
+		}
+		return failures, false, err
+	}
+
+	key := orderKey(isRestock, orderID, "aisle_failures")
+	pipe := m.client.TxPipeline()
+	incr := pipe.HIncrBy(ctx, key, aisle, 1)
+	pipe.Expire(ctx, key, orderStateTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, false, err
+	}
+	return incr.Val(), true, nil
+}
+
+// GetAisleFailures returns FAILED report counts per aisle, which is also each aisle's current retry number.
+func (m *RedisMemoryStore) GetAisleFailures(ctx context.Context, orderID string, isRestock bool) (map[string]int, error) {
+	vals, err := m.client.HGetAll(ctx, orderKey(isRestock, orderID, "aisle_failures")).Result()
+	if err != nil {
+		return nil, err
+	}
+	failures := make(map[string]int, len(vals))
+	for aisle, v := range vals {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		failures[aisle] = n
+	}
+	return failures, nil
+}
+
+// ForgetDispatch removes an order from redelivery tracking.
+func (m *RedisMemoryStore) ForgetDispatch(ctx context.Context, orderID string, isRestock bool) error {
+	if err := m.client.Del(ctx, orderKey(isRestock, orderID, "dispatch"), orderKey(isRestock, orderID, "dispatch_items")).Err(); err != nil {
+		return err
+	}
+	return m.client.ZRem(ctx, orderIndexKey(isRestock, dispatchPendingIndex), orderID).Err()
+}
+
+// DeleteOrderData clears transient redis keys for either order flow.
+func (m *RedisMemoryStore) DeleteOrderData(ctx context.Context, orderID string, isRestock bool) {
+	keys := make([]string, 0, len(orderStateSuffixes))
+	for _, suffix := range orderStateSuffixes {
+		keys = append(keys, orderKey(isRestock, orderID, suffix))
+	}
+	m.client.Del(ctx, keys...)
+	m.client.ZRem(ctx, orderIndexKey(isRestock, inFlightIndex), orderID)
+	m.client.ZRem(ctx, orderIndexKey(isRestock, dispatchPendingIndex), orderID)
+}
+
+// ForgetInFlight drops an order from the in-flight index.
+func (m *RedisMemoryStore) ForgetInFlight(ctx context.Context, orderID string, isRestock bool) error {
+	return m.client.ZRem(ctx, orderIndexKey(isRestock, inFlightIndex), orderID).Err()
+}
+
+// RecordSweepFailure counts one failed sweeper claim and returns the order's total.
+func (m *RedisMemoryStore) RecordSweepFailure(ctx context.Context, orderID string, isRestock bool) (int64, error) {
+	key := orderKey(isRestock, orderID, "sweep_failures")
+	pipe := m.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, orderStateTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// TryMarkOrderFinalized sets a one-time finalize marker using SETNX semantics.
+func (m *RedisMemoryStore) TryMarkOrderFinalized(ctx context.Context, orderID string, isRestock bool) (bool, error) {
+	key := orderKey(isRestock, orderID, "finalized")
+	return m.client.SetNX(ctx, key, "1", orderStateTTL).Result()
+}
+
+// ClearOrderFinalized deletes the finalize marker so the order can be claimed again.
+func (m *RedisMemoryStore) ClearOrderFinalized(ctx context.Context, orderID string, isRestock bool) error {
+	return m.client.Del(ctx, orderKey(isRestock, orderID, "finalized")).Err()
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Before the namespaced keyspace, client orders lived in database 0 and restocks in database 1 as
+// <order_id>:<suffix>, each database with its own inflight:orders and dispatch:pending index.
+var legacyOrderIndexes = map[string]string{
+	"inflight:orders":  inFlightIndex,
+	"dispatch:pending": dispatchPendingIndex,
+}
+
+// MigrateLegacyKeys moves order state left in the per-flow databases into the namespaced keyspace, keeping ttls,
+// and returns how many keys it moved. Keys already present under their new name win. It is safe to run on
+// every startup, but writers still using the old layout must be stopped first or their updates can be lost.
+func (m *RedisMemoryStore) MigrateLegacyKeys(ctx context.Context) (int, error) {
+	moved := 0
+	for _, isRestock := range []bool{false, true} {
+		opts := m.opts
+		opts.DB = 0
+		if isRestock {
+			opts.DB = 1
+		}
+		legacy := redis.NewClient(&opts)
+		n, err := m.migrateLegacyDB(ctx, legacy, isRestock)
+		legacy.Close()
+		moved += n
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
+
+// migrateLegacyDB moves one flow's indexes and per-order keys out of its legacy database.
+func (m *RedisMemoryStore) migrateLegacyDB(ctx context.Context, legacy *redis.Client, isRestock bool) (int, error) {
+	moved := 0
+	for legacyKey, index := range legacyOrderIndexes {
+		members, err := legacy.ZRangeWithScores(ctx, legacyKey, 0, -1).Result()
+		if err != nil {
+			return moved, err
+		}
+		if len(members) == 0 {
+			continue
+		}
+		if err := m.client.ZAddNX(ctx, orderIndexKey(isRestock, index), members...).Err(); err != nil {
+			return moved, err
+		}
+		if err := legacy.Del(ctx, legacyKey).Err(); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+
+	for _, suffix := range orderStateSuffixes {
+		iter := legacy.Scan(ctx, 0, "*:"+suffix, 100).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			// Database 0 also holds the namespaced keys and the fleet registry.
+			if strings.HasPrefix(key, "order:") || strings.HasPrefix(key, "fleet:") {
+				continue
+			}
+			orderID := strings.TrimSuffix(key, ":"+suffix)
+			if err := m.moveLegacyKey(ctx, legacy, key, orderKey(isRestock, orderID, suffix)); err != nil {
+				return moved, err
+			}
+			moved++
+		}
+		if err := iter.Err(); err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
+
+// moveLegacyKey copies one key with its remaining ttl to its namespaced name and deletes the original.
+func (m *RedisMemoryStore) moveLegacyKey(ctx context.Context, legacy *redis.Client, from string, to string) error {
+	dump, err := legacy.Dump(ctx, from).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil // expired while scanning
+	} else if err != nil {
+		return err
+	}
+	ttl, err := legacy.PTTL(ctx, from).Result()
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		ttl = 0 // no expiry
+	}
+	if err := m.client.Restore(ctx, to, ttl, dump).Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYKEY") {
+		return err
+	}
+	return legacy.Del(ctx, from).Err()
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Movement reasons recorded in inventory_movements.
+const (
+	MovementReserve        = "RESERVE"
+	MovementRelease        = "RELEASE"
+	MovementRestock        = "RESTOCK"
+	MovementExpiryWriteOff = "EXPIRY_WRITEOFF"
+	// MovementOpeningBalance rows are written once by the migration that created the journal.
+	MovementOpeningBalance = "OPENING_BALANCE"
+)
+
+// StockMovement is one journaled change to a sku's available quantity.
+type StockMovement struct {
+	ID           int64
+	SKU          string
+	Delta        int
+	Reason       string
+	OrderID      string
+	BalanceAfter int
+	CreatedAt    time.Time
+}
+
+// recordMovements journals per-sku deltas with their resulting balances inside the caller's transaction.
+func recordMovements(ctx context.Context, tx *sql.Tx, reason string, orderID string, deltas map[string]int32, balances map[string]int) error {
+	var skus []string
+	for sku, delta := range deltas {
+		if delta != 0 {
+			skus = append(skus, sku)
+		}
+	}
+	if len(skus) == 0 {
+		return nil
+	}
+	sort.Strings(skus)
+
+	counts := make([]int32, len(skus))
+	after := make([]int32, len(skus))
+	for i, sku := range skus {
+		counts[i] = deltas[sku]
+		after[i] = int32(balances[sku])
+	}
+
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO inventory_movements (sku, delta, reason, order_id, balance_after)
+        SELECT unnest($1::text[]), unnest($2::int[]), $3, NULLIF($4, ''), unnest($5::int[])
+    `, pq.Array(skus), pq.Array(counts), reason, orderID, pq.Array(after))
+	if err != nil {
+		return fmt.Errorf("failed to record stock movements: %w", err)
+	}
+	return nil
+}
+
+// ListStockMovements returns a sku's movements in [from, to), oldest first, up to limit rows.
+func (s *Store) ListStockMovements(ctx context.Context, sku string, from, to time.Time, limit int) ([]StockMovement, error) {
+	query := `
+        SELECT id, sku, delta, reason, COALESCE(order_id, ''), balance_after, created_at
+        FROM inventory_movements
+        WHERE sku = $1 AND created_at >= $2 AND created_at < $3
+        ORDER BY created_at, id
+        LIMIT $4
+    `
+	rows, err := s.db.QueryContext(ctx, query, sku, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []StockMovement
+	for rows.Next() {
+		var m StockMovement
+		if err := rows.Scan(&m.ID, &m.SKU, &m.Delta, &m.Reason, &m.OrderID, &m.BalanceAfter, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		movements = append(movements, m)
+	}
+	return movements, rows.Err()
+}
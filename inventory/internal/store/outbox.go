@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Webhook outbox delivery states.
+const (
+	WebhookPending   = "PENDING"
+	WebhookDelivered = "DELIVERED"
+	WebhookDead      = "DEAD"
+)
+
+type WebhookOutboxEntry struct {
+	ID            int64     `json:"id"`
+	OrderID       string    `json:"order_id"`
+	OrderType     string    `json:"order_type"`
+	TargetURL     string    `json:"target_url"`
+	Payload       []byte    `json:"payload"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+const outboxColumns = `id, order_id, order_type, target_url, payload, status, attempts, COALESCE(last_error, ''), next_attempt_at, created_at`
+
+// EnqueueWebhook persists a pending webhook whose first delivery attempt is leased to the caller for lease.
+func (s *Store) EnqueueWebhook(ctx context.Context, orderID string, orderType string, targetURL string, payload []byte, lease time.Duration) (int64, error) {
+	return enqueueWebhook(ctx, s.db, orderID, orderType, targetURL, payload, lease)
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// enqueueWebhook inserts a pending outbox entry through q, which may be a transaction.
+func enqueueWebhook(ctx context.Context, q rowQuerier, orderID string, orderType string, targetURL string, payload []byte, lease time.Duration) (int64, error) {
+	query := `
+        INSERT INTO webhook_outbox (order_id, order_type, target_url, payload, status, next_attempt_at)
+        VALUES ($1, $2, $3, $4, $5, NOW() + make_interval(secs => $6))
+        RETURNING id
+    `
+	var id int64
+	err := q.QueryRowContext(ctx, query, orderID, orderType, targetURL, payload, WebhookPending, lease.Seconds()).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook: %w", err)
+	}
+	return id, nil
+}
+
+// ClaimDueWebhooks leases up to limit pending webhooks whose next attempt is due.
+func (s *Store) ClaimDueWebhooks(ctx context.Context, limit int, lease time.Duration) ([]WebhookOutboxEntry, error) {
+	query := `
+        WITH due AS (
+            SELECT id FROM webhook_outbox
+            WHERE status = $1 AND next_attempt_at <= NOW()
+            ORDER BY next_attempt_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE webhook_outbox w
+        SET next_attempt_at = NOW() + make_interval(secs => $3)
+        FROM due
+        WHERE w.id = due.id
+        RETURNING w.id, w.order_id, w.order_type, w.target_url, w.payload, w.status, w.attempts,
+                  COALESCE(w.last_error, ''), w.next_attempt_at, w.created_at
+    `
+
+	rows, err := s.db.QueryContext(ctx, query, WebhookPending, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due webhooks: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxEntries(rows)
+}
+
+// MarkWebhookDelivered records a successful delivery.
+func (s *Store) MarkWebhookDelivered(ctx context.Context, id int64) error {
+	query := `
+        UPDATE webhook_outbox
+        SET status = $1, attempts = attempts + 1, last_error = NULL, delivered_at = NOW()
+        WHERE id = $2
+    `
+	if _, err := s.db.ExecContext(ctx, query, WebhookDelivered, id); err != nil {
+		return fmt.Errorf("failed to mark webhook delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt and either schedules a retry after retryIn or dead-letters the entry.
+func (s *Store) MarkWebhookFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error {
+	status := WebhookPending
+	if dead {
+		status = WebhookDead
+	}
+	query := `
+        UPDATE webhook_outbox
+        SET status = $1, attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + make_interval(secs => $3)
+        WHERE id = $4
+    `
+	if _, err := s.db.ExecContext(ctx, query, status, lastError, retryIn.Seconds(), id); err != nil {
+		return fmt.Errorf("failed to mark webhook failed: %w", err)
+	}
+	return nil
+}
+
+// ListUndeliveredWebhooks returns pending and dead-lettered webhooks, optionally filtered by order type and status.
+func (s *Store) ListUndeliveredWebhooks(ctx context.Context, orderType string, statuses []string, limit int) ([]WebhookOutboxEntry, error) {
+	if len(statuses) == 0 {
+		statuses = []string{WebhookPending, WebhookDead}
+	}
+	query := `
+        SELECT ` + outboxColumns + `
+        FROM webhook_outbox
+        WHERE status = ANY($1) AND status <> $2 AND ($3 = '' OR order_type = $3)
+        ORDER BY created_at
+        LIMIT $4
+    `
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(statuses), WebhookDelivered, orderType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+	return scanOutboxEntries(rows)
+}
+
+// ReplayWebhooks resets undelivered webhooks to pending with a fresh retry budget.
+// Explicit ids are replayed when given; otherwise every dead-lettered entry of orderType is.
+func (s *Store) ReplayWebhooks(ctx context.Context, ids []int64, orderType string) (int64, error) {
+	query := `
+        UPDATE webhook_outbox
+        SET status = $1, attempts = 0, next_attempt_at = NOW()
+        WHERE status <> $2
+          AND ($3 = '' OR order_type = $3)
+          AND (cardinality($4::bigint[]) > 0 AND id = ANY($4) OR cardinality($4::bigint[]) = 0 AND status = $5)
+    `
+	result, err := s.db.ExecContext(ctx, query, WebhookPending, WebhookDelivered, orderType, pq.Array(ids), WebhookDead)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay webhooks: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// scanOutboxEntries reads outbox rows selected with outboxColumns.
+func scanOutboxEntries(rows *sql.Rows) ([]WebhookOutboxEntry, error) {
+	var entries []WebhookOutboxEntry
+	for rows.Next() {
+		var e WebhookOutboxEntry
+		if err := rows.Scan(
+			&e.ID, &e.OrderID, &e.OrderType, &e.TargetURL, &e.Payload, &e.Status,
+			&e.Attempts, &e.LastError, &e.NextAttemptAt, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
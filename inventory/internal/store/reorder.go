@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrUnknownSKU is returned when a sku has no available_stock row.
+var ErrUnknownSKU = errors.New("unknown sku")
+
+// LowStockEvent records a reserve taking a sku below its reorder point.
+type LowStockEvent struct {
+	ID           int64
+	SKU          string
+	OrderID      string
+	Quantity     int
+	ReorderPoint int
+	TargetLevel  int
+	CreatedAt    time.Time
+}
+
+// LowStockItem is a sku currently below its reorder point.
+type LowStockItem struct {
+	SKU          string
+	Name         string
+	AisleType    string
+	Quantity     int
+	ReorderPoint int
+	TargetLevel  int
+	UnitCost     float64
+	LastAlertAt  time.Time // zero when no reserve has alerted yet
+}
+
+// SetReorderPoint sets a sku's reorder point and the level a restock should bring it back to.
+func (s *Store) SetReorderPoint(ctx context.Context, sku string, reorderPoint, targetLevel int) error {
+	result, err := s.db.ExecContext(ctx, `
+        UPDATE available_stock SET reorder_point = $2, target_level = $3 WHERE sku = $1
+    `, sku, reorderPoint, targetLevel)
+	if err != nil {
+		return fmt.Errorf("failed to set reorder point: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrUnknownSKU
+	}
+	return nil
+}
+
+// recordLowStockCrossings journals every sku the reserve took from at or above its reorder point to below it.
+// taken holds the units reserved per sku; available_stock must already reflect them.
+func recordLowStockCrossings(ctx context.Context, tx *sql.Tx, orderID string, taken map[string]int32) ([]LowStockEvent, error) {
+	skus := sortedSKUs(taken)
+	counts := make([]int32, len(skus))
+	for i, sku := range skus {
+		counts[i] = taken[sku]
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        INSERT INTO low_stock_events (sku, order_id, quantity, reorder_point, target_level)
+        SELECT a.sku, $1, a.quantity, a.reorder_point, a.target_level
+        FROM available_stock a
+        JOIN (SELECT unnest($2::text[]) as sku, unnest($3::int[]) as taken) as d ON d.sku = a.sku
+        WHERE a.reorder_point > 0
+          AND a.quantity < a.reorder_point
+          AND a.quantity + d.taken >= a.reorder_point
+        RETURNING id, sku, quantity, reorder_point, target_level, created_at
+    `, orderID, pq.Array(skus), pq.Array(counts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record low stock events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []LowStockEvent
+	for rows.Next() {
+		e := LowStockEvent{OrderID: orderID}
+		if err := rows.Scan(&e.ID, &e.SKU, &e.Quantity, &e.ReorderPoint, &e.TargetLevel, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ListLowStock returns every sku below its reorder point with its latest alert time.
+func (s *Store) ListLowStock(ctx context.Context) ([]LowStockItem, error) {
+	query := `
+        SELECT a.sku, a.name, a.aisle_type, a.quantity, a.reorder_point, a.target_level,
+               COALESCE(a.unit_cost, 0), e.last_alert_at
+        FROM available_stock a
+        LEFT JOIN (
+            SELECT sku, MAX(created_at) as last_alert_at FROM low_stock_events GROUP BY sku
+        ) e ON e.sku = a.sku
+        WHERE a.reorder_point > 0 AND a.quantity < a.reorder_point
+        ORDER BY a.aisle_type, a.sku
+    `
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock: %w", err)
+	}
+	defer rows.Close()
+
+	var items []LowStockItem
+	for rows.Next() {
+		var i LowStockItem
+		var lastAlert sql.NullTime
+		if err := rows.Scan(&i.SKU, &i.Name, &i.AisleType, &i.Quantity, &i.ReorderPoint, &i.TargetLevel, &i.UnitCost, &lastAlert); err != nil {
+			return nil, err
+		}
+		i.LastAlertAt = lastAlert.Time
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reservation lifecycle states.
+const (
+	ReservationHeld      = "HELD"
+	ReservationCommitted = "COMMITTED"
+	ReservationReleased  = "RELEASED"
+	ReservationExpired   = "EXPIRED"
+	// ReservationRejected records a reserve that took nothing, so repeating it reports the same shortfall.
+	ReservationRejected = "REJECTED"
+)
+
+// MovementReservationExpired journals stock returned by an expired hold.
+const MovementReservationExpired = "RESERVATION_EXPIRED"
+
+var (
+	// ErrReservationNotHeld is returned when an order has no live (HELD) reservation.
+	ErrReservationNotHeld = errors.New("reservation not held")
+	// ErrReservationCommitted is returned when releasing an order that has already been dispatched.
+	ErrReservationCommitted = errors.New("reservation committed")
+)
+
+// Reservation is the inventory-side record of a client order's stock hold.
+type Reservation struct {
+	OrderID   string
+	Status    string
+	ExpiresAt time.Time
+	// Lines is the result of the order's reserve per sku; nil unless loaded.
+	Lines map[string]ReservationLine
+	// LowStock lists the skus this reserve took below their reorder point; only set when it was created.
+	LowStock []LowStockEvent
+}
+
+// ReservationLine is what an order's reserve asked for, found sellable and got for one sku.
+type ReservationLine struct {
+	Requested int32
+	Reserved  int32
+	Available int32
+}
+
+// Shortfall is how many requested units the stock could not cover.
+func (l ReservationLine) Shortfall() int32 {
+	return max(l.Requested-l.Available, 0)
+}
+
+// Live reports whether the hold still counts: HELD and unexpired, or COMMITTED.
+func (r *Reservation) Live(now time.Time) bool {
+	return r.Status == ReservationCommitted || (r.Status == ReservationHeld && r.ExpiresAt.After(now))
+}
+
+// lockReservation locks an order's reservation row for the transaction; nil when the order has none.
+func lockReservation(ctx context.Context, tx *sql.Tx, orderID string) (*Reservation, error) {
+	var r Reservation
+	err := tx.QueryRowContext(ctx, `
+        SELECT order_id, status, expires_at
+        FROM reservations
+        WHERE order_id = $1
+        FOR UPDATE
+    `, orderID).Scan(&r.OrderID, &r.Status, &r.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// loadReservation locks an order's reservation and reads the recorded result of its reserve.
+func loadReservation(ctx context.Context, tx *sql.Tx, orderID string) (*Reservation, error) {
+	r, err := lockReservation(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("reservation %s not found", orderID)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT sku, requested, reserved, available FROM reservation_items WHERE order_id = $1
+    `, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	r.Lines = make(map[string]ReservationLine)
+	for rows.Next() {
+		var sku string
+		var line ReservationLine
+		if err := rows.Scan(&sku, &line.Requested, &line.Reserved, &line.Available); err != nil {
+			return nil, err
+		}
+		r.Lines[sku] = line
+	}
+	return r, rows.Err()
+}
+
+// HeldItems returns what an order's live HELD reservation holds per sku. It returns ErrReservationCommitted
+// once the order was dispatched and ErrReservationNotHeld when there is no live hold.
+func (s *Store) HeldItems(ctx context.Context, orderID string) (map[string]int32, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load held items: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	var live bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT status, status = $2 AND expires_at > NOW() FROM reservations WHERE order_id = $1
+    `, orderID, ReservationHeld).Scan(&status, &live)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReservationNotHeld
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load held items: %w", err)
+	}
+	if status == ReservationCommitted {
+		return nil, ErrReservationCommitted
+	}
+	if !live {
+		return nil, ErrReservationNotHeld
+	}
+	held, err := heldQuantities(ctx, tx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load held items: %w", err)
+	}
+	return held, nil
+}
+
+// ExpireNextReservation releases the held stock of one overdue reservation and marks it EXPIRED.
+// It returns the expired order's id, or "" when nothing is overdue.
+func (s *Store) ExpireNextReservation(ctx context.Context) (string, map[string]int32, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+	defer tx.Rollback()
+
+	var orderID string
+	err = tx.QueryRowContext(ctx, `
+        SELECT order_id
+        FROM reservations
+        WHERE status = $1 AND expires_at <= NOW()
+        ORDER BY expires_at
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED
+    `, ReservationHeld).Scan(&orderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, nil
+	} else if err != nil {
+		return "", nil, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+
+	held, err := heldQuantities(ctx, tx, orderID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+	if err := releaseLots(ctx, tx, orderID, held, MovementReservationExpired, false); err != nil {
+		return "", nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE reservations SET status = $2, updated_at = NOW() WHERE order_id = $1
+    `, orderID, ReservationExpired); err != nil {
+		return "", nil, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", nil, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+	return orderID, held, nil
+}
+
+// heldQuantities sums the units an order holds per sku across its lots.
+func heldQuantities(ctx context.Context, tx *sql.Tx, orderID string) (map[string]int32, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT l.sku, SUM(r.quantity)::int
+        FROM lot_reservations r
+        JOIN stock_lots l ON l.id = r.lot_id
+        WHERE r.order_id = $1
+        GROUP BY l.sku
+    `, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	held := make(map[string]int32)
+	for rows.Next() {
+		var sku string
+		var qty int32
+		if err := rows.Scan(&sku, &qty); err != nil {
+			return nil, err
+		}
+		held[sku] = qty
+	}
+	return held, rows.Err()
+}
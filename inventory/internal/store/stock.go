@@ -3,7 +3,10 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -58,117 +61,673 @@ func (s *Store) GetBatchItems(ctx context.Context, skus []string) (map[string]*S
 	return items, nil
 }
 
-// ReserveStock atomically decrements available stock and returns actual reserved quantities.
-func (s *Store) ReserveStock(ctx context.Context, requests map[string]int32) (map[string]int32, error) {
-	var skus []string
-	var counts []int32
-	for sku, count := range requests {
-		skus = append(skus, sku)
-		counts = append(counts, count)
-	}
-
+// ListInStockByAisle returns in-stock items of one aisle type, excluding the given skus, most stocked first.
+func (s *Store) ListInStockByAisle(ctx context.Context, aisleType string, exclude []string, limit int) ([]StockItem, error) {
 	query := `
-        WITH request_batch AS (
-            SELECT unnest($1::text[]) as sku, unnest($2::int[]) as count
-        ),
-        locked_rows AS (
-            SELECT s.id, s.sku, s.quantity as old_qty, r.count as req_count
-            FROM available_stock s
-            JOIN request_batch r ON s.sku = r.sku
-            WHERE s.quantity > 0 
-            FOR UPDATE
-        )
-        UPDATE available_stock s
-        SET quantity = s.quantity - LEAST(l.old_qty, l.req_count), 
-            last_updated = NOW()
-        FROM locked_rows l
-        WHERE s.id = l.id
-        RETURNING s.sku, LEAST(l.old_qty, l.req_count) as actual_taken
+        SELECT id, sku, name, aisle_type, quantity, COALESCE(unit_cost, 0)
+        FROM available_stock
+        WHERE aisle_type = $1 AND quantity > 0 AND NOT (sku = ANY($2))
+        ORDER BY quantity DESC, sku
+        LIMIT $3
     `
-
-	rows, err := s.db.QueryContext(ctx, query, pq.Array(skus), pq.Array(counts))
+	rows, err := s.db.QueryContext(ctx, query, aisleType, pq.Array(exclude), limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reserve stock: %w", err)
+		return nil, fmt.Errorf("failed to list aisle stock: %w", err)
 	}
 	defer rows.Close()
 
+	var items []StockItem
+	for rows.Next() {
+		var i StockItem
+		if err := rows.Scan(&i.ID, &i.SKU, &i.Name, &i.AisleType, &i.Quantity, &i.UnitCost); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+// LotAllocation is the quantity of one stock lot held by an order.
+type LotAllocation struct {
+	LotID      int64
+	SKU        string
+	Quantity   int32
+	ExpiryDate time.Time // zero when the lot has no expiry
+}
+
+// ReserveStock holds stock for an order until holdFor elapses, taking from the earliest-expiring
+// sellable lots first. Unless partial is set the reserve is all-or-nothing: any shortfall takes nothing
+// and records the reservation as REJECTED. An order reserves once: if it already has a reservation,
+// that reservation is returned unchanged with created=false.
+func (s *Store) ReserveStock(ctx context.Context, orderID string, requests map[string]int32, holdFor time.Duration, partial bool) (*Reservation, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	defer tx.Rollback()
+
+	var expiresAt time.Time
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO reservations (order_id, status, expires_at)
+        VALUES ($1, $2, NOW() + make_interval(secs => $3))
+        ON CONFLICT (order_id) DO NOTHING
+        RETURNING expires_at
+    `, orderID, ReservationHeld, holdFor.Seconds()).Scan(&expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// A concurrent first reserve has committed by now, so the row is visible.
+		existing, err := loadReservation(ctx, tx, orderID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load reservation: %w", err)
+		}
+		return existing, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	reserved, available, err := takeLots(ctx, tx, orderID, requests, partial)
+	if err != nil {
+		return nil, false, err
+	}
+	var lowStock []LowStockEvent
+	if len(reserved) > 0 {
+		if lowStock, err = recordLowStockCrossings(ctx, tx, orderID, reserved); err != nil {
+			return nil, false, err
+		}
+	}
+
+	skus := sortedSKUs(requests)
+	lines := make(map[string]ReservationLine, len(skus))
+	requested := make([]int32, len(skus))
+	got := make([]int32, len(skus))
+	seen := make([]int32, len(skus))
+	var total int32
+	for i, sku := range skus {
+		requested[i], got[i], seen[i] = requests[sku], reserved[sku], available[sku]
+		lines[sku] = ReservationLine{Requested: requested[i], Reserved: got[i], Available: seen[i]}
+		total += got[i]
+	}
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO reservation_items (order_id, sku, requested, reserved, available)
+        SELECT $1, unnest($2::text[]), unnest($3::int[]), unnest($4::int[]), unnest($5::int[])
+    `, orderID, pq.Array(skus), pq.Array(requested), pq.Array(got), pq.Array(seen)); err != nil {
+		return nil, false, fmt.Errorf("failed to record reservation items: %w", err)
+	}
+
+	status := ReservationHeld
+	if total == 0 {
+		// Nothing was taken, so there is nothing to hold.
+		status = ReservationRejected
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE reservations SET status = $2, updated_at = NOW() WHERE order_id = $1
+        `, orderID, status); err != nil {
+			return nil, false, fmt.Errorf("failed to reject reservation: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	return &Reservation{OrderID: orderID, Status: status, ExpiresAt: expiresAt, Lines: lines, LowStock: lowStock}, true, nil
+}
+
+// takeLots decrements the earliest-expiring sellable lots for an order, records the lot holds,
+// and journals the change. It returns the quantity taken and the sellable quantity seen per sku.
+// Unless partial is set, nothing is taken when any sku is short.
+func takeLots(ctx context.Context, tx *sql.Tx, orderID string, requests map[string]int32, partial bool) (map[string]int32, map[string]int32, error) {
+	skus := sortedSKUs(requests)
+	if err := lockStockRows(ctx, tx, skus); err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, sku, quantity
+        FROM stock_lots
+        WHERE sku = ANY($1)
+          AND quantity > 0
+          AND (expiry_date IS NULL OR expiry_date >= NOW())
+        ORDER BY sku, expiry_date ASC NULLS LAST, id
+        FOR UPDATE
+    `, pq.Array(skus))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	remaining := make(map[string]int32, len(requests))
+	for sku, count := range requests {
+		remaining[sku] = count
+	}
 	results := make(map[string]int32)
+	available := make(map[string]int32)
+	var lotIDs []int64
+	var taken []int32
 	for rows.Next() {
+		var lotID int64
 		var sku string
-		var taken int32
-		if err := rows.Scan(&sku, &taken); err != nil {
+		var quantity int32
+		if err := rows.Scan(&lotID, &sku, &quantity); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		available[sku] += quantity
+		take := min(quantity, remaining[sku])
+		if take <= 0 {
+			continue
+		}
+		remaining[sku] -= take
+		results[sku] += take
+		lotIDs = append(lotIDs, lotID)
+		taken = append(taken, take)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	if !partial {
+		for _, sku := range skus {
+			if remaining[sku] > 0 {
+				return map[string]int32{}, available, nil
+			}
+		}
+	}
+	if len(lotIDs) == 0 {
+		return results, available, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE stock_lots l
+        SET quantity = l.quantity - d.taken
+        FROM (
+            SELECT unnest($1::bigint[]) as id, unnest($2::int[]) as taken
+        ) as d
+        WHERE l.id = d.id
+    `, pq.Array(lotIDs), pq.Array(taken)); err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock lots: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO lot_reservations (order_id, lot_id, quantity)
+        SELECT $1, unnest($2::bigint[]), unnest($3::int[])
+        ON CONFLICT (order_id, lot_id)
+        DO UPDATE SET quantity = lot_reservations.quantity + EXCLUDED.quantity
+    `, orderID, pq.Array(lotIDs), pq.Array(taken)); err != nil {
+		return nil, nil, fmt.Errorf("failed to record lot reservations: %w", err)
+	}
+	balances, err := refreshStockTotals(ctx, tx, skus)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	deltas := make(map[string]int32, len(results))
+	for sku, qty := range results {
+		deltas[sku] = -qty
+	}
+	if err := recordMovements(ctx, tx, MovementReserve, orderID, deltas, balances); err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	return results, available, nil
+}
+
+// ReleaseReservation returns everything an order still holds to stock and marks its hold RELEASED.
+// Releasing an order that holds nothing is a no-op; a COMMITTED order returns ErrReservationCommitted.
+func (s *Store) ReleaseReservation(ctx context.Context, orderID string) (map[string]int32, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+	defer tx.Rollback()
+
+	r, err := lockReservation(ctx, tx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+	if r != nil && r.Status == ReservationCommitted {
+		return nil, ErrReservationCommitted
+	}
+
+	held, err := heldQuantities(ctx, tx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+	if len(held) > 0 {
+		if err := releaseLots(ctx, tx, orderID, held, MovementRelease, false); err != nil {
 			return nil, err
 		}
-		results[sku] = taken
 	}
-	return results, nil
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE reservations SET status = $2, updated_at = NOW() WHERE order_id = $1 AND status = $3
+    `, orderID, ReservationReleased, ReservationHeld); err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+	return held, nil
 }
 
-// ReleaseStock restores quantities for previously reserved SKUs.
-func (s *Store) ReleaseStock(ctx context.Context, returns map[string]int32) error {
-	var skus []string
-	var counts []int32
+// releaseHeldStock returns quantities to the lots the order reserved them from, latest-expiring first,
+// never more than the order still holds. Only a legacy order, reserved before reservations and lot
+// holds were recorded, returns its quantities to the sku's most recently received lot instead.
+// The order's reservation is marked RELEASED once it holds nothing.
+func releaseHeldStock(ctx context.Context, tx *sql.Tx, orderID string, returns map[string]int32) error {
+	// Reservation row first, then skus, then lots: the order every stock transaction locks in.
+	r, err := lockReservation(ctx, tx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+	legacy := false
+	if r == nil {
+		var hasLots bool
+		if err := tx.QueryRowContext(ctx, `
+            SELECT EXISTS (SELECT 1 FROM lot_reservations WHERE order_id = $1)
+        `, orderID).Scan(&hasLots); err != nil {
+			return fmt.Errorf("failed to release stock: %w", err)
+		}
+		legacy = !hasLots
+	}
+	if err := releaseLots(ctx, tx, orderID, returns, MovementRelease, legacy); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE reservations
+        SET status = $2, updated_at = NOW()
+        WHERE order_id = $1
+          AND status IN ($3, $4)
+          AND NOT EXISTS (SELECT 1 FROM lot_reservations WHERE order_id = $1)
+    `, orderID, ReservationReleased, ReservationHeld, ReservationCommitted); err != nil {
+		return fmt.Errorf("failed to release reservation: %w", err)
+	}
+	return nil
+}
+
+// releaseLots gives an order's held units back to their lots and journals the change under reason.
+// Units beyond what the order holds are dropped, since they never left the shelf for this order, unless
+// legacy is set: then they go to the sku's newest lot.
+func releaseLots(ctx context.Context, tx *sql.Tx, orderID string, returns map[string]int32, reason string, legacy bool) error {
+	skus := sortedSKUs(returns)
+	if err := lockStockRows(ctx, tx, skus); err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT r.lot_id, l.sku, r.quantity
+        FROM lot_reservations r
+        JOIN stock_lots l ON l.id = r.lot_id
+        WHERE r.order_id = $1 AND l.sku = ANY($2)
+        ORDER BY l.sku, l.expiry_date DESC NULLS FIRST, l.id DESC
+        FOR UPDATE OF r
+    `, orderID, pq.Array(skus))
+	if err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+
+	remaining := make(map[string]int32, len(returns))
 	for sku, count := range returns {
-		skus = append(skus, sku)
-		counts = append(counts, count)
+		remaining[sku] = count
+	}
+	returned := make(map[string]int32, len(returns))
+	var lotIDs []int64
+	var given []int32
+	for rows.Next() {
+		var lotID int64
+		var sku string
+		var held int32
+		if err := rows.Scan(&lotID, &sku, &held); err != nil {
+			rows.Close()
+			return err
+		}
+		give := min(held, remaining[sku])
+		if give <= 0 {
+			continue
+		}
+		remaining[sku] -= give
+		returned[sku] += give
+		lotIDs = append(lotIDs, lotID)
+		given = append(given, give)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
 	}
 
-	query := `
-        UPDATE available_stock s
-        SET quantity = s.quantity + data.count,
-            last_updated = NOW()
-        FROM (
-            SELECT unnest($1::text[]) as sku, unnest($2::int[]) as count
-        ) as data
-        WHERE s.sku = data.sku
-    `
-	_, err := s.db.ExecContext(ctx, query, pq.Array(skus), pq.Array(counts))
+	if len(lotIDs) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE stock_lots l
+            SET quantity = l.quantity + d.given
+            FROM (
+                SELECT unnest($1::bigint[]) as id, unnest($2::int[]) as given
+            ) as d
+            WHERE l.id = d.id
+        `, pq.Array(lotIDs), pq.Array(given)); err != nil {
+			return fmt.Errorf("failed to release stock lots: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+            UPDATE lot_reservations r
+            SET quantity = r.quantity - d.given
+            FROM (
+                SELECT unnest($2::bigint[]) as lot_id, unnest($3::int[]) as given
+            ) as d
+            WHERE r.order_id = $1 AND r.lot_id = d.lot_id
+        `, orderID, pq.Array(lotIDs), pq.Array(given)); err != nil {
+			return fmt.Errorf("failed to update lot reservations: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM lot_reservations WHERE order_id = $1 AND quantity <= 0`, orderID); err != nil {
+			return fmt.Errorf("failed to update lot reservations: %w", err)
+		}
+	}
+
+	for _, sku := range skus {
+		if legacy && remaining[sku] > 0 {
+			if err := returnToNewestLot(ctx, tx, sku, remaining[sku]); err != nil {
+				return fmt.Errorf("failed to release stock: %w", err)
+			}
+			returned[sku] += remaining[sku]
+		}
+	}
+
+	balances, err := refreshStockTotals(ctx, tx, skus)
 	if err != nil {
 		return fmt.Errorf("failed to release stock: %w", err)
 	}
+	deltas := make(map[string]int32, len(returned))
+	for sku, qty := range returned {
+		// Releases of skus without a stock row change nothing, so there is nothing to journal.
+		if _, ok := balances[sku]; ok && qty > 0 {
+			deltas[sku] = qty
+		}
+	}
+	if err := recordMovements(ctx, tx, reason, orderID, deltas, balances); err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
 	return nil
 }
 
-// UpsertStock inserts new stock or increments existing quantity for a SKU.
-func (s *Store) UpsertStock(ctx context.Context, item StockItem) error {
+// GetReservedLots returns the lots an order holds per sku in first-expiry-first-out order.
+func (s *Store) GetReservedLots(ctx context.Context, orderID string) (map[string][]LotAllocation, error) {
+	query := `
+        SELECT r.lot_id, l.sku, r.quantity, l.expiry_date
+        FROM lot_reservations r
+        JOIN stock_lots l ON l.id = r.lot_id
+        WHERE r.order_id = $1
+        ORDER BY l.sku, l.expiry_date ASC NULLS LAST, l.id
+    `
+	rows, err := s.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserved lots: %w", err)
+	}
+	defer rows.Close()
+
+	lots := make(map[string][]LotAllocation)
+	for rows.Next() {
+		var a LotAllocation
+		var expiry sql.NullTime
+		if err := rows.Scan(&a.LotID, &a.SKU, &a.Quantity, &expiry); err != nil {
+			return nil, err
+		}
+		a.ExpiryDate = expiry.Time
+		lots[a.SKU] = append(lots[a.SKU], a)
+	}
+	return lots, rows.Err()
+}
+
+// receiveLot records a received shipment as a new lot, creating the sku if needed, and journals the restock.
+func receiveLot(ctx context.Context, tx *sql.Tx, restockOrderID string, item StockItem) (int64, error) {
+	// The upsert also locks the sku row for the rest of the transaction.
 	query := `
         INSERT INTO available_stock (sku, name, aisle_type, quantity, unit_cost, mfd_date, expiry_date, last_updated)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+        VALUES ($1, $2, $3, 0, $4, $5, $6, NOW())
         ON CONFLICT (sku) 
         DO UPDATE SET 
-            quantity = available_stock.quantity + EXCLUDED.quantity, 
 			unit_cost = EXCLUDED.unit_cost,
             name = EXCLUDED.name,
             last_updated = NOW()
     `
-	_, err := s.db.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		item.SKU,
 		item.Name,
 		item.AisleType,
-		item.Quantity,
 		item.UnitCost,
 		item.MfdDate,
 		item.ExpiryDate,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to upsert stock: %w", err)
+		return 0, fmt.Errorf("failed to upsert stock: %w", err)
 	}
-	return nil
+
+	var lotID int64
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO stock_lots (sku, restock_order_id, quantity, received_quantity, unit_cost, mfd_date, expiry_date)
+        VALUES ($1, NULLIF($2, ''), $3, $3, $4, $5, $6)
+        RETURNING id
+    `, item.SKU, restockOrderID, item.Quantity, item.UnitCost, item.MfdDate, item.ExpiryDate).Scan(&lotID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create stock lot: %w", err)
+	}
+
+	balances, err := refreshStockTotals(ctx, tx, []string{item.SKU})
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert stock: %w", err)
+	}
+	if err := recordMovements(ctx, tx, MovementRestock, restockOrderID, map[string]int32{item.SKU: int32(item.Quantity)}, balances); err != nil {
+		return 0, fmt.Errorf("failed to upsert stock: %w", err)
+	}
+	return lotID, nil
 }
 
-// ClearExpiredStock zeroes out quantities for expired inventory records.
-func (s *Store) ClearExpiredStock(ctx context.Context) (int64, error) {
-	query := `
-        UPDATE available_stock
-        SET quantity = 0, 
-            last_updated = NOW()
+// ClearExpiredStock zeroes out expired lots and records each cleared lot as waste in the same transaction.
+func (s *Store) ClearExpiredStock(ctx context.Context) ([]WasteRecord, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	defer tx.Rollback()
+
+	var skus []string
+	err = tx.QueryRowContext(ctx, `
+        SELECT COALESCE(array_agg(DISTINCT sku), '{}')
+        FROM stock_lots
         WHERE expiry_date < NOW() AND quantity > 0
+    `).Scan(pq.Array(&skus))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	if len(skus) == 0 {
+		return nil, nil
+	}
+	sort.Strings(skus)
+
+	// Lock skus before lots, the same order reservations use.
+	if err := lockStockRows(ctx, tx, skus); err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	rows, err := tx.QueryContext(ctx, `
+        WITH expired AS (
+            SELECT l.id, l.sku, s.aisle_type, l.quantity, l.unit_cost, l.expiry_date
+            FROM stock_lots l
+            JOIN available_stock s ON s.sku = l.sku
+            WHERE l.sku = ANY($1) AND l.expiry_date < NOW() AND l.quantity > 0
+            FOR UPDATE OF l
+        ),
+        cleared AS (
+            UPDATE stock_lots l
+            SET quantity = 0
+            FROM expired e
+            WHERE l.id = e.id
+            RETURNING l.id
+        )
+        INSERT INTO stock_waste (lot_id, sku, aisle_type, quantity, unit_cost, expiry_date)
+        SELECT e.id, e.sku, e.aisle_type, e.quantity, e.unit_cost, e.expiry_date
+        FROM expired e
+        JOIN cleared c ON c.id = e.id
+        RETURNING lot_id, sku, aisle_type, quantity, unit_cost, expiry_date, written_off_at
+    `, pq.Array(skus))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	var records []WasteRecord
+	for rows.Next() {
+		var r WasteRecord
+		if err := rows.Scan(&r.LotID, &r.SKU, &r.AisleType, &r.Quantity, &r.UnitCost, &r.ExpiryDate, &r.WrittenOffAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+
+	balances, err := refreshStockTotals(ctx, tx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	deltas := make(map[string]int32)
+	for _, r := range records {
+		deltas[r.SKU] -= int32(r.Quantity)
+	}
+	if err := recordMovements(ctx, tx, MovementExpiryWriteOff, "", deltas, balances); err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to clear expired stock: %w", err)
+	}
+	return records, nil
+}
+
+// lockStockRows serializes quantity changes per sku; rows are locked in sku order to avoid deadlocks.
+func lockStockRows(ctx context.Context, tx *sql.Tx, skus []string) error {
+	_, err := tx.ExecContext(ctx, `
+        SELECT id FROM available_stock
+        WHERE sku = ANY($1)
+        ORDER BY sku
+        FOR UPDATE
+    `, pq.Array(skus))
+	return err
+}
+
+// refreshStockTotals recomputes available_stock quantity and next-to-expire dates from the skus' lots
+// and returns each sku's resulting quantity.
+func refreshStockTotals(ctx context.Context, tx *sql.Tx, skus []string) (map[string]int, error) {
+	rows, err := tx.QueryContext(ctx, `
+        UPDATE available_stock s
+        SET quantity = totals.quantity,
+            mfd_date = COALESCE(next_lot.mfd_date, s.mfd_date),
+            expiry_date = COALESCE(next_lot.expiry_date, s.expiry_date),
+            last_updated = NOW()
+        FROM available_stock base
+        CROSS JOIN LATERAL (
+            SELECT COALESCE(SUM(quantity), 0)::int AS quantity
+            FROM stock_lots
+            WHERE sku = base.sku
+        ) totals
+        LEFT JOIN LATERAL (
+            SELECT mfd_date, expiry_date
+            FROM stock_lots
+            WHERE sku = base.sku AND quantity > 0
+            ORDER BY expiry_date ASC NULLS LAST, id
+            LIMIT 1
+        ) next_lot ON true
+        WHERE s.id = base.id AND base.sku = ANY($1)
+        RETURNING s.sku, s.quantity
+    `, pq.Array(skus))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[string]int, len(skus))
+	for rows.Next() {
+		var sku string
+		var qty int
+		if err := rows.Scan(&sku, &qty); err != nil {
+			return nil, err
+		}
+		balances[sku] = qty
+	}
+	return balances, rows.Err()
+}
+
+// returnToNewestLot puts back units with no recorded lot, creating a lot from the sku row if it has none.
+func returnToNewestLot(ctx context.Context, tx *sql.Tx, sku string, qty int32) error {
+	result, err := tx.ExecContext(ctx, `
+        UPDATE stock_lots
+        SET quantity = quantity + $2
+        WHERE id = (
+            SELECT id FROM stock_lots
+            WHERE sku = $1
+            ORDER BY received_at DESC, id DESC
+            LIMIT 1
+        )
+    `, sku, qty)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO stock_lots (sku, quantity, received_quantity, unit_cost, mfd_date, expiry_date)
+        SELECT sku, $2, $2, COALESCE(unit_cost, 0.00), mfd_date, expiry_date
+        FROM available_stock
+        WHERE sku = $1
+    `, sku, qty)
+	return err
+}
+
+// sortedSKUs returns the map's skus in lock order.
+func sortedSKUs(items map[string]int32) []string {
+	skus := make([]string, 0, len(items))
+	for sku := range items {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+	return skus
+}
+
+// StockFilter narrows ListStock; zero values match everything.
+type StockFilter struct {
+	AisleType      string
+	InStockOnly    bool
+	ExpiringBefore time.Time
+	NamePrefix     string
+}
+
+// ListStock returns up to limit stock rows with sku greater than after, in sku order.
+func (s *Store) ListStock(ctx context.Context, filter StockFilter, after string, limit int) ([]StockItem, error) {
+	var expiringBefore sql.NullTime
+	if !filter.ExpiringBefore.IsZero() {
+		expiringBefore = sql.NullTime{Time: filter.ExpiringBefore, Valid: true}
+	}
+	prefix := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(filter.NamePrefix)
+
+	query := `
+        SELECT id, sku, name, aisle_type, quantity, COALESCE(unit_cost, 0), expiry_date
+        FROM available_stock
+        WHERE sku > $1
+          AND ($2::text = '' OR aisle_type = $2)
+          AND (NOT $3::boolean OR quantity > 0)
+          AND ($4::timestamp IS NULL OR expiry_date <= $4)
+          AND name ILIKE $5::text || '%'
+        ORDER BY sku
+        LIMIT $6
     `
-	result, err := s.db.ExecContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, query, after, filter.AisleType, filter.InStockOnly, expiringBefore, prefix, limit)
 	if err != nil {
-		return 0, fmt.Errorf("failed to clear expired stock: %w", err)
+		return nil, fmt.Errorf("failed to list stock: %w", err)
+	}
+	defer rows.Close()
+
+	var items []StockItem
+	for rows.Next() {
+		var i StockItem
+		var expiry sql.NullTime
+		if err := rows.Scan(&i.ID, &i.SKU, &i.Name, &i.AisleType, &i.Quantity, &i.UnitCost, &expiry); err != nil {
+			return nil, err
+		}
+		i.ExpiryDate = expiry.Time
+		items = append(items, i)
 	}
-	return result.RowsAffected()
+	return items, rows.Err()
 }
 
 // GetAllStock returns sku-level quantity and unit cost for pricing sync.
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WasteRecord is one expired lot written off by ClearExpiredStock.
+type WasteRecord struct {
+	LotID        int64
+	SKU          string
+	AisleType    string
+	Quantity     int
+	UnitCost     float64
+	ExpiryDate   time.Time
+	WrittenOffAt time.Time
+}
+
+// WasteTotal aggregates written-off stock for one day and aisle.
+type WasteTotal struct {
+	Day       string // YYYY-MM-DD
+	AisleType string
+	Quantity  int
+	TotalCost float64
+	Lots      int
+}
+
+// SumWasteByDayAndAisle totals write-offs in [from, to), optionally limited to one aisle.
+func (s *Store) SumWasteByDayAndAisle(ctx context.Context, from, to time.Time, aisleType string) ([]WasteTotal, error) {
+	query := `
+        SELECT to_char(date_trunc('day', written_off_at), 'YYYY-MM-DD') AS day,
+               aisle_type,
+               SUM(quantity)::int,
+               SUM(quantity * unit_cost)::float8,
+               COUNT(*)::int
+        FROM stock_waste
+        WHERE written_off_at >= $1 AND written_off_at < $2
+          AND ($3 = '' OR aisle_type = $3)
+        GROUP BY 1, 2
+        ORDER BY 1, 2
+    `
+	rows, err := s.db.QueryContext(ctx, query, from, to, aisleType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum waste: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []WasteTotal
+	for rows.Next() {
+		var t WasteTotal
+		if err := rows.Scan(&t.Day, &t.AisleType, &t.Quantity, &t.TotalCost, &t.Lots); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,548 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"auto_grocery/inventory/internal/mq"
+	pb "auto_grocery/inventory/proto"
+
+	"github.com/lib/pq"
+)
+
+// Order flows as recorded in workflows and the webhook outbox.
+const (
+	OrderTypeCustomer = "CUSTOMER"
+	OrderTypeRestock  = "RESTOCK"
+)
+
+// Fulfillment workflow stages, in order.
+const (
+	WorkflowDispatched = "DISPATCHED"
+	WorkflowPicking    = "PICKING"
+	WorkflowBilling    = "BILLING"
+	WorkflowNotified   = "NOTIFIED"
+	WorkflowDone       = "DONE"
+	// WorkflowDead is a BILLING workflow whose settlement kept failing; it waits for an operator.
+	WorkflowDead = "DEAD"
+)
+
+// ErrWorkflowNotFound is returned when an order has no workflow in the stage an operation needs.
+var ErrWorkflowNotFound = errors.New("workflow not found")
+
+// Workflow is the durable state of one dispatched order.
+type Workflow struct {
+	OrderID         string
+	OrderType       string
+	Stage           string
+	Items           map[string]int32  // CUSTOMER orders
+	QuoteID         string            // CUSTOMER orders billed at a price quote
+	RestockItems    []*pb.RestockItem // RESTOCK orders
+	RobotItems      map[string]mq.ItemDetails
+	ExpectedReports int
+	Reports         int // counted robot reports
+	Picked          map[string]int32
+	Reporters       []string
+	FailCode        string // set when the order is failed instead of billed
+	FailReason      string
+	StockSettled    bool
+	SettleAttempts  int // failed settlements so far
+	DispatchedAt    time.Time
+}
+
+// WorkflowStore holds the durable fulfillment workflows of dispatched orders and the delivery state of the
+// ordering webhooks they enqueue. Store is the postgres implementation.
+type WorkflowStore interface {
+	// CreateWorkflow records a dispatched order; recording the same order again is a no-op.
+	CreateWorkflow(ctx context.Context, wf *Workflow) error
+	// CommitCustomerWorkflow commits a client order's HELD reservation and records its workflow together,
+	// or returns ErrReservationNotHeld.
+	CommitCustomerWorkflow(ctx context.Context, wf *Workflow) error
+	// GetWorkflow loads an order's workflow, or returns ErrWorkflowNotFound.
+	GetWorkflow(ctx context.Context, orderType string, orderID string) (*Workflow, error)
+	// ListOpenWorkflows returns every workflow that has not reached DONE or DEAD, oldest dispatch first.
+	ListOpenWorkflows(ctx context.Context) ([]*Workflow, error)
+
+	// RecordWorkflowReport adds one counted robot report to a workflow that is still being picked.
+	RecordWorkflowReport(ctx context.Context, orderType string, orderID string, robotID string, picked map[string]int32) error
+	// ClaimWorkflow moves a dispatched or picking workflow to BILLING and reports whether this caller claimed it.
+	ClaimWorkflow(ctx context.Context, orderType string, orderID string, picked map[string]int32, failCode string, failReason string) (bool, error)
+	// AbandonOrder releases a client order's held stock and moves a workflow still being picked to DEAD.
+	AbandonOrder(ctx context.Context, orderType string, orderID string, failCode string, failReason string) error
+
+	// SettleClientOrder applies a billed client order's stock effects exactly once.
+	SettleClientOrder(ctx context.Context, orderID string, release map[string]int32) error
+	// SettleRestock shelves a restock's offloaded lots exactly once and returns the new lot ids.
+	SettleRestock(ctx context.Context, orderID string, lots []StockItem) ([]int64, error)
+	// ClaimDueSettlements leases up to limit BILLING workflows whose retry is due.
+	ClaimDueSettlements(ctx context.Context, limit int, lease time.Duration) ([]*Workflow, error)
+	// MarkSettleFailed records a failed settlement and schedules a retry or moves the workflow to DEAD.
+	MarkSettleFailed(ctx context.Context, orderType string, orderID string, lastError string, retryIn time.Duration, dead bool) error
+
+	// NotifyWorkflow moves a BILLING workflow to NOTIFIED and enqueues its ordering webhook.
+	NotifyWorkflow(ctx context.Context, orderType string, orderID string, targetURL string, payload []byte, lease time.Duration) (int64, error)
+	// MarkWebhookDelivered records a successful webhook delivery.
+	MarkWebhookDelivered(ctx context.Context, id int64) error
+	// MarkWebhookFailed records a failed webhook delivery and schedules a retry or dead-letters it.
+	MarkWebhookFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error
+	// CompleteWorkflow marks a notified workflow DONE.
+	CompleteWorkflow(ctx context.Context, orderType string, orderID string) error
+}
+
+var _ WorkflowStore = (*Store)(nil)
+
+const workflowColumns = `order_id, order_type, stage, items, robot_items, expected_reports, reports, picked, reporters,
+               COALESCE(fail_code, ''), COALESCE(fail_reason, ''), stock_settled, dispatched_at, COALESCE(quote_id, ''),
+               settle_attempts`
+
+// CreateWorkflow records a dispatched order; recording the same order again is a no-op.
+func (s *Store) CreateWorkflow(ctx context.Context, wf *Workflow) error {
+	return insertWorkflow(ctx, s.db, wf)
+}
+
+// CommitCustomerWorkflow commits a client order's HELD reservation and records its workflow in one
+// transaction, so a committed reservation always has a workflow to settle it. It returns
+// ErrReservationNotHeld, recording nothing, when the hold expired or was released.
+func (s *Store) CommitCustomerWorkflow(ctx context.Context, wf *Workflow) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to commit reservation: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE reservations
+        SET status = $2, updated_at = NOW()
+        WHERE order_id = $1 AND status = $3 AND expires_at > NOW()
+    `, wf.OrderID, ReservationCommitted, ReservationHeld)
+	if err != nil {
+		return fmt.Errorf("failed to commit reservation: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrReservationNotHeld
+	}
+	if err := insertWorkflow(ctx, tx, wf); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reservation: %w", err)
+	}
+	return nil
+}
+
+// insertWorkflow writes a workflow row through db or a caller's transaction.
+func insertWorkflow(ctx context.Context, db interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}, wf *Workflow) error {
+	var items any = wf.Items
+	if wf.OrderType == OrderTypeRestock {
+		items = wf.RestockItems
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow items: %w", err)
+	}
+	robotJSON, err := json.Marshal(wf.RobotItems)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow robot items: %w", err)
+	}
+	var pickedJSON []byte
+	if wf.Picked != nil {
+		if pickedJSON, err = json.Marshal(wf.Picked); err != nil {
+			return fmt.Errorf("failed to encode workflow picked items: %w", err)
+		}
+	}
+	stage := wf.Stage
+	if stage == "" {
+		stage = WorkflowDispatched
+	}
+
+	_, err = db.ExecContext(ctx, `
+        INSERT INTO fulfillment_workflows
+            (order_id, order_type, stage, items, robot_items, expected_reports, reports, picked, reporters, fail_code, fail_reason, quote_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8::jsonb, '{}'), COALESCE($9::text[], '{}'), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''))
+        ON CONFLICT (order_type, order_id) DO NOTHING
+    `, wf.OrderID, wf.OrderType, stage, itemsJSON, robotJSON, wf.ExpectedReports, wf.Reports, nullJSON(pickedJSON),
+		pq.Array(wf.Reporters), wf.FailCode, wf.FailReason, wf.QuoteID)
+	if err != nil {
+		return fmt.Errorf("failed to create workflow: %w", err)
+	}
+	return nil
+}
+
+// RecordWorkflowReport adds one counted robot report to a workflow that is still being picked.
+func (s *Store) RecordWorkflowReport(ctx context.Context, orderType string, orderID string, robotID string, picked map[string]int32) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to record workflow report: %w", err)
+	}
+	defer tx.Rollback()
+
+	var raw []byte
+	err = tx.QueryRowContext(ctx, `
+        SELECT picked FROM fulfillment_workflows
+        WHERE order_type = $1 AND order_id = $2 AND stage IN ($3, $4)
+        FOR UPDATE
+    `, orderType, orderID, WorkflowDispatched, WorkflowPicking).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrWorkflowNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to load workflow: %w", err)
+	}
+	var total map[string]int32
+	if err := json.Unmarshal(raw, &total); err != nil {
+		return fmt.Errorf("failed to decode workflow picked items: %w", err)
+	}
+	if total == nil {
+		total = make(map[string]int32)
+	}
+	// Robots on one aisle all confirm the same task; the weakest report is what the aisle delivered.
+	for sku, qty := range picked {
+		if current, ok := total[sku]; !ok || qty < current {
+			total[sku] = qty
+		}
+	}
+	pickedJSON, err := json.Marshal(total)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow picked items: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stage = $3,
+            reports = reports + 1,
+            picked = $4,
+            reporters = CASE WHEN $5::text = '' THEN reporters ELSE array_append(reporters, $5::text) END,
+            updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2
+    `, orderType, orderID, WorkflowPicking, pickedJSON, robotID); err != nil {
+		return fmt.Errorf("failed to record workflow report: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ClaimWorkflow moves a dispatched or picking workflow to BILLING and reports whether this caller claimed it.
+// picked, when non-nil, replaces the recorded picked totals; failCode marks the order to be failed instead of billed.
+// It returns ErrWorkflowNotFound when the order has no workflow at all.
+func (s *Store) ClaimWorkflow(ctx context.Context, orderType string, orderID string, picked map[string]int32, failCode string, failReason string) (bool, error) {
+	var pickedJSON []byte
+	if picked != nil {
+		var err error
+		if pickedJSON, err = json.Marshal(picked); err != nil {
+			return false, fmt.Errorf("failed to encode workflow picked items: %w", err)
+		}
+	}
+
+	var claimed, exists bool
+	err := s.db.QueryRowContext(ctx, `
+        WITH claimed AS (
+            UPDATE fulfillment_workflows
+            SET stage = $3,
+                picked = COALESCE($4::jsonb, picked),
+                fail_code = NULLIF($5, ''),
+                fail_reason = NULLIF($6, ''),
+                updated_at = NOW()
+            WHERE order_type = $1 AND order_id = $2 AND stage IN ($7, $8)
+            RETURNING 1
+        )
+        SELECT EXISTS (SELECT 1 FROM claimed),
+               EXISTS (SELECT 1 FROM fulfillment_workflows WHERE order_type = $1 AND order_id = $2)
+    `, orderType, orderID, WorkflowBilling, nullJSON(pickedJSON), failCode, failReason,
+		WorkflowDispatched, WorkflowPicking).Scan(&claimed, &exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim workflow: %w", err)
+	}
+	if !exists {
+		return false, ErrWorkflowNotFound
+	}
+	return claimed, nil
+}
+
+// GetWorkflow loads an order's workflow.
+func (s *Store) GetWorkflow(ctx context.Context, orderType string, orderID string) (*Workflow, error) {
+	row := s.db.QueryRowContext(ctx, `
+        SELECT `+workflowColumns+`
+        FROM fulfillment_workflows
+        WHERE order_type = $1 AND order_id = $2
+    `, orderType, orderID)
+	wf, err := scanWorkflow(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWorkflowNotFound
+	}
+	return wf, err
+}
+
+// ListOpenWorkflows returns every workflow that has not reached DONE or DEAD, oldest dispatch first.
+func (s *Store) ListOpenWorkflows(ctx context.Context) ([]*Workflow, error) {
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT `+workflowColumns+`
+        FROM fulfillment_workflows
+        WHERE stage NOT IN ($1, $2)
+        ORDER BY dispatched_at
+    `, WorkflowDone, WorkflowDead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open workflows: %w", err)
+	}
+	defer rows.Close()
+	return scanWorkflows(rows)
+}
+
+// ClaimDueSettlements leases up to limit BILLING workflows whose retry is due and returns them. A workflow
+// that never failed is due once it has sat in BILLING for lease, so the caller finishing it first is left alone.
+func (s *Store) ClaimDueSettlements(ctx context.Context, limit int, lease time.Duration) ([]*Workflow, error) {
+	rows, err := s.db.QueryContext(ctx, `
+        UPDATE fulfillment_workflows w
+        SET next_settle_at = NOW() + make_interval(secs => $3)
+        FROM (
+            SELECT order_type AS due_type, order_id AS due_id
+            FROM fulfillment_workflows
+            WHERE stage = $1
+              AND COALESCE(next_settle_at, updated_at + make_interval(secs => $3)) <= NOW()
+            ORDER BY updated_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        ) due
+        WHERE w.order_type = due.due_type AND w.order_id = due.due_id
+        RETURNING `+workflowColumns+`
+    `, WorkflowBilling, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due settlements: %w", err)
+	}
+	defer rows.Close()
+	return scanWorkflows(rows)
+}
+
+// MarkSettleFailed records a failed settlement of a BILLING workflow and either schedules a retry after
+// retryIn or moves the workflow to DEAD.
+func (s *Store) MarkSettleFailed(ctx context.Context, orderType string, orderID string, lastError string, retryIn time.Duration, dead bool) error {
+	stage := WorkflowBilling
+	if dead {
+		stage = WorkflowDead
+	}
+	if _, err := s.db.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stage = $3, settle_attempts = settle_attempts + 1, last_error = $4,
+            next_settle_at = NOW() + make_interval(secs => $5), updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2 AND stage = $6
+    `, orderType, orderID, stage, lastError, retryIn.Seconds(), WorkflowBilling); err != nil {
+		return fmt.Errorf("failed to mark settlement failed: %w", err)
+	}
+	return nil
+}
+
+// scanWorkflows reads workflow rows selected with workflowColumns.
+func scanWorkflows(rows *sql.Rows) ([]*Workflow, error) {
+	var workflows []*Workflow
+	for rows.Next() {
+		wf, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, wf)
+	}
+	return workflows, rows.Err()
+}
+
+// SettleClientOrder applies the stock effects of billing a client order exactly once: release returns
+// unpicked (or, for a failed order, all) units to their lots and whatever is still held leaves with the customer.
+func (s *Store) SettleClientOrder(ctx context.Context, orderID string, release map[string]int32) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to settle order stock: %w", err)
+	}
+	defer tx.Rollback()
+
+	settled, err := lockBillingWorkflow(ctx, tx, OrderTypeCustomer, orderID)
+	if err != nil || settled {
+		return err
+	}
+	if len(release) > 0 {
+		if err := releaseHeldStock(ctx, tx, orderID, release); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lot_reservations WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("failed to commit lot reservations: %w", err)
+	}
+	if err := markStockSettled(ctx, tx, OrderTypeCustomer, orderID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SettleRestock shelves a restock's offloaded lots exactly once and returns the new lot ids in sku order;
+// nil when an earlier attempt already shelved them.
+func (s *Store) SettleRestock(ctx context.Context, orderID string, lots []StockItem) ([]int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle restock: %w", err)
+	}
+	defer tx.Rollback()
+
+	settled, err := lockBillingWorkflow(ctx, tx, OrderTypeRestock, orderID)
+	if err != nil || settled {
+		return nil, err
+	}
+
+	// Shelve in sku order so concurrent restocks lock stock rows in the same order.
+	sort.SliceStable(lots, func(i, j int) bool { return lots[i].SKU < lots[j].SKU })
+	lotIDs := make([]int64, 0, len(lots))
+	for _, item := range lots {
+		lotID, err := receiveLot(ctx, tx, orderID, item)
+		if err != nil {
+			return nil, err
+		}
+		lotIDs = append(lotIDs, lotID)
+	}
+	if err := markStockSettled(ctx, tx, OrderTypeRestock, orderID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to settle restock: %w", err)
+	}
+	return lotIDs, nil
+}
+
+// NotifyWorkflow moves a BILLING workflow to NOTIFIED and enqueues its ordering webhook in the same transaction,
+// so ordering is told exactly once. It returns ErrWorkflowNotFound when the workflow is not in BILLING.
+func (s *Store) NotifyWorkflow(ctx context.Context, orderType string, orderID string, targetURL string, payload []byte, lease time.Duration) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to notify workflow: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stage = $3, updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2 AND stage = $4
+    `, orderType, orderID, WorkflowNotified, WorkflowBilling)
+	if err != nil {
+		return 0, fmt.Errorf("failed to notify workflow: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return 0, ErrWorkflowNotFound
+	}
+	id, err := enqueueWebhook(ctx, tx, orderID, orderType, targetURL, payload, lease)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to notify workflow: %w", err)
+	}
+	return id, nil
+}
+
+// CompleteWorkflow marks a notified workflow DONE.
+func (s *Store) CompleteWorkflow(ctx context.Context, orderType string, orderID string) error {
+	if _, err := s.db.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stage = $3, updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2 AND stage = $4
+    `, orderType, orderID, WorkflowDone, WorkflowNotified); err != nil {
+		return fmt.Errorf("failed to complete workflow: %w", err)
+	}
+	return nil
+}
+
+// lockBillingWorkflow locks a BILLING workflow and reports whether its stock effects were already applied.
+func lockBillingWorkflow(ctx context.Context, tx *sql.Tx, orderType string, orderID string) (bool, error) {
+	var settled bool
+	err := tx.QueryRowContext(ctx, `
+        SELECT stock_settled FROM fulfillment_workflows
+        WHERE order_type = $1 AND order_id = $2 AND stage = $3
+        FOR UPDATE
+    `, orderType, orderID, WorkflowBilling).Scan(&settled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrWorkflowNotFound
+	} else if err != nil {
+		return false, fmt.Errorf("failed to lock workflow: %w", err)
+	}
+	return settled, nil
+}
+
+// markStockSettled records that a workflow's stock effects are applied.
+func markStockSettled(ctx context.Context, tx *sql.Tx, orderType string, orderID string) error {
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stock_settled = TRUE, updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2
+    `, orderType, orderID); err != nil {
+		return fmt.Errorf("failed to settle workflow stock: %w", err)
+	}
+	return nil
+}
+
+// AbandonOrder dead-letters an order that could not be finalized: a client order's held stock goes back to
+// its lots and a workflow still being picked moves to DEAD with the failure. Repeating it changes nothing.
+func (s *Store) AbandonOrder(ctx context.Context, orderType string, orderID string, failCode string, failReason string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to abandon order: %w", err)
+	}
+	defer tx.Rollback()
+
+	if orderType == OrderTypeCustomer {
+		held, err := heldQuantities(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to abandon order: %w", err)
+		}
+		if len(held) > 0 {
+			if err := releaseHeldStock(ctx, tx, orderID, held); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE fulfillment_workflows
+        SET stage = $3, fail_code = NULLIF($4, ''), fail_reason = NULLIF($5, ''), last_error = $5, updated_at = NOW()
+        WHERE order_type = $1 AND order_id = $2 AND stage IN ($6, $7)
+    `, orderType, orderID, WorkflowDead, failCode, failReason, WorkflowDispatched, WorkflowPicking); err != nil {
+		return fmt.Errorf("failed to abandon workflow: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to abandon order: %w", err)
+	}
+	return nil
+}
+
+// scanWorkflow reads one workflowColumns row.
+func scanWorkflow(row interface{ Scan(...any) error }) (*Workflow, error) {
+	var wf Workflow
+	var items, robotItems, picked []byte
+	err := row.Scan(&wf.OrderID, &wf.OrderType, &wf.Stage, &items, &robotItems, &wf.ExpectedReports, &wf.Reports, &picked,
+		pq.Array(&wf.Reporters), &wf.FailCode, &wf.FailReason, &wf.StockSettled, &wf.DispatchedAt, &wf.QuoteID,
+		&wf.SettleAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	if wf.OrderType == OrderTypeRestock {
+		err = json.Unmarshal(items, &wf.RestockItems)
+	} else {
+		err = json.Unmarshal(items, &wf.Items)
+	}
+	if err == nil {
+		err = json.Unmarshal(robotItems, &wf.RobotItems)
+	}
+	if err == nil {
+		err = json.Unmarshal(picked, &wf.Picked)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode workflow %s: %w", wf.OrderID, err)
+	}
+	return &wf, nil
+}
+
+// nullJSON passes an empty encoding as SQL NULL.
+func nullJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
@@ -22,6 +22,168 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How ReserveItems treats skus without enough stock.
+type ReserveMode int32
+
+const (
+	ReserveMode_RESERVE_MODE_UNSPECIFIED    ReserveMode = 0 // same as ALL_OR_NOTHING
+	ReserveMode_RESERVE_MODE_ALL_OR_NOTHING ReserveMode = 1 // any shortfall rejects the reserve and takes nothing
+	ReserveMode_RESERVE_MODE_PARTIAL        ReserveMode = 2 // take what is available; fails only when nothing is
+)
+
+// Enum value maps for ReserveMode.
+var (
+	ReserveMode_name = map[int32]string{
+		0: "RESERVE_MODE_UNSPECIFIED",
+		1: "RESERVE_MODE_ALL_OR_NOTHING",
+		2: "RESERVE_MODE_PARTIAL",
+	}
+	ReserveMode_value = map[string]int32{
+		"RESERVE_MODE_UNSPECIFIED":    0,
+		"RESERVE_MODE_ALL_OR_NOTHING": 1,
+		"RESERVE_MODE_PARTIAL":        2,
+	}
+)
+
+func (x ReserveMode) Enum() *ReserveMode {
+	p := new(ReserveMode)
+	*p = x
+	return p
+}
+
+func (x ReserveMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReserveMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (ReserveMode) Type() protoreflect.EnumType {
+	return &file_inventory_proto_inventory_proto_enumTypes[0]
+}
+
+func (x ReserveMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReserveMode.Descriptor instead.
+func (ReserveMode) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{0}
+}
+
+// Outcome of one robot's work on an aisle task.
+type JobStatus int32
+
+const (
+	JobStatus_JOB_STATUS_UNSPECIFIED JobStatus = 0
+	JobStatus_JOB_STATUS_SUCCESS     JobStatus = 1 // every item picked/offloaded
+	JobStatus_JOB_STATUS_NO_OP       JobStatus = 2 // nothing to do for this aisle
+	JobStatus_JOB_STATUS_FAILED      JobStatus = 3 // the robot could not work the task; the aisle is re-dispatched
+	JobStatus_JOB_STATUS_PARTIAL     JobStatus = 4 // some items short; processed_items holds what was handled
+)
+
+// Enum value maps for JobStatus.
+var (
+	JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNSPECIFIED",
+		1: "JOB_STATUS_SUCCESS",
+		2: "JOB_STATUS_NO_OP",
+		3: "JOB_STATUS_FAILED",
+		4: "JOB_STATUS_PARTIAL",
+	}
+	JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNSPECIFIED": 0,
+		"JOB_STATUS_SUCCESS":     1,
+		"JOB_STATUS_NO_OP":       2,
+		"JOB_STATUS_FAILED":      3,
+		"JOB_STATUS_PARTIAL":     4,
+	}
+)
+
+func (x JobStatus) Enum() *JobStatus {
+	p := new(JobStatus)
+	*p = x
+	return p
+}
+
+func (x JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (JobStatus) Type() protoreflect.EnumType {
+	return &file_inventory_proto_inventory_proto_enumTypes[1]
+}
+
+func (x JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobStatus.Descriptor instead.
+func (JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+// Why stock is being corrected.
+type AdjustmentReason int32
+
+const (
+	AdjustmentReason_ADJUSTMENT_REASON_UNSPECIFIED AdjustmentReason = 0
+	AdjustmentReason_ADJUSTMENT_REASON_CYCLE_COUNT AdjustmentReason = 1 // set the sku to counted_quantity
+	AdjustmentReason_ADJUSTMENT_REASON_DAMAGE      AdjustmentReason = 2 // delta < 0
+	AdjustmentReason_ADJUSTMENT_REASON_THEFT       AdjustmentReason = 3 // delta < 0
+	AdjustmentReason_ADJUSTMENT_REASON_FOUND       AdjustmentReason = 4 // delta > 0
+)
+
+// Enum value maps for AdjustmentReason.
+var (
+	AdjustmentReason_name = map[int32]string{
+		0: "ADJUSTMENT_REASON_UNSPECIFIED",
+		1: "ADJUSTMENT_REASON_CYCLE_COUNT",
+		2: "ADJUSTMENT_REASON_DAMAGE",
+		3: "ADJUSTMENT_REASON_THEFT",
+		4: "ADJUSTMENT_REASON_FOUND",
+	}
+	AdjustmentReason_value = map[string]int32{
+		"ADJUSTMENT_REASON_UNSPECIFIED": 0,
+		"ADJUSTMENT_REASON_CYCLE_COUNT": 1,
+		"ADJUSTMENT_REASON_DAMAGE":      2,
+		"ADJUSTMENT_REASON_THEFT":       3,
+		"ADJUSTMENT_REASON_FOUND":       4,
+	}
+)
+
+func (x AdjustmentReason) Enum() *AdjustmentReason {
+	p := new(AdjustmentReason)
+	*p = x
+	return p
+}
+
+func (x AdjustmentReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdjustmentReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_proto_inventory_proto_enumTypes[2].Descriptor()
+}
+
+func (AdjustmentReason) Type() protoreflect.EnumType {
+	return &file_inventory_proto_inventory_proto_enumTypes[2]
+}
+
+func (x AdjustmentReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdjustmentReason.Descriptor instead.
+func (AdjustmentReason) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{2}
+}
+
 type CheckAvailabilityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Skus          []string               `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
@@ -178,28 +340,32 @@ func (x *StockLevel) GetQuantityAvailable() int32 {
 	return 0
 }
 
-type ReserveItemsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         map[string]int32       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ListStockRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AisleType          string                 `protobuf:"bytes,1,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"` // empty = every aisle
+	InStockOnly        bool                   `protobuf:"varint,2,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"`
+	ExpiringWithinDays int32                  `protobuf:"varint,3,opt,name=expiring_within_days,json=expiringWithinDays,proto3" json:"expiring_within_days,omitempty"` // > 0: only skus whose next lot expires within this many days
+	NamePrefix         string                 `protobuf:"bytes,4,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`                            // case-insensitive
+	PageSize           int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                                 // default 50, max 200
+	PageToken          string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                               // next_page_token of the previous page
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *ReserveItemsRequest) Reset() {
-	*x = ReserveItemsRequest{}
+func (x *ListStockRequest) Reset() {
+	*x = ListStockRequest{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveItemsRequest) String() string {
+func (x *ListStockRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveItemsRequest) ProtoMessage() {}
+func (*ListStockRequest) ProtoMessage() {}
 
-func (x *ReserveItemsRequest) ProtoReflect() protoreflect.Message {
+func (x *ListStockRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -211,48 +377,80 @@ func (x *ReserveItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveItemsRequest.ProtoReflect.Descriptor instead.
-func (*ReserveItemsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListStockRequest.ProtoReflect.Descriptor instead.
+func (*ListStockRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ReserveItemsRequest) GetOrderId() string {
+func (x *ListStockRequest) GetAisleType() string {
 	if x != nil {
-		return x.OrderId
+		return x.AisleType
 	}
 	return ""
 }
 
-func (x *ReserveItemsRequest) GetItems() map[string]int32 {
+func (x *ListStockRequest) GetInStockOnly() bool {
 	if x != nil {
-		return x.Items
+		return x.InStockOnly
 	}
-	return nil
+	return false
 }
 
-type ReserveItemsResponse struct {
+func (x *ListStockRequest) GetExpiringWithinDays() int32 {
+	if x != nil {
+		return x.ExpiringWithinDays
+	}
+	return 0
+}
+
+func (x *ListStockRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListStockRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStockRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type CatalogItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AisleType     string                 `protobuf:"bytes,3,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExpiryDate    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"` // next lot to expire; unset when unknown
+	UnitPrice     float64                `protobuf:"fixed64,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Priced        bool                   `protobuf:"varint,7,opt,name=priced,proto3" json:"priced,omitempty"` // false when pricing has no price for the sku or could not be reached
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReserveItemsResponse) Reset() {
-	*x = ReserveItemsResponse{}
+func (x *CatalogItem) Reset() {
+	*x = CatalogItem{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReserveItemsResponse) String() string {
+func (x *CatalogItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReserveItemsResponse) ProtoMessage() {}
+func (*CatalogItem) ProtoMessage() {}
 
-func (x *ReserveItemsResponse) ProtoReflect() protoreflect.Message {
+func (x *CatalogItem) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -264,54 +462,82 @@ func (x *ReserveItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReserveItemsResponse.ProtoReflect.Descriptor instead.
-func (*ReserveItemsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CatalogItem.ProtoReflect.Descriptor instead.
+func (*CatalogItem) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ReserveItemsResponse) GetOrderId() string {
+func (x *CatalogItem) GetSku() string {
 	if x != nil {
-		return x.OrderId
+		return x.Sku
 	}
 	return ""
 }
 
-func (x *ReserveItemsResponse) GetSuccess() bool {
+func (x *CatalogItem) GetName() string {
 	if x != nil {
-		return x.Success
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-func (x *ReserveItemsResponse) GetErrorMessage() string {
+func (x *CatalogItem) GetAisleType() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.AisleType
 	}
 	return ""
 }
 
-type ReleaseItemsRequest struct {
+func (x *CatalogItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+func (x *CatalogItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *CatalogItem) GetPriced() bool {
+	if x != nil {
+		return x.Priced
+	}
+	return false
+}
+
+type ListStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         map[string]int32       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Items         []*CatalogItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                                        // sorted by sku
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseItemsRequest) Reset() {
-	*x = ReleaseItemsRequest{}
+func (x *ListStockResponse) Reset() {
+	*x = ListStockResponse{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseItemsRequest) String() string {
+func (x *ListStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseItemsRequest) ProtoMessage() {}
+func (*ListStockResponse) ProtoMessage() {}
 
-func (x *ReleaseItemsRequest) ProtoReflect() protoreflect.Message {
+func (x *ListStockResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -323,46 +549,49 @@ func (x *ReleaseItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseItemsRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseItemsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListStockResponse.ProtoReflect.Descriptor instead.
+func (*ListStockResponse) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ReleaseItemsRequest) GetOrderId() string {
+func (x *ListStockResponse) GetItems() []*CatalogItem {
 	if x != nil {
-		return x.OrderId
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *ReleaseItemsRequest) GetItems() map[string]int32 {
+func (x *ListStockResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Items
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-type ReleaseItemsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+type ReserveItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reservations are keyed by order_id; repeating a reserve returns the first result.
+	OrderId       string           `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         map[string]int32 `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Mode          ReserveMode      `protobuf:"varint,3,opt,name=mode,proto3,enum=inventory.ReserveMode" json:"mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseItemsResponse) Reset() {
-	*x = ReleaseItemsResponse{}
+func (x *ReserveItemsRequest) Reset() {
+	*x = ReserveItemsRequest{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseItemsResponse) String() string {
+func (x *ReserveItemsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseItemsResponse) ProtoMessage() {}
+func (*ReserveItemsRequest) ProtoMessage() {}
 
-func (x *ReleaseItemsResponse) ProtoReflect() protoreflect.Message {
+func (x *ReserveItemsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -374,40 +603,60 @@ func (x *ReleaseItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseItemsResponse.ProtoReflect.Descriptor instead.
-func (*ReleaseItemsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveItemsRequest) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ReleaseItemsResponse) GetSuccess() bool {
+func (x *ReserveItemsRequest) GetOrderId() string {
 	if x != nil {
-		return x.Success
+		return x.OrderId
 	}
-	return false
+	return ""
 }
 
-type ProcessCustomerOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         map[string]int32       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+func (x *ReserveItemsRequest) GetItems() map[string]int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveItemsRequest) GetMode() ReserveMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ReserveMode_RESERVE_MODE_UNSPECIFIED
+}
+
+// Per-sku outcome of a reserve.
+type ReserveLine struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Sku       string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Requested int32                  `protobuf:"varint,2,opt,name=requested,proto3" json:"requested,omitempty"`
+	Available int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"` // sellable stock when the reserve ran
+	Reserved  int32                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Shortfall int32                  `protobuf:"varint,5,opt,name=shortfall,proto3" json:"shortfall,omitempty"` // requested units the stock could not cover
+	// In-stock items from the same aisle_type, best match first; only set when shortfall > 0.
+	Substitutes   []*Substitute `protobuf:"bytes,6,rep,name=substitutes,proto3" json:"substitutes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProcessCustomerOrderRequest) Reset() {
-	*x = ProcessCustomerOrderRequest{}
+func (x *ReserveLine) Reset() {
+	*x = ReserveLine{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProcessCustomerOrderRequest) String() string {
+func (x *ReserveLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessCustomerOrderRequest) ProtoMessage() {}
+func (*ReserveLine) ProtoMessage() {}
 
-func (x *ProcessCustomerOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *ReserveLine) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,47 +668,80 @@ func (x *ProcessCustomerOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessCustomerOrderRequest.ProtoReflect.Descriptor instead.
-func (*ProcessCustomerOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReserveLine.ProtoReflect.Descriptor instead.
+func (*ReserveLine) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ProcessCustomerOrderRequest) GetOrderId() string {
+func (x *ReserveLine) GetSku() string {
 	if x != nil {
-		return x.OrderId
+		return x.Sku
 	}
 	return ""
 }
 
-func (x *ProcessCustomerOrderRequest) GetItems() map[string]int32 {
+func (x *ReserveLine) GetRequested() int32 {
 	if x != nil {
-		return x.Items
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *ReserveLine) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *ReserveLine) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *ReserveLine) GetShortfall() int32 {
+	if x != nil {
+		return x.Shortfall
+	}
+	return 0
+}
+
+func (x *ReserveLine) GetSubstitutes() []*Substitute {
+	if x != nil {
+		return x.Substitutes
 	}
 	return nil
 }
 
-type ProcessCustomerOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// A replacement suggestion for a short sku.
+type Substitute struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Sku               string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Available         int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	UnitPrice         float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`                        // current pricing price; unit cost when pricing is unavailable
+	Similarity        float64                `protobuf:"fixed64,5,opt,name=similarity,proto3" json:"similarity,omitempty"`                                       // 0..1 name/sku token overlap with the short sku
+	SuggestedQuantity int32                  `protobuf:"varint,6,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"` // min(shortfall, available): what to request on retry
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ProcessCustomerOrderResponse) Reset() {
-	*x = ProcessCustomerOrderResponse{}
+func (x *Substitute) Reset() {
+	*x = Substitute{}
 	mi := &file_inventory_proto_inventory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProcessCustomerOrderResponse) String() string {
+func (x *Substitute) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessCustomerOrderResponse) ProtoMessage() {}
+func (*Substitute) ProtoMessage() {}
 
-func (x *ProcessCustomerOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *Substitute) ProtoReflect() protoreflect.Message {
 	mi := &file_inventory_proto_inventory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,48 +753,2155 @@ func (x *ProcessCustomerOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessCustomerOrderResponse.ProtoReflect.Descriptor instead.
-func (*ProcessCustomerOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Substitute.ProtoReflect.Descriptor instead.
+func (*Substitute) Descriptor() ([]byte, []int) {
 	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ProcessCustomerOrderResponse) GetSuccess() bool {
+func (x *Substitute) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Substitute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Substitute) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Substitute) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *Substitute) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *Substitute) GetSuggestedQuantity() int32 {
+	if x != nil {
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+type ReserveItemsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	OrderId      string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Success      bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// When the hold is released unless ProcessCustomerOrder commits it first.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Lines     []*ReserveLine         `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"` // sorted by sku
+	// Prices locked for the reserved quantities; unset when pricing could not quote.
+	Quote         *PriceQuote `protobuf:"bytes,6,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveItemsResponse) Reset() {
+	*x = ReserveItemsResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveItemsResponse) ProtoMessage() {}
+
+func (x *ReserveItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReserveItemsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReserveItemsResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveItemsResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ProcessCustomerOrderResponse) GetMessage() string {
+func (x *ReserveItemsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ReserveItemsResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ReserveItemsResponse) GetLines() []*ReserveLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReserveItemsResponse) GetQuote() *PriceQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// PriceQuote is a pricing quote the order is billed at once it is confirmed.
+type PriceQuote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Lines         []*QuotedLine          `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"` // sorted by sku
+	Total         float64                `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceQuote) Reset() {
+	*x = PriceQuote{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceQuote) ProtoMessage() {}
+
+func (x *PriceQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceQuote.ProtoReflect.Descriptor instead.
+func (*PriceQuote) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PriceQuote) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *PriceQuote) GetLines() []*QuotedLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *PriceQuote) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PriceQuote) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type QuotedLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotedLine) Reset() {
+	*x = QuotedLine{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotedLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotedLine) ProtoMessage() {}
+
+func (x *QuotedLine) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotedLine.ProtoReflect.Descriptor instead.
+func (*QuotedLine) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuotedLine) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *QuotedLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *QuotedLine) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *QuotedLine) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+type ReleaseItemsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Ignored: inventory releases exactly what the order holds.
+	Items         map[string]int32 `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseItemsRequest) Reset() {
+	*x = ReleaseItemsRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseItemsRequest) ProtoMessage() {}
+
+func (x *ReleaseItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseItemsRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReleaseItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReleaseItemsRequest) GetItems() map[string]int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReleaseItemsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Units returned to stock per sku; empty when the order held nothing.
+	Released      map[string]int32 `protobuf:"bytes,2,rep,name=released,proto3" json:"released,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseItemsResponse) Reset() {
+	*x = ReleaseItemsResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseItemsResponse) ProtoMessage() {}
+
+func (x *ReleaseItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseItemsResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReleaseItemsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseItemsResponse) GetReleased() map[string]int32 {
+	if x != nil {
+		return x.Released
+	}
+	return nil
+}
+
+type ProcessCustomerOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         map[string]int32       `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	QuoteId       string                 `protobuf:"bytes,3,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"` // quote from ReserveItems; the order is billed at its prices
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessCustomerOrderRequest) Reset() {
+	*x = ProcessCustomerOrderRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessCustomerOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessCustomerOrderRequest) ProtoMessage() {}
+
+func (x *ProcessCustomerOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessCustomerOrderRequest.ProtoReflect.Descriptor instead.
+func (*ProcessCustomerOrderRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProcessCustomerOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ProcessCustomerOrderRequest) GetItems() map[string]int32 {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ProcessCustomerOrderRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type ProcessCustomerOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessCustomerOrderResponse) Reset() {
+	*x = ProcessCustomerOrderResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessCustomerOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessCustomerOrderResponse) ProtoMessage() {}
+
+func (x *ProcessCustomerOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessCustomerOrderResponse.ProtoReflect.Descriptor instead.
+func (*ProcessCustomerOrderResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProcessCustomerOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ProcessCustomerOrderResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RestockItemsOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*RestockItem         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItemsOrderRequest) Reset() {
+	*x = RestockItemsOrderRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItemsOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItemsOrderRequest) ProtoMessage() {}
+
+func (x *RestockItemsOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItemsOrderRequest.ProtoReflect.Descriptor instead.
+func (*RestockItemsOrderRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestockItemsOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RestockItemsOrderRequest) GetItems() []*RestockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RestockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AisleType     string                 `protobuf:"bytes,3,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitCost      float64                `protobuf:"fixed64,5,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
+	MfdDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=mfd_date,json=mfdDate,proto3" json:"mfd_date,omitempty"`
+	ExpiryDate    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItem) Reset() {
+	*x = RestockItem{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItem) ProtoMessage() {}
+
+func (x *RestockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItem.ProtoReflect.Descriptor instead.
+func (*RestockItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestockItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *RestockItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RestockItem) GetAisleType() string {
+	if x != nil {
+		return x.AisleType
+	}
+	return ""
+}
+
+func (x *RestockItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RestockItem) GetUnitCost() float64 {
+	if x != nil {
+		return x.UnitCost
+	}
+	return 0
+}
+
+func (x *RestockItem) GetMfdDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MfdDate
+	}
+	return nil
+}
+
+func (x *RestockItem) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+type RestockItemsOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItemsOrderResponse) Reset() {
+	*x = RestockItemsOrderResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItemsOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItemsOrderResponse) ProtoMessage() {}
+
+func (x *RestockItemsOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItemsOrderResponse.ProtoReflect.Descriptor instead.
+func (*RestockItemsOrderResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestockItemsOrderResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ReportJobStatusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Reporting robot; repeat reports from the same robot for an order are acknowledged but not counted.
+	RobotId string `protobuf:"bytes,2,opt,name=robot_id,json=robotId,proto3" json:"robot_id,omitempty"`
+	// Legacy free-form status ("SUCCESS", "NO_OP", "FAILED", "PARTIAL"); used only when job_status is unset.
+	Status         string           `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ProcessedItems map[string]int32 `protobuf:"bytes,4,rep,name=processed_items,json=processedItems,proto3" json:"processed_items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// 🆕 ADD THIS FIELD
+	OrderType string `protobuf:"bytes,5,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	// Aisle served by the reporting robot; reports from aisles the order does not need are ignored.
+	Aisle     string    `protobuf:"bytes,6,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	JobStatus JobStatus `protobuf:"varint,7,opt,name=job_status,json=jobStatus,proto3,enum=inventory.JobStatus" json:"job_status,omitempty"`
+	// OrderBroadcast.retry of the task being reported; stale FAILED reports for earlier retries are ignored.
+	Retry         int32 `protobuf:"varint,8,opt,name=retry,proto3" json:"retry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportJobStatusRequest) Reset() {
+	*x = ReportJobStatusRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportJobStatusRequest) ProtoMessage() {}
+
+func (x *ReportJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*ReportJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReportJobStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReportJobStatusRequest) GetRobotId() string {
+	if x != nil {
+		return x.RobotId
+	}
+	return ""
+}
+
+func (x *ReportJobStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReportJobStatusRequest) GetProcessedItems() map[string]int32 {
+	if x != nil {
+		return x.ProcessedItems
+	}
+	return nil
+}
+
+func (x *ReportJobStatusRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *ReportJobStatusRequest) GetAisle() string {
+	if x != nil {
+		return x.Aisle
+	}
+	return ""
+}
+
+func (x *ReportJobStatusRequest) GetJobStatus() JobStatus {
+	if x != nil {
+		return x.JobStatus
+	}
+	return JobStatus_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *ReportJobStatusRequest) GetRetry() int32 {
+	if x != nil {
+		return x.Retry
+	}
+	return 0
+}
+
+type ReportJobStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportJobStatusResponse) Reset() {
+	*x = ReportJobStatusResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportJobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportJobStatusResponse) ProtoMessage() {}
+
+func (x *ReportJobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportJobStatusResponse.ProtoReflect.Descriptor instead.
+func (*ReportJobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReportJobStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type WatchOrderProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderType     string                 `protobuf:"bytes,2,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"` // CUSTOMER (default) or RESTOCK
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderProgressRequest) Reset() {
+	*x = WatchOrderProgressRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderProgressRequest) ProtoMessage() {}
+
+func (x *WatchOrderProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderProgressRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchOrderProgressRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WatchOrderProgressRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+// One fulfillment step of an order. The stream opens with a SNAPSHOT and ends after FINALIZED.
+type OrderProgressEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderType       string                 `protobuf:"bytes,2,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	EventType       string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // SNAPSHOT, ROBOT_REPORT, BILLED, FINALIZED
+	Aisle           string                 `protobuf:"bytes,4,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	RobotId         string                 `protobuf:"bytes,5,opt,name=robot_id,json=robotId,proto3" json:"robot_id,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // robot status for ROBOT_REPORT, order status for FINALIZED
+	ProcessedItems  map[string]int32       `protobuf:"bytes,7,rep,name=processed_items,json=processedItems,proto3" json:"processed_items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ReportsReceived int32                  `protobuf:"varint,8,opt,name=reports_received,json=reportsReceived,proto3" json:"reports_received,omitempty"`
+	ReportsExpected int32                  `protobuf:"varint,9,opt,name=reports_expected,json=reportsExpected,proto3" json:"reports_expected,omitempty"`
+	Amount          float64                `protobuf:"fixed64,10,opt,name=amount,proto3" json:"amount,omitempty"` // bill total for BILLED, final total_price/total_cost for FINALIZED
+	Reason          string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`   // set when FINALIZED with FAILED
+	At              *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=at,proto3" json:"at,omitempty"`
+	ReasonCode      string                 `protobuf:"bytes,13,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"` // e.g. ROBOT_FAILED, SLA_TIMEOUT
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OrderProgressEvent) Reset() {
+	*x = OrderProgressEvent{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderProgressEvent) ProtoMessage() {}
+
+func (x *OrderProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderProgressEvent.ProtoReflect.Descriptor instead.
+func (*OrderProgressEvent) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrderProgressEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetAisle() string {
+	if x != nil {
+		return x.Aisle
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetRobotId() string {
+	if x != nil {
+		return x.RobotId
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetProcessedItems() map[string]int32 {
+	if x != nil {
+		return x.ProcessedItems
+	}
+	return nil
+}
+
+func (x *OrderProgressEvent) GetReportsReceived() int32 {
+	if x != nil {
+		return x.ReportsReceived
+	}
+	return 0
+}
+
+func (x *OrderProgressEvent) GetReportsExpected() int32 {
+	if x != nil {
+		return x.ReportsExpected
+	}
+	return 0
+}
+
+func (x *OrderProgressEvent) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OrderProgressEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderProgressEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *OrderProgressEvent) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+type RegisterRobotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RobotId       string                 `protobuf:"bytes,1,opt,name=robot_id,json=robotId,proto3" json:"robot_id,omitempty"`
+	Aisle         string                 `protobuf:"bytes,2,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRobotRequest) Reset() {
+	*x = RegisterRobotRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRobotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRobotRequest) ProtoMessage() {}
+
+func (x *RegisterRobotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRobotRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRobotRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RegisterRobotRequest) GetRobotId() string {
+	if x != nil {
+		return x.RobotId
+	}
+	return ""
+}
+
+func (x *RegisterRobotRequest) GetAisle() string {
+	if x != nil {
+		return x.Aisle
+	}
+	return ""
+}
+
+type RegisterRobotResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Success                  bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	HeartbeatIntervalSeconds int32                  `protobuf:"varint,2,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *RegisterRobotResponse) Reset() {
+	*x = RegisterRobotResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRobotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRobotResponse) ProtoMessage() {}
+
+func (x *RegisterRobotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRobotResponse.ProtoReflect.Descriptor instead.
+func (*RegisterRobotResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RegisterRobotResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterRobotResponse) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+type RobotHeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RobotId       string                 `protobuf:"bytes,1,opt,name=robot_id,json=robotId,proto3" json:"robot_id,omitempty"`
+	Aisle         string                 `protobuf:"bytes,2,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RobotHeartbeatRequest) Reset() {
+	*x = RobotHeartbeatRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RobotHeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RobotHeartbeatRequest) ProtoMessage() {}
+
+func (x *RobotHeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RobotHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*RobotHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RobotHeartbeatRequest) GetRobotId() string {
+	if x != nil {
+		return x.RobotId
+	}
+	return ""
+}
+
+func (x *RobotHeartbeatRequest) GetAisle() string {
+	if x != nil {
+		return x.Aisle
+	}
+	return ""
+}
+
+type RobotHeartbeatResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// False when the registry no longer knows the robot and it should register again.
+	Registered    bool `protobuf:"varint,2,opt,name=registered,proto3" json:"registered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RobotHeartbeatResponse) Reset() {
+	*x = RobotHeartbeatResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RobotHeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RobotHeartbeatResponse) ProtoMessage() {}
+
+func (x *RobotHeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RobotHeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*RobotHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RobotHeartbeatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RobotHeartbeatResponse) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+// Sent by a robot as soon as it receives an aisle task so inventory stops re-broadcasting it.
+type AcknowledgeDispatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderType     string                 `protobuf:"bytes,2,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	Aisle         string                 `protobuf:"bytes,3,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	RobotId       string                 `protobuf:"bytes,4,opt,name=robot_id,json=robotId,proto3" json:"robot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeDispatchRequest) Reset() {
+	*x = AcknowledgeDispatchRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeDispatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeDispatchRequest) ProtoMessage() {}
+
+func (x *AcknowledgeDispatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeDispatchRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeDispatchRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AcknowledgeDispatchRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AcknowledgeDispatchRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *AcknowledgeDispatchRequest) GetAisle() string {
+	if x != nil {
+		return x.Aisle
+	}
+	return ""
+}
+
+func (x *AcknowledgeDispatchRequest) GetRobotId() string {
+	if x != nil {
+		return x.RobotId
+	}
+	return ""
+}
+
+type AcknowledgeDispatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeDispatchResponse) Reset() {
+	*x = AcknowledgeDispatchResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeDispatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeDispatchResponse) ProtoMessage() {}
+
+func (x *AcknowledgeDispatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeDispatchResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeDispatchResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AcknowledgeDispatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type WebhookOutboxEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderType     string                 `protobuf:"bytes,3,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"` // CUSTOMER or RESTOCK
+	TargetUrl     string                 `protobuf:"bytes,4,opt,name=target_url,json=targetUrl,proto3" json:"target_url,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // PENDING or DEAD
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookOutboxEntry) Reset() {
+	*x = WebhookOutboxEntry{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookOutboxEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookOutboxEntry) ProtoMessage() {}
+
+func (x *WebhookOutboxEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookOutboxEntry.ProtoReflect.Descriptor instead.
+func (*WebhookOutboxEntry) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WebhookOutboxEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookOutboxEntry) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetTargetUrl() string {
+	if x != nil {
+		return x.TargetUrl
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookOutboxEntry) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookOutboxEntry) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookOutboxEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListUndeliveredWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderType     string                 `protobuf:"bytes,1,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"` // CUSTOMER, RESTOCK, or empty for both
+	Statuses      []string               `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`                    // PENDING and/or DEAD; empty means both
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // defaults to 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUndeliveredWebhooksRequest) Reset() {
+	*x = ListUndeliveredWebhooksRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUndeliveredWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUndeliveredWebhooksRequest) ProtoMessage() {}
+
+func (x *ListUndeliveredWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUndeliveredWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListUndeliveredWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUndeliveredWebhooksRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+func (x *ListUndeliveredWebhooksRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListUndeliveredWebhooksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListUndeliveredWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*WebhookOutboxEntry  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUndeliveredWebhooksResponse) Reset() {
+	*x = ListUndeliveredWebhooksResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUndeliveredWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUndeliveredWebhooksResponse) ProtoMessage() {}
+
+func (x *ListUndeliveredWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUndeliveredWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListUndeliveredWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListUndeliveredWebhooksResponse) GetEntries() []*WebhookOutboxEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ReplayWebhooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries to replay; when empty every DEAD entry matching order_type is replayed.
+	Ids           []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	OrderType     string  `protobuf:"bytes,2,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhooksRequest) Reset() {
+	*x = ReplayWebhooksRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhooksRequest) ProtoMessage() {}
+
+func (x *ReplayWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReplayWebhooksRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReplayWebhooksRequest) GetOrderType() string {
+	if x != nil {
+		return x.OrderType
+	}
+	return ""
+}
+
+type ReplayWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Replayed      int32                  `protobuf:"varint,2,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhooksResponse) Reset() {
+	*x = ReplayWebhooksResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhooksResponse) ProtoMessage() {}
+
+func (x *ReplayWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReplayWebhooksResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReplayWebhooksResponse) GetReplayed() int32 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+type GetWasteSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                            // inclusive; defaults to 30 days before to
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                // exclusive; defaults to now
+	AisleType     string                 `protobuf:"bytes,3,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"` // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWasteSummaryRequest) Reset() {
+	*x = GetWasteSummaryRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWasteSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWasteSummaryRequest) ProtoMessage() {}
+
+func (x *GetWasteSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWasteSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetWasteSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetWasteSummaryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetWasteSummaryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetWasteSummaryRequest) GetAisleType() string {
+	if x != nil {
+		return x.AisleType
+	}
+	return ""
+}
+
+// Expired stock written off on one day (UTC) in one aisle.
+type WasteTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"` // YYYY-MM-DD
+	AisleType     string                 `protobuf:"bytes,2,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TotalCost     float64                `protobuf:"fixed64,4,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"` // quantity * lot unit_cost
+	Lots          int32                  `protobuf:"varint,5,opt,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WasteTotal) Reset() {
+	*x = WasteTotal{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WasteTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WasteTotal) ProtoMessage() {}
+
+func (x *WasteTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WasteTotal.ProtoReflect.Descriptor instead.
+func (*WasteTotal) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WasteTotal) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *WasteTotal) GetAisleType() string {
+	if x != nil {
+		return x.AisleType
+	}
+	return ""
+}
+
+func (x *WasteTotal) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *WasteTotal) GetTotalCost() float64 {
+	if x != nil {
+		return x.TotalCost
+	}
+	return 0
+}
+
+func (x *WasteTotal) GetLots() int32 {
+	if x != nil {
+		return x.Lots
+	}
+	return 0
+}
+
+type GetWasteSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Totals        []*WasteTotal          `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty"`
+	TotalQuantity int32                  `protobuf:"varint,2,opt,name=total_quantity,json=totalQuantity,proto3" json:"total_quantity,omitempty"`
+	TotalCost     float64                `protobuf:"fixed64,3,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWasteSummaryResponse) Reset() {
+	*x = GetWasteSummaryResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWasteSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWasteSummaryResponse) ProtoMessage() {}
+
+func (x *GetWasteSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWasteSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetWasteSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetWasteSummaryResponse) GetTotals() []*WasteTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *GetWasteSummaryResponse) GetTotalQuantity() int32 {
+	if x != nil {
+		return x.TotalQuantity
+	}
+	return 0
+}
+
+func (x *GetWasteSummaryResponse) GetTotalCost() float64 {
+	if x != nil {
+		return x.TotalCost
+	}
+	return 0
+}
+
+type GetStockHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`      // required
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`    // inclusive; defaults to 30 days before to
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`        // exclusive; defaults to now
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // defaults to 500, capped at 5000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockHistoryRequest) Reset() {
+	*x = GetStockHistoryRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockHistoryRequest) ProtoMessage() {}
+
+func (x *GetStockHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetStockHistoryRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *GetStockHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetStockHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetStockHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// One change to a sku's available quantity.
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // RESERVE, RELEASE, RESTOCK, EXPIRY_WRITEOFF, OPENING_BALANCE
+	OrderId       string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	BalanceAfter  int32                  `protobuf:"varint,6,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StockMovement) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockMovement) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetBalanceAfter() int32 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *StockMovement) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type GetStockHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockHistoryResponse) Reset() {
+	*x = GetStockHistoryResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockHistoryResponse) ProtoMessage() {}
+
+func (x *GetStockHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetStockHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetStockHistoryResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+type SetReorderPointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	ReorderPoint  int32                  `protobuf:"varint,2,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"` // 0 turns low-stock alerts off
+	TargetLevel   int32                  `protobuf:"varint,3,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`    // stock level a restock should bring the sku back to; >= reorder_point
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReorderPointRequest) Reset() {
+	*x = SetReorderPointRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReorderPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReorderPointRequest) ProtoMessage() {}
+
+func (x *SetReorderPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReorderPointRequest.ProtoReflect.Descriptor instead.
+func (*SetReorderPointRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetReorderPointRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SetReorderPointRequest) GetReorderPoint() int32 {
+	if x != nil {
+		return x.ReorderPoint
+	}
+	return 0
+}
+
+func (x *SetReorderPointRequest) GetTargetLevel() int32 {
 	if x != nil {
-		return x.Message
+		return x.TargetLevel
 	}
-	return ""
+	return 0
 }
 
-type RestockItemsOrderRequest struct {
+type SetReorderPointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         []*RestockItem         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestockItemsOrderRequest) Reset() {
-	*x = RestockItemsOrderRequest{}
-	mi := &file_inventory_proto_inventory_proto_msgTypes[9]
+func (x *SetReorderPointResponse) Reset() {
+	*x = SetReorderPointResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestockItemsOrderRequest) String() string {
+func (x *SetReorderPointResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestockItemsOrderRequest) ProtoMessage() {}
+func (*SetReorderPointResponse) ProtoMessage() {}
 
-func (x *RestockItemsOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_inventory_proto_msgTypes[9]
+func (x *SetReorderPointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,53 +2912,83 @@ func (x *RestockItemsOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestockItemsOrderRequest.ProtoReflect.Descriptor instead.
-func (*RestockItemsOrderRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SetReorderPointResponse.ProtoReflect.Descriptor instead.
+func (*SetReorderPointResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *RestockItemsOrderRequest) GetOrderId() string {
+func (x *SetReorderPointResponse) GetSuccess() bool {
 	if x != nil {
-		return x.OrderId
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *RestockItemsOrderRequest) GetItems() []*RestockItem {
+type ListLowStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowStockRequest) Reset() {
+	*x = ListLowStockRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowStockRequest) ProtoMessage() {}
+
+func (x *ListLowStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[42]
 	if x != nil {
-		return x.Items
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type RestockItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	AisleType     string                 `protobuf:"bytes,3,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	UnitCost      float64                `protobuf:"fixed64,5,opt,name=unit_cost,json=unitCost,proto3" json:"unit_cost,omitempty"`
-	MfdDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=mfd_date,json=mfdDate,proto3" json:"mfd_date,omitempty"`
-	ExpiryDate    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Deprecated: Use ListLowStockRequest.ProtoReflect.Descriptor instead.
+func (*ListLowStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *RestockItem) Reset() {
-	*x = RestockItem{}
-	mi := &file_inventory_proto_inventory_proto_msgTypes[10]
+type LowStockItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Sku               string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AisleType         string                 `protobuf:"bytes,3,opt,name=aisle_type,json=aisleType,proto3" json:"aisle_type,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReorderPoint      int32                  `protobuf:"varint,5,opt,name=reorder_point,json=reorderPoint,proto3" json:"reorder_point,omitempty"`
+	TargetLevel       int32                  `protobuf:"varint,6,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`
+	SuggestedQuantity int32                  `protobuf:"varint,7,opt,name=suggested_quantity,json=suggestedQuantity,proto3" json:"suggested_quantity,omitempty"` // target_level - quantity
+	LastAlertAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_alert_at,json=lastAlertAt,proto3" json:"last_alert_at,omitempty"`                  // unset when no reserve has alerted yet
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *LowStockItem) Reset() {
+	*x = LowStockItem{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestockItem) String() string {
+func (x *LowStockItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestockItem) ProtoMessage() {}
+func (*LowStockItem) ProtoMessage() {}
 
-func (x *RestockItem) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_inventory_proto_msgTypes[10]
+func (x *LowStockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,82 +2999,89 @@ func (x *RestockItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestockItem.ProtoReflect.Descriptor instead.
-func (*RestockItem) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use LowStockItem.ProtoReflect.Descriptor instead.
+func (*LowStockItem) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *RestockItem) GetSku() string {
+func (x *LowStockItem) GetSku() string {
 	if x != nil {
 		return x.Sku
 	}
 	return ""
 }
 
-func (x *RestockItem) GetName() string {
+func (x *LowStockItem) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *RestockItem) GetAisleType() string {
+func (x *LowStockItem) GetAisleType() string {
 	if x != nil {
 		return x.AisleType
 	}
 	return ""
 }
 
-func (x *RestockItem) GetQuantity() int32 {
+func (x *LowStockItem) GetQuantity() int32 {
 	if x != nil {
 		return x.Quantity
 	}
 	return 0
 }
 
-func (x *RestockItem) GetUnitCost() float64 {
+func (x *LowStockItem) GetReorderPoint() int32 {
 	if x != nil {
-		return x.UnitCost
+		return x.ReorderPoint
 	}
 	return 0
 }
 
-func (x *RestockItem) GetMfdDate() *timestamppb.Timestamp {
+func (x *LowStockItem) GetTargetLevel() int32 {
 	if x != nil {
-		return x.MfdDate
+		return x.TargetLevel
 	}
-	return nil
+	return 0
 }
 
-func (x *RestockItem) GetExpiryDate() *timestamppb.Timestamp {
+func (x *LowStockItem) GetSuggestedQuantity() int32 {
 	if x != nil {
-		return x.ExpiryDate
+		return x.SuggestedQuantity
+	}
+	return 0
+}
+
+func (x *LowStockItem) GetLastAlertAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAlertAt
 	}
 	return nil
 }
 
-type RestockItemsOrderResponse struct {
+type ListLowStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Items         []*LowStockItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestockItemsOrderResponse) Reset() {
-	*x = RestockItemsOrderResponse{}
-	mi := &file_inventory_proto_inventory_proto_msgTypes[11]
+func (x *ListLowStockResponse) Reset() {
+	*x = ListLowStockResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestockItemsOrderResponse) String() string {
+func (x *ListLowStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestockItemsOrderResponse) ProtoMessage() {}
+func (*ListLowStockResponse) ProtoMessage() {}
 
-func (x *RestockItemsOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_inventory_proto_msgTypes[11]
+func (x *ListLowStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,45 +3092,127 @@ func (x *RestockItemsOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestockItemsOrderResponse.ProtoReflect.Descriptor instead.
-func (*RestockItemsOrderResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListLowStockResponse.ProtoReflect.Descriptor instead.
+func (*ListLowStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *RestockItemsOrderResponse) GetSuccess() bool {
+func (x *ListLowStockResponse) GetItems() []*LowStockItem {
 	if x != nil {
-		return x.Success
+		return x.Items
 	}
-	return false
+	return nil
 }
 
-type ReportJobStatusRequest struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	// string robot_id = 2; // Deprecated/Removed
-	Status         string           `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	ProcessedItems map[string]int32 `protobuf:"bytes,4,rep,name=processed_items,json=processedItems,proto3" json:"processed_items,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	// 🆕 ADD THIS FIELD
-	OrderType     string `protobuf:"bytes,5,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+type AdjustStockRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sku             string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Reason          AdjustmentReason       `protobuf:"varint,2,opt,name=reason,proto3,enum=inventory.AdjustmentReason" json:"reason,omitempty"`
+	Delta           int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`                                            // units added (> 0) or removed (< 0); ignored for CYCLE_COUNT
+	CountedQuantity int32                  `protobuf:"varint,4,opt,name=counted_quantity,json=countedQuantity,proto3" json:"counted_quantity,omitempty"` // CYCLE_COUNT only
+	RequestedBy     string                 `protobuf:"bytes,5,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	Note            string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AdjustStockRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetReason() AdjustmentReason {
+	if x != nil {
+		return x.Reason
+	}
+	return AdjustmentReason_ADJUSTMENT_REASON_UNSPECIFIED
+}
+
+func (x *AdjustStockRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetCountedQuantity() int32 {
+	if x != nil {
+		return x.CountedQuantity
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdjustmentId  int64                  `protobuf:"varint,1,opt,name=adjustment_id,json=adjustmentId,proto3" json:"adjustment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // APPLIED, PENDING_APPROVAL or REJECTED
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	QuantityAfter int32                  `protobuf:"varint,4,opt,name=quantity_after,json=quantityAfter,proto3" json:"quantity_after,omitempty"` // set when APPLIED
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportJobStatusRequest) Reset() {
-	*x = ReportJobStatusRequest{}
-	mi := &file_inventory_proto_inventory_proto_msgTypes[12]
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportJobStatusRequest) String() string {
+func (x *AdjustStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportJobStatusRequest) ProtoMessage() {}
+func (*AdjustStockResponse) ProtoMessage() {}
 
-func (x *ReportJobStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_inventory_proto_msgTypes[12]
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -715,61 +3223,63 @@ func (x *ReportJobStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportJobStatusRequest.ProtoReflect.Descriptor instead.
-func (*ReportJobStatusRequest) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ReportJobStatusRequest) GetOrderId() string {
+func (x *AdjustStockResponse) GetAdjustmentId() int64 {
 	if x != nil {
-		return x.OrderId
+		return x.AdjustmentId
 	}
-	return ""
+	return 0
 }
 
-func (x *ReportJobStatusRequest) GetStatus() string {
+func (x *AdjustStockResponse) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-func (x *ReportJobStatusRequest) GetProcessedItems() map[string]int32 {
+func (x *AdjustStockResponse) GetDelta() int32 {
 	if x != nil {
-		return x.ProcessedItems
+		return x.Delta
 	}
-	return nil
+	return 0
 }
 
-func (x *ReportJobStatusRequest) GetOrderType() string {
+func (x *AdjustStockResponse) GetQuantityAfter() int32 {
 	if x != nil {
-		return x.OrderType
+		return x.QuantityAfter
 	}
-	return ""
+	return 0
 }
 
-type ReportJobStatusResponse struct {
+type DecideAdjustmentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AdjustmentId  int64                  `protobuf:"varint,1,opt,name=adjustment_id,json=adjustmentId,proto3" json:"adjustment_id,omitempty"`
+	DecidedBy     string                 `protobuf:"bytes,2,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"` // must differ from the requester
+	Approve       bool                   `protobuf:"varint,3,opt,name=approve,proto3" json:"approve,omitempty"`                     // false rejects
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportJobStatusResponse) Reset() {
-	*x = ReportJobStatusResponse{}
-	mi := &file_inventory_proto_inventory_proto_msgTypes[13]
+func (x *DecideAdjustmentRequest) Reset() {
+	*x = DecideAdjustmentRequest{}
+	mi := &file_inventory_proto_inventory_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportJobStatusResponse) String() string {
+func (x *DecideAdjustmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportJobStatusResponse) ProtoMessage() {}
+func (*DecideAdjustmentRequest) ProtoMessage() {}
 
-func (x *ReportJobStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_inventory_proto_msgTypes[13]
+func (x *DecideAdjustmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_inventory_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,14 +3290,28 @@ func (x *ReportJobStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportJobStatusResponse.ProtoReflect.Descriptor instead.
-func (*ReportJobStatusResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DecideAdjustmentRequest.ProtoReflect.Descriptor instead.
+func (*DecideAdjustmentRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_inventory_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *ReportJobStatusResponse) GetSuccess() bool {
+func (x *DecideAdjustmentRequest) GetAdjustmentId() int64 {
 	if x != nil {
-		return x.Success
+		return x.AdjustmentId
+	}
+	return 0
+}
+
+func (x *DecideAdjustmentRequest) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *DecideAdjustmentRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
 	}
 	return false
 }
@@ -811,30 +3335,97 @@ const file_inventory_proto_inventory_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
 	"aisle_type\x18\x03 \x01(\tR\taisleType\x12-\n" +
-	"\x12quantity_available\x18\x04 \x01(\x05R\x11quantityAvailable\"\xab\x01\n" +
+	"\x12quantity_available\x18\x04 \x01(\x05R\x11quantityAvailable\"\xe4\x01\n" +
+	"\x10ListStockRequest\x12\x1d\n" +
+	"\n" +
+	"aisle_type\x18\x01 \x01(\tR\taisleType\x12\"\n" +
+	"\rin_stock_only\x18\x02 \x01(\bR\vinStockOnly\x120\n" +
+	"\x14expiring_within_days\x18\x03 \x01(\x05R\x12expiringWithinDays\x12\x1f\n" +
+	"\vname_prefix\x18\x04 \x01(\tR\n" +
+	"namePrefix\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\xe2\x01\n" +
+	"\vCatalogItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"aisle_type\x18\x03 \x01(\tR\taisleType\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12;\n" +
+	"\vexpiry_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiryDate\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\x01R\tunitPrice\x12\x16\n" +
+	"\x06priced\x18\a \x01(\bR\x06priced\"i\n" +
+	"\x11ListStockResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.inventory.CatalogItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd7\x01\n" +
 	"\x13ReserveItemsRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12?\n" +
-	"\x05items\x18\x02 \x03(\v2).inventory.ReserveItemsRequest.ItemsEntryR\x05items\x1a8\n" +
+	"\x05items\x18\x02 \x03(\v2).inventory.ReserveItemsRequest.ItemsEntryR\x05items\x12*\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x16.inventory.ReserveModeR\x04mode\x1a8\n" +
 	"\n" +
 	"ItemsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"p\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xce\x01\n" +
+	"\vReserveLine\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1c\n" +
+	"\trequested\x18\x02 \x01(\x05R\trequested\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tshortfall\x18\x05 \x01(\x05R\tshortfall\x127\n" +
+	"\vsubstitutes\x18\x06 \x03(\v2\x15.inventory.SubstituteR\vsubstitutes\"\xbe\x01\n" +
+	"\n" +
+	"Substitute\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\x05R\tavailable\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x05 \x01(\x01R\n" +
+	"similarity\x12-\n" +
+	"\x12suggested_quantity\x18\x06 \x01(\x05R\x11suggestedQuantity\"\x86\x02\n" +
 	"\x14ReserveItemsResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\xab\x01\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12,\n" +
+	"\x05lines\x18\x05 \x03(\v2\x16.inventory.ReserveLineR\x05lines\x12+\n" +
+	"\x05quote\x18\x06 \x01(\v2\x15.inventory.PriceQuoteR\x05quote\"\xa5\x01\n" +
+	"\n" +
+	"PriceQuote\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12+\n" +
+	"\x05lines\x18\x02 \x03(\v2\x15.inventory.QuotedLineR\x05lines\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x01R\x05total\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"z\n" +
+	"\n" +
+	"QuotedLine\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1f\n" +
+	"\vtotal_price\x18\x04 \x01(\x01R\n" +
+	"totalPrice\"\xab\x01\n" +
 	"\x13ReleaseItemsRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12?\n" +
 	"\x05items\x18\x02 \x03(\v2).inventory.ReleaseItemsRequest.ItemsEntryR\x05items\x1a8\n" +
 	"\n" +
 	"ItemsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"0\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xb8\x01\n" +
 	"\x14ReleaseItemsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xbb\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12I\n" +
+	"\breleased\x18\x02 \x03(\v2-.inventory.ReleaseItemsResponse.ReleasedEntryR\breleased\x1a;\n" +
+	"\rReleasedEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xd6\x01\n" +
 	"\x1bProcessCustomerOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12G\n" +
-	"\x05items\x18\x02 \x03(\v21.inventory.ProcessCustomerOrderRequest.ItemsEntryR\x05items\x1a8\n" +
+	"\x05items\x18\x02 \x03(\v21.inventory.ProcessCustomerOrderRequest.ItemsEntryR\x05items\x12\x19\n" +
+	"\bquote_id\x18\x03 \x01(\tR\aquoteId\x1a8\n" +
 	"\n" +
 	"ItemsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
@@ -856,25 +3447,206 @@ const file_inventory_proto_inventory_proto_rawDesc = "" +
 	"\vexpiry_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"expiryDate\"5\n" +
 	"\x19RestockItemsOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8d\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x89\x03\n" +
 	"\x16ReportJobStatusRequest\x12\x19\n" +
-	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x19\n" +
+	"\brobot_id\x18\x02 \x01(\tR\arobotId\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12^\n" +
 	"\x0fprocessed_items\x18\x04 \x03(\v25.inventory.ReportJobStatusRequest.ProcessedItemsEntryR\x0eprocessedItems\x12\x1d\n" +
 	"\n" +
-	"order_type\x18\x05 \x01(\tR\torderType\x1aA\n" +
+	"order_type\x18\x05 \x01(\tR\torderType\x12\x14\n" +
+	"\x05aisle\x18\x06 \x01(\tR\x05aisle\x123\n" +
+	"\n" +
+	"job_status\x18\a \x01(\x0e2\x14.inventory.JobStatusR\tjobStatus\x12\x14\n" +
+	"\x05retry\x18\b \x01(\x05R\x05retry\x1aA\n" +
 	"\x13ProcessedItemsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"3\n" +
 	"\x17ReportJobStatusResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb7\x04\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"U\n" +
+	"\x19WatchOrderProgressRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\tR\torderType\"\xa8\x04\n" +
+	"\x12OrderProgressEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\tR\torderType\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x14\n" +
+	"\x05aisle\x18\x04 \x01(\tR\x05aisle\x12\x19\n" +
+	"\brobot_id\x18\x05 \x01(\tR\arobotId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12Z\n" +
+	"\x0fprocessed_items\x18\a \x03(\v21.inventory.OrderProgressEvent.ProcessedItemsEntryR\x0eprocessedItems\x12)\n" +
+	"\x10reports_received\x18\b \x01(\x05R\x0freportsReceived\x12)\n" +
+	"\x10reports_expected\x18\t \x01(\x05R\x0freportsExpected\x12\x16\n" +
+	"\x06amount\x18\n" +
+	" \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\x12*\n" +
+	"\x02at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x1f\n" +
+	"\vreason_code\x18\r \x01(\tR\n" +
+	"reasonCode\x1aA\n" +
+	"\x13ProcessedItemsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"G\n" +
+	"\x14RegisterRobotRequest\x12\x19\n" +
+	"\brobot_id\x18\x01 \x01(\tR\arobotId\x12\x14\n" +
+	"\x05aisle\x18\x02 \x01(\tR\x05aisle\"o\n" +
+	"\x15RegisterRobotResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x02 \x01(\x05R\x18heartbeatIntervalSeconds\"H\n" +
+	"\x15RobotHeartbeatRequest\x12\x19\n" +
+	"\brobot_id\x18\x01 \x01(\tR\arobotId\x12\x14\n" +
+	"\x05aisle\x18\x02 \x01(\tR\x05aisle\"R\n" +
+	"\x16RobotHeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1e\n" +
+	"\n" +
+	"registered\x18\x02 \x01(\bR\n" +
+	"registered\"\x87\x01\n" +
+	"\x1aAcknowledgeDispatchRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\tR\torderType\x12\x14\n" +
+	"\x05aisle\x18\x03 \x01(\tR\x05aisle\x12\x19\n" +
+	"\brobot_id\x18\x04 \x01(\tR\arobotId\"7\n" +
+	"\x1bAcknowledgeDispatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xe9\x02\n" +
+	"\x12WebhookOutboxEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x03 \x01(\tR\torderType\x12\x1d\n" +
+	"\n" +
+	"target_url\x18\x04 \x01(\tR\ttargetUrl\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"q\n" +
+	"\x1eListUndeliveredWebhooksRequest\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x01 \x01(\tR\torderType\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Z\n" +
+	"\x1fListUndeliveredWebhooksResponse\x127\n" +
+	"\aentries\x18\x01 \x03(\v2\x1d.inventory.WebhookOutboxEntryR\aentries\"H\n" +
+	"\x15ReplayWebhooksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x1d\n" +
+	"\n" +
+	"order_type\x18\x02 \x01(\tR\torderType\"N\n" +
+	"\x16ReplayWebhooksResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1a\n" +
+	"\breplayed\x18\x02 \x01(\x05R\breplayed\"\x93\x01\n" +
+	"\x16GetWasteSummaryRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1d\n" +
+	"\n" +
+	"aisle_type\x18\x03 \x01(\tR\taisleType\"\x8c\x01\n" +
+	"\n" +
+	"WasteTotal\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12\x1d\n" +
+	"\n" +
+	"aisle_type\x18\x02 \x01(\tR\taisleType\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"total_cost\x18\x04 \x01(\x01R\ttotalCost\x12\x12\n" +
+	"\x04lots\x18\x05 \x01(\x05R\x04lots\"\x8e\x01\n" +
+	"\x17GetWasteSummaryResponse\x12-\n" +
+	"\x06totals\x18\x01 \x03(\v2\x15.inventory.WasteTotalR\x06totals\x12%\n" +
+	"\x0etotal_quantity\x18\x02 \x01(\x05R\rtotalQuantity\x12\x1d\n" +
+	"\n" +
+	"total_cost\x18\x03 \x01(\x01R\ttotalCost\"\x9c\x01\n" +
+	"\x16GetStockHistoryRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xcb\x01\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12#\n" +
+	"\rbalance_after\x18\x06 \x01(\x05R\fbalanceAfter\x12*\n" +
+	"\x02at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"Q\n" +
+	"\x17GetStockHistoryResponse\x126\n" +
+	"\tmovements\x18\x01 \x03(\v2\x18.inventory.StockMovementR\tmovements\"r\n" +
+	"\x16SetReorderPointRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12#\n" +
+	"\rreorder_point\x18\x02 \x01(\x05R\freorderPoint\x12!\n" +
+	"\ftarget_level\x18\x03 \x01(\x05R\vtargetLevel\"3\n" +
+	"\x17SetReorderPointResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x15\n" +
+	"\x13ListLowStockRequest\"\xa6\x02\n" +
+	"\fLowStockItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"aisle_type\x18\x03 \x01(\tR\taisleType\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12#\n" +
+	"\rreorder_point\x18\x05 \x01(\x05R\freorderPoint\x12!\n" +
+	"\ftarget_level\x18\x06 \x01(\x05R\vtargetLevel\x12-\n" +
+	"\x12suggested_quantity\x18\a \x01(\x05R\x11suggestedQuantity\x12>\n" +
+	"\rlast_alert_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vlastAlertAt\"E\n" +
+	"\x14ListLowStockResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.inventory.LowStockItemR\x05items\"\xd3\x01\n" +
+	"\x12AdjustStockRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x123\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x1b.inventory.AdjustmentReasonR\x06reason\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\x12)\n" +
+	"\x10counted_quantity\x18\x04 \x01(\x05R\x0fcountedQuantity\x12!\n" +
+	"\frequested_by\x18\x05 \x01(\tR\vrequestedBy\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\"\x8f\x01\n" +
+	"\x13AdjustStockResponse\x12#\n" +
+	"\radjustment_id\x18\x01 \x01(\x03R\fadjustmentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\x12%\n" +
+	"\x0equantity_after\x18\x04 \x01(\x05R\rquantityAfter\"w\n" +
+	"\x17DecideAdjustmentRequest\x12#\n" +
+	"\radjustment_id\x18\x01 \x01(\x03R\fadjustmentId\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\x02 \x01(\tR\tdecidedBy\x12\x18\n" +
+	"\aapprove\x18\x03 \x01(\bR\aapprove*f\n" +
+	"\vReserveMode\x12\x1c\n" +
+	"\x18RESERVE_MODE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRESERVE_MODE_ALL_OR_NOTHING\x10\x01\x12\x18\n" +
+	"\x14RESERVE_MODE_PARTIAL\x10\x02*\x84\x01\n" +
+	"\tJobStatus\x12\x1a\n" +
+	"\x16JOB_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12JOB_STATUS_SUCCESS\x10\x01\x12\x14\n" +
+	"\x10JOB_STATUS_NO_OP\x10\x02\x12\x15\n" +
+	"\x11JOB_STATUS_FAILED\x10\x03\x12\x16\n" +
+	"\x12JOB_STATUS_PARTIAL\x10\x04*\xb0\x01\n" +
+	"\x10AdjustmentReason\x12!\n" +
+	"\x1dADJUSTMENT_REASON_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dADJUSTMENT_REASON_CYCLE_COUNT\x10\x01\x12\x1c\n" +
+	"\x18ADJUSTMENT_REASON_DAMAGE\x10\x02\x12\x1b\n" +
+	"\x17ADJUSTMENT_REASON_THEFT\x10\x03\x12\x1b\n" +
+	"\x17ADJUSTMENT_REASON_FOUND\x10\x042\xbb\r\n" +
 	"\x10InventoryService\x12^\n" +
 	"\x11CheckAvailability\x12#.inventory.CheckAvailabilityRequest\x1a$.inventory.CheckAvailabilityResponse\x12O\n" +
-	"\fReserveItems\x12\x1e.inventory.ReserveItemsRequest\x1a\x1f.inventory.ReserveItemsResponse\x12O\n" +
+	"\fReserveItems\x12\x1e.inventory.ReserveItemsRequest\x1a\x1f.inventory.ReserveItemsResponse\x12F\n" +
+	"\tListStock\x12\x1b.inventory.ListStockRequest\x1a\x1c.inventory.ListStockResponse\x12O\n" +
 	"\fReleaseItems\x12\x1e.inventory.ReleaseItemsRequest\x1a\x1f.inventory.ReleaseItemsResponse\x12^\n" +
 	"\x11RestockItemsOrder\x12#.inventory.RestockItemsOrderRequest\x1a$.inventory.RestockItemsOrderResponse\x12g\n" +
 	"\x14ProcessCustomerOrder\x12&.inventory.ProcessCustomerOrderRequest\x1a'.inventory.ProcessCustomerOrderResponse\x12X\n" +
-	"\x0fReportJobStatus\x12!.inventory.ReportJobStatusRequest\x1a\".inventory.ReportJobStatusResponseB*Z(auto_grocery/inventory/proto;inventorypbb\x06proto3"
+	"\x0fReportJobStatus\x12!.inventory.ReportJobStatusRequest\x1a\".inventory.ReportJobStatusResponse\x12[\n" +
+	"\x12WatchOrderProgress\x12$.inventory.WatchOrderProgressRequest\x1a\x1d.inventory.OrderProgressEvent0\x01\x12R\n" +
+	"\rRegisterRobot\x12\x1f.inventory.RegisterRobotRequest\x1a .inventory.RegisterRobotResponse\x12U\n" +
+	"\x0eRobotHeartbeat\x12 .inventory.RobotHeartbeatRequest\x1a!.inventory.RobotHeartbeatResponse\x12d\n" +
+	"\x13AcknowledgeDispatch\x12%.inventory.AcknowledgeDispatchRequest\x1a&.inventory.AcknowledgeDispatchResponse\x12p\n" +
+	"\x17ListUndeliveredWebhooks\x12).inventory.ListUndeliveredWebhooksRequest\x1a*.inventory.ListUndeliveredWebhooksResponse\x12U\n" +
+	"\x0eReplayWebhooks\x12 .inventory.ReplayWebhooksRequest\x1a!.inventory.ReplayWebhooksResponse\x12X\n" +
+	"\x0fGetWasteSummary\x12!.inventory.GetWasteSummaryRequest\x1a\".inventory.GetWasteSummaryResponse\x12X\n" +
+	"\x0fGetStockHistory\x12!.inventory.GetStockHistoryRequest\x1a\".inventory.GetStockHistoryResponse\x12X\n" +
+	"\x0fSetReorderPoint\x12!.inventory.SetReorderPointRequest\x1a\".inventory.SetReorderPointResponse\x12O\n" +
+	"\fListLowStock\x12\x1e.inventory.ListLowStockRequest\x1a\x1f.inventory.ListLowStockResponse\x12L\n" +
+	"\vAdjustStock\x12\x1d.inventory.AdjustStockRequest\x1a\x1e.inventory.AdjustStockResponse\x12V\n" +
+	"\x10DecideAdjustment\x12\".inventory.DecideAdjustmentRequest\x1a\x1e.inventory.AdjustStockResponseB*Z(auto_grocery/inventory/proto;inventorypbb\x06proto3"
 
 var (
 	file_inventory_proto_inventory_proto_rawDescOnce sync.Once
@@ -888,56 +3660,148 @@ func file_inventory_proto_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_inventory_proto_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_inventory_proto_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_inventory_proto_inventory_proto_goTypes = []any{
-	(*CheckAvailabilityRequest)(nil),     // 0: inventory.CheckAvailabilityRequest
-	(*CheckAvailabilityResponse)(nil),    // 1: inventory.CheckAvailabilityResponse
-	(*StockLevel)(nil),                   // 2: inventory.StockLevel
-	(*ReserveItemsRequest)(nil),          // 3: inventory.ReserveItemsRequest
-	(*ReserveItemsResponse)(nil),         // 4: inventory.ReserveItemsResponse
-	(*ReleaseItemsRequest)(nil),          // 5: inventory.ReleaseItemsRequest
-	(*ReleaseItemsResponse)(nil),         // 6: inventory.ReleaseItemsResponse
-	(*ProcessCustomerOrderRequest)(nil),  // 7: inventory.ProcessCustomerOrderRequest
-	(*ProcessCustomerOrderResponse)(nil), // 8: inventory.ProcessCustomerOrderResponse
-	(*RestockItemsOrderRequest)(nil),     // 9: inventory.RestockItemsOrderRequest
-	(*RestockItem)(nil),                  // 10: inventory.RestockItem
-	(*RestockItemsOrderResponse)(nil),    // 11: inventory.RestockItemsOrderResponse
-	(*ReportJobStatusRequest)(nil),       // 12: inventory.ReportJobStatusRequest
-	(*ReportJobStatusResponse)(nil),      // 13: inventory.ReportJobStatusResponse
-	nil,                                  // 14: inventory.CheckAvailabilityResponse.ItemsEntry
-	nil,                                  // 15: inventory.ReserveItemsRequest.ItemsEntry
-	nil,                                  // 16: inventory.ReleaseItemsRequest.ItemsEntry
-	nil,                                  // 17: inventory.ProcessCustomerOrderRequest.ItemsEntry
-	nil,                                  // 18: inventory.ReportJobStatusRequest.ProcessedItemsEntry
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
+	(ReserveMode)(0),                        // 0: inventory.ReserveMode
+	(JobStatus)(0),                          // 1: inventory.JobStatus
+	(AdjustmentReason)(0),                   // 2: inventory.AdjustmentReason
+	(*CheckAvailabilityRequest)(nil),        // 3: inventory.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),       // 4: inventory.CheckAvailabilityResponse
+	(*StockLevel)(nil),                      // 5: inventory.StockLevel
+	(*ListStockRequest)(nil),                // 6: inventory.ListStockRequest
+	(*CatalogItem)(nil),                     // 7: inventory.CatalogItem
+	(*ListStockResponse)(nil),               // 8: inventory.ListStockResponse
+	(*ReserveItemsRequest)(nil),             // 9: inventory.ReserveItemsRequest
+	(*ReserveLine)(nil),                     // 10: inventory.ReserveLine
+	(*Substitute)(nil),                      // 11: inventory.Substitute
+	(*ReserveItemsResponse)(nil),            // 12: inventory.ReserveItemsResponse
+	(*PriceQuote)(nil),                      // 13: inventory.PriceQuote
+	(*QuotedLine)(nil),                      // 14: inventory.QuotedLine
+	(*ReleaseItemsRequest)(nil),             // 15: inventory.ReleaseItemsRequest
+	(*ReleaseItemsResponse)(nil),            // 16: inventory.ReleaseItemsResponse
+	(*ProcessCustomerOrderRequest)(nil),     // 17: inventory.ProcessCustomerOrderRequest
+	(*ProcessCustomerOrderResponse)(nil),    // 18: inventory.ProcessCustomerOrderResponse
+	(*RestockItemsOrderRequest)(nil),        // 19: inventory.RestockItemsOrderRequest
+	(*RestockItem)(nil),                     // 20: inventory.RestockItem
+	(*RestockItemsOrderResponse)(nil),       // 21: inventory.RestockItemsOrderResponse
+	(*ReportJobStatusRequest)(nil),          // 22: inventory.ReportJobStatusRequest
+	(*ReportJobStatusResponse)(nil),         // 23: inventory.ReportJobStatusResponse
+	(*WatchOrderProgressRequest)(nil),       // 24: inventory.WatchOrderProgressRequest
+	(*OrderProgressEvent)(nil),              // 25: inventory.OrderProgressEvent
+	(*RegisterRobotRequest)(nil),            // 26: inventory.RegisterRobotRequest
+	(*RegisterRobotResponse)(nil),           // 27: inventory.RegisterRobotResponse
+	(*RobotHeartbeatRequest)(nil),           // 28: inventory.RobotHeartbeatRequest
+	(*RobotHeartbeatResponse)(nil),          // 29: inventory.RobotHeartbeatResponse
+	(*AcknowledgeDispatchRequest)(nil),      // 30: inventory.AcknowledgeDispatchRequest
+	(*AcknowledgeDispatchResponse)(nil),     // 31: inventory.AcknowledgeDispatchResponse
+	(*WebhookOutboxEntry)(nil),              // 32: inventory.WebhookOutboxEntry
+	(*ListUndeliveredWebhooksRequest)(nil),  // 33: inventory.ListUndeliveredWebhooksRequest
+	(*ListUndeliveredWebhooksResponse)(nil), // 34: inventory.ListUndeliveredWebhooksResponse
+	(*ReplayWebhooksRequest)(nil),           // 35: inventory.ReplayWebhooksRequest
+	(*ReplayWebhooksResponse)(nil),          // 36: inventory.ReplayWebhooksResponse
+	(*GetWasteSummaryRequest)(nil),          // 37: inventory.GetWasteSummaryRequest
+	(*WasteTotal)(nil),                      // 38: inventory.WasteTotal
+	(*GetWasteSummaryResponse)(nil),         // 39: inventory.GetWasteSummaryResponse
+	(*GetStockHistoryRequest)(nil),          // 40: inventory.GetStockHistoryRequest
+	(*StockMovement)(nil),                   // 41: inventory.StockMovement
+	(*GetStockHistoryResponse)(nil),         // 42: inventory.GetStockHistoryResponse
+	(*SetReorderPointRequest)(nil),          // 43: inventory.SetReorderPointRequest
+	(*SetReorderPointResponse)(nil),         // 44: inventory.SetReorderPointResponse
+	(*ListLowStockRequest)(nil),             // 45: inventory.ListLowStockRequest
+	(*LowStockItem)(nil),                    // 46: inventory.LowStockItem
+	(*ListLowStockResponse)(nil),            // 47: inventory.ListLowStockResponse
+	(*AdjustStockRequest)(nil),              // 48: inventory.AdjustStockRequest
+	(*AdjustStockResponse)(nil),             // 49: inventory.AdjustStockResponse
+	(*DecideAdjustmentRequest)(nil),         // 50: inventory.DecideAdjustmentRequest
+	nil,                                     // 51: inventory.CheckAvailabilityResponse.ItemsEntry
+	nil,                                     // 52: inventory.ReserveItemsRequest.ItemsEntry
+	nil,                                     // 53: inventory.ReleaseItemsRequest.ItemsEntry
+	nil,                                     // 54: inventory.ReleaseItemsResponse.ReleasedEntry
+	nil,                                     // 55: inventory.ProcessCustomerOrderRequest.ItemsEntry
+	nil,                                     // 56: inventory.ReportJobStatusRequest.ProcessedItemsEntry
+	nil,                                     // 57: inventory.OrderProgressEvent.ProcessedItemsEntry
+	(*timestamppb.Timestamp)(nil),           // 58: google.protobuf.Timestamp
 }
 var file_inventory_proto_inventory_proto_depIdxs = []int32{
-	14, // 0: inventory.CheckAvailabilityResponse.items:type_name -> inventory.CheckAvailabilityResponse.ItemsEntry
-	15, // 1: inventory.ReserveItemsRequest.items:type_name -> inventory.ReserveItemsRequest.ItemsEntry
-	16, // 2: inventory.ReleaseItemsRequest.items:type_name -> inventory.ReleaseItemsRequest.ItemsEntry
-	17, // 3: inventory.ProcessCustomerOrderRequest.items:type_name -> inventory.ProcessCustomerOrderRequest.ItemsEntry
-	10, // 4: inventory.RestockItemsOrderRequest.items:type_name -> inventory.RestockItem
-	19, // 5: inventory.RestockItem.mfd_date:type_name -> google.protobuf.Timestamp
-	19, // 6: inventory.RestockItem.expiry_date:type_name -> google.protobuf.Timestamp
-	18, // 7: inventory.ReportJobStatusRequest.processed_items:type_name -> inventory.ReportJobStatusRequest.ProcessedItemsEntry
-	2,  // 8: inventory.CheckAvailabilityResponse.ItemsEntry.value:type_name -> inventory.StockLevel
-	0,  // 9: inventory.InventoryService.CheckAvailability:input_type -> inventory.CheckAvailabilityRequest
-	3,  // 10: inventory.InventoryService.ReserveItems:input_type -> inventory.ReserveItemsRequest
-	5,  // 11: inventory.InventoryService.ReleaseItems:input_type -> inventory.ReleaseItemsRequest
-	9,  // 12: inventory.InventoryService.RestockItemsOrder:input_type -> inventory.RestockItemsOrderRequest
-	7,  // 13: inventory.InventoryService.ProcessCustomerOrder:input_type -> inventory.ProcessCustomerOrderRequest
-	12, // 14: inventory.InventoryService.ReportJobStatus:input_type -> inventory.ReportJobStatusRequest
-	1,  // 15: inventory.InventoryService.CheckAvailability:output_type -> inventory.CheckAvailabilityResponse
-	4,  // 16: inventory.InventoryService.ReserveItems:output_type -> inventory.ReserveItemsResponse
-	6,  // 17: inventory.InventoryService.ReleaseItems:output_type -> inventory.ReleaseItemsResponse
-	11, // 18: inventory.InventoryService.RestockItemsOrder:output_type -> inventory.RestockItemsOrderResponse
-	8,  // 19: inventory.InventoryService.ProcessCustomerOrder:output_type -> inventory.ProcessCustomerOrderResponse
-	13, // 20: inventory.InventoryService.ReportJobStatus:output_type -> inventory.ReportJobStatusResponse
-	15, // [15:21] is the sub-list for method output_type
-	9,  // [9:15] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	51, // 0: inventory.CheckAvailabilityResponse.items:type_name -> inventory.CheckAvailabilityResponse.ItemsEntry
+	58, // 1: inventory.CatalogItem.expiry_date:type_name -> google.protobuf.Timestamp
+	7,  // 2: inventory.ListStockResponse.items:type_name -> inventory.CatalogItem
+	52, // 3: inventory.ReserveItemsRequest.items:type_name -> inventory.ReserveItemsRequest.ItemsEntry
+	0,  // 4: inventory.ReserveItemsRequest.mode:type_name -> inventory.ReserveMode
+	11, // 5: inventory.ReserveLine.substitutes:type_name -> inventory.Substitute
+	58, // 6: inventory.ReserveItemsResponse.expires_at:type_name -> google.protobuf.Timestamp
+	10, // 7: inventory.ReserveItemsResponse.lines:type_name -> inventory.ReserveLine
+	13, // 8: inventory.ReserveItemsResponse.quote:type_name -> inventory.PriceQuote
+	14, // 9: inventory.PriceQuote.lines:type_name -> inventory.QuotedLine
+	58, // 10: inventory.PriceQuote.expires_at:type_name -> google.protobuf.Timestamp
+	53, // 11: inventory.ReleaseItemsRequest.items:type_name -> inventory.ReleaseItemsRequest.ItemsEntry
+	54, // 12: inventory.ReleaseItemsResponse.released:type_name -> inventory.ReleaseItemsResponse.ReleasedEntry
+	55, // 13: inventory.ProcessCustomerOrderRequest.items:type_name -> inventory.ProcessCustomerOrderRequest.ItemsEntry
+	20, // 14: inventory.RestockItemsOrderRequest.items:type_name -> inventory.RestockItem
+	58, // 15: inventory.RestockItem.mfd_date:type_name -> google.protobuf.Timestamp
+	58, // 16: inventory.RestockItem.expiry_date:type_name -> google.protobuf.Timestamp
+	56, // 17: inventory.ReportJobStatusRequest.processed_items:type_name -> inventory.ReportJobStatusRequest.ProcessedItemsEntry
+	1,  // 18: inventory.ReportJobStatusRequest.job_status:type_name -> inventory.JobStatus
+	57, // 19: inventory.OrderProgressEvent.processed_items:type_name -> inventory.OrderProgressEvent.ProcessedItemsEntry
+	58, // 20: inventory.OrderProgressEvent.at:type_name -> google.protobuf.Timestamp
+	58, // 21: inventory.WebhookOutboxEntry.next_attempt_at:type_name -> google.protobuf.Timestamp
+	58, // 22: inventory.WebhookOutboxEntry.created_at:type_name -> google.protobuf.Timestamp
+	32, // 23: inventory.ListUndeliveredWebhooksResponse.entries:type_name -> inventory.WebhookOutboxEntry
+	58, // 24: inventory.GetWasteSummaryRequest.from:type_name -> google.protobuf.Timestamp
+	58, // 25: inventory.GetWasteSummaryRequest.to:type_name -> google.protobuf.Timestamp
+	38, // 26: inventory.GetWasteSummaryResponse.totals:type_name -> inventory.WasteTotal
+	58, // 27: inventory.GetStockHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	58, // 28: inventory.GetStockHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	58, // 29: inventory.StockMovement.at:type_name -> google.protobuf.Timestamp
+	41, // 30: inventory.GetStockHistoryResponse.movements:type_name -> inventory.StockMovement
+	58, // 31: inventory.LowStockItem.last_alert_at:type_name -> google.protobuf.Timestamp
+	46, // 32: inventory.ListLowStockResponse.items:type_name -> inventory.LowStockItem
+	2,  // 33: inventory.AdjustStockRequest.reason:type_name -> inventory.AdjustmentReason
+	5,  // 34: inventory.CheckAvailabilityResponse.ItemsEntry.value:type_name -> inventory.StockLevel
+	3,  // 35: inventory.InventoryService.CheckAvailability:input_type -> inventory.CheckAvailabilityRequest
+	9,  // 36: inventory.InventoryService.ReserveItems:input_type -> inventory.ReserveItemsRequest
+	6,  // 37: inventory.InventoryService.ListStock:input_type -> inventory.ListStockRequest
+	15, // 38: inventory.InventoryService.ReleaseItems:input_type -> inventory.ReleaseItemsRequest
+	19, // 39: inventory.InventoryService.RestockItemsOrder:input_type -> inventory.RestockItemsOrderRequest
+	17, // 40: inventory.InventoryService.ProcessCustomerOrder:input_type -> inventory.ProcessCustomerOrderRequest
+	22, // 41: inventory.InventoryService.ReportJobStatus:input_type -> inventory.ReportJobStatusRequest
+	24, // 42: inventory.InventoryService.WatchOrderProgress:input_type -> inventory.WatchOrderProgressRequest
+	26, // 43: inventory.InventoryService.RegisterRobot:input_type -> inventory.RegisterRobotRequest
+	28, // 44: inventory.InventoryService.RobotHeartbeat:input_type -> inventory.RobotHeartbeatRequest
+	30, // 45: inventory.InventoryService.AcknowledgeDispatch:input_type -> inventory.AcknowledgeDispatchRequest
+	33, // 46: inventory.InventoryService.ListUndeliveredWebhooks:input_type -> inventory.ListUndeliveredWebhooksRequest
+	35, // 47: inventory.InventoryService.ReplayWebhooks:input_type -> inventory.ReplayWebhooksRequest
+	37, // 48: inventory.InventoryService.GetWasteSummary:input_type -> inventory.GetWasteSummaryRequest
+	40, // 49: inventory.InventoryService.GetStockHistory:input_type -> inventory.GetStockHistoryRequest
+	43, // 50: inventory.InventoryService.SetReorderPoint:input_type -> inventory.SetReorderPointRequest
+	45, // 51: inventory.InventoryService.ListLowStock:input_type -> inventory.ListLowStockRequest
+	48, // 52: inventory.InventoryService.AdjustStock:input_type -> inventory.AdjustStockRequest
+	50, // 53: inventory.InventoryService.DecideAdjustment:input_type -> inventory.DecideAdjustmentRequest
+	4,  // 54: inventory.InventoryService.CheckAvailability:output_type -> inventory.CheckAvailabilityResponse
+	12, // 55: inventory.InventoryService.ReserveItems:output_type -> inventory.ReserveItemsResponse
+	8,  // 56: inventory.InventoryService.ListStock:output_type -> inventory.ListStockResponse
+	16, // 57: inventory.InventoryService.ReleaseItems:output_type -> inventory.ReleaseItemsResponse
+	21, // 58: inventory.InventoryService.RestockItemsOrder:output_type -> inventory.RestockItemsOrderResponse
+	18, // 59: inventory.InventoryService.ProcessCustomerOrder:output_type -> inventory.ProcessCustomerOrderResponse
+	23, // 60: inventory.InventoryService.ReportJobStatus:output_type -> inventory.ReportJobStatusResponse
+	25, // 61: inventory.InventoryService.WatchOrderProgress:output_type -> inventory.OrderProgressEvent
+	27, // 62: inventory.InventoryService.RegisterRobot:output_type -> inventory.RegisterRobotResponse
+	29, // 63: inventory.InventoryService.RobotHeartbeat:output_type -> inventory.RobotHeartbeatResponse
+	31, // 64: inventory.InventoryService.AcknowledgeDispatch:output_type -> inventory.AcknowledgeDispatchResponse
+	34, // 65: inventory.InventoryService.ListUndeliveredWebhooks:output_type -> inventory.ListUndeliveredWebhooksResponse
+	36, // 66: inventory.InventoryService.ReplayWebhooks:output_type -> inventory.ReplayWebhooksResponse
+	39, // 67: inventory.InventoryService.GetWasteSummary:output_type -> inventory.GetWasteSummaryResponse
+	42, // 68: inventory.InventoryService.GetStockHistory:output_type -> inventory.GetStockHistoryResponse
+	44, // 69: inventory.InventoryService.SetReorderPoint:output_type -> inventory.SetReorderPointResponse
+	47, // 70: inventory.InventoryService.ListLowStock:output_type -> inventory.ListLowStockResponse
+	49, // 71: inventory.InventoryService.AdjustStock:output_type -> inventory.AdjustStockResponse
+	49, // 72: inventory.InventoryService.DecideAdjustment:output_type -> inventory.AdjustStockResponse
+	54, // [54:73] is the sub-list for method output_type
+	35, // [35:54] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_inventory_proto_init() }
@@ -950,13 +3814,14 @@ func file_inventory_proto_inventory_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_inventory_proto_rawDesc), len(file_inventory_proto_inventory_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   19,
+			NumEnums:      3,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_inventory_proto_inventory_proto_goTypes,
 		DependencyIndexes: file_inventory_proto_inventory_proto_depIdxs,
+		EnumInfos:         file_inventory_proto_inventory_proto_enumTypes,
 		MessageInfos:      file_inventory_proto_inventory_proto_msgTypes,
 	}.Build()
 	File_inventory_proto_inventory_proto = out.File
@@ -19,12 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_CheckAvailability_FullMethodName    = "/inventory.InventoryService/CheckAvailability"
-	InventoryService_ReserveItems_FullMethodName         = "/inventory.InventoryService/ReserveItems"
-	InventoryService_ReleaseItems_FullMethodName         = "/inventory.InventoryService/ReleaseItems"
-	InventoryService_RestockItemsOrder_FullMethodName    = "/inventory.InventoryService/RestockItemsOrder"
-	InventoryService_ProcessCustomerOrder_FullMethodName = "/inventory.InventoryService/ProcessCustomerOrder"
-	InventoryService_ReportJobStatus_FullMethodName      = "/inventory.InventoryService/ReportJobStatus"
+	InventoryService_CheckAvailability_FullMethodName       = "/inventory.InventoryService/CheckAvailability"
+	InventoryService_ReserveItems_FullMethodName            = "/inventory.InventoryService/ReserveItems"
+	InventoryService_ListStock_FullMethodName               = "/inventory.InventoryService/ListStock"
+	InventoryService_ReleaseItems_FullMethodName            = "/inventory.InventoryService/ReleaseItems"
+	InventoryService_RestockItemsOrder_FullMethodName       = "/inventory.InventoryService/RestockItemsOrder"
+	InventoryService_ProcessCustomerOrder_FullMethodName    = "/inventory.InventoryService/ProcessCustomerOrder"
+	InventoryService_ReportJobStatus_FullMethodName         = "/inventory.InventoryService/ReportJobStatus"
+	InventoryService_WatchOrderProgress_FullMethodName      = "/inventory.InventoryService/WatchOrderProgress"
+	InventoryService_RegisterRobot_FullMethodName           = "/inventory.InventoryService/RegisterRobot"
+	InventoryService_RobotHeartbeat_FullMethodName          = "/inventory.InventoryService/RobotHeartbeat"
+	InventoryService_AcknowledgeDispatch_FullMethodName     = "/inventory.InventoryService/AcknowledgeDispatch"
+	InventoryService_ListUndeliveredWebhooks_FullMethodName = "/inventory.InventoryService/ListUndeliveredWebhooks"
+	InventoryService_ReplayWebhooks_FullMethodName          = "/inventory.InventoryService/ReplayWebhooks"
+	InventoryService_GetWasteSummary_FullMethodName         = "/inventory.InventoryService/GetWasteSummary"
+	InventoryService_GetStockHistory_FullMethodName         = "/inventory.InventoryService/GetStockHistory"
+	InventoryService_SetReorderPoint_FullMethodName         = "/inventory.InventoryService/SetReorderPoint"
+	InventoryService_ListLowStock_FullMethodName            = "/inventory.InventoryService/ListLowStock"
+	InventoryService_AdjustStock_FullMethodName             = "/inventory.InventoryService/AdjustStock"
+	InventoryService_DecideAdjustment_FullMethodName        = "/inventory.InventoryService/DecideAdjustment"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -33,10 +46,29 @@ const (
 type InventoryServiceClient interface {
 	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
 	ReserveItems(ctx context.Context, in *ReserveItemsRequest, opts ...grpc.CallOption) (*ReserveItemsResponse, error)
+	// Browsing: stocked skus page by page, with current prices from pricing.
+	ListStock(ctx context.Context, in *ListStockRequest, opts ...grpc.CallOption) (*ListStockResponse, error)
 	ReleaseItems(ctx context.Context, in *ReleaseItemsRequest, opts ...grpc.CallOption) (*ReleaseItemsResponse, error)
 	RestockItemsOrder(ctx context.Context, in *RestockItemsOrderRequest, opts ...grpc.CallOption) (*RestockItemsOrderResponse, error)
 	ProcessCustomerOrder(ctx context.Context, in *ProcessCustomerOrderRequest, opts ...grpc.CallOption) (*ProcessCustomerOrderResponse, error)
 	ReportJobStatus(ctx context.Context, in *ReportJobStatusRequest, opts ...grpc.CallOption) (*ReportJobStatusResponse, error)
+	WatchOrderProgress(ctx context.Context, in *WatchOrderProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderProgressEvent], error)
+	RegisterRobot(ctx context.Context, in *RegisterRobotRequest, opts ...grpc.CallOption) (*RegisterRobotResponse, error)
+	RobotHeartbeat(ctx context.Context, in *RobotHeartbeatRequest, opts ...grpc.CallOption) (*RobotHeartbeatResponse, error)
+	AcknowledgeDispatch(ctx context.Context, in *AcknowledgeDispatchRequest, opts ...grpc.CallOption) (*AcknowledgeDispatchResponse, error)
+	// Admin: inspect and replay ordering webhooks that have not been delivered.
+	ListUndeliveredWebhooks(ctx context.Context, in *ListUndeliveredWebhooksRequest, opts ...grpc.CallOption) (*ListUndeliveredWebhooksResponse, error)
+	ReplayWebhooks(ctx context.Context, in *ReplayWebhooksRequest, opts ...grpc.CallOption) (*ReplayWebhooksResponse, error)
+	// Reporting: expired stock written off by the scheduled expiry job.
+	GetWasteSummary(ctx context.Context, in *GetWasteSummaryRequest, opts ...grpc.CallOption) (*GetWasteSummaryResponse, error)
+	// Audit: journaled quantity changes for one sku.
+	GetStockHistory(ctx context.Context, in *GetStockHistoryRequest, opts ...grpc.CallOption) (*GetStockHistoryResponse, error)
+	// Replenishment: per-sku reorder thresholds and the skus currently below them.
+	SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error)
+	ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error)
+	// Corrections after a physical count; large ones need a second person's decision.
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	DecideAdjustment(ctx context.Context, in *DecideAdjustmentRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -67,6 +99,16 @@ func (c *inventoryServiceClient) ReserveItems(ctx context.Context, in *ReserveIt
 	return out, nil
 }
 
+func (c *inventoryServiceClient) ListStock(ctx context.Context, in *ListStockRequest, opts ...grpc.CallOption) (*ListStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) ReleaseItems(ctx context.Context, in *ReleaseItemsRequest, opts ...grpc.CallOption) (*ReleaseItemsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReleaseItemsResponse)
@@ -107,16 +149,164 @@ func (c *inventoryServiceClient) ReportJobStatus(ctx context.Context, in *Report
 	return out, nil
 }
 
+func (c *inventoryServiceClient) WatchOrderProgress(ctx context.Context, in *WatchOrderProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &InventoryService_ServiceDesc.Streams[0], InventoryService_WatchOrderProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderProgressRequest, OrderProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchOrderProgressClient = grpc.ServerStreamingClient[OrderProgressEvent]
+
+func (c *inventoryServiceClient) RegisterRobot(ctx context.Context, in *RegisterRobotRequest, opts ...grpc.CallOption) (*RegisterRobotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterRobotResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RegisterRobot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RobotHeartbeat(ctx context.Context, in *RobotHeartbeatRequest, opts ...grpc.CallOption) (*RobotHeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RobotHeartbeatResponse)
+	err := c.cc.Invoke(ctx, InventoryService_RobotHeartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) AcknowledgeDispatch(ctx context.Context, in *AcknowledgeDispatchRequest, opts ...grpc.CallOption) (*AcknowledgeDispatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeDispatchResponse)
+	err := c.cc.Invoke(ctx, InventoryService_AcknowledgeDispatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListUndeliveredWebhooks(ctx context.Context, in *ListUndeliveredWebhooksRequest, opts ...grpc.CallOption) (*ListUndeliveredWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUndeliveredWebhooksResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListUndeliveredWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReplayWebhooks(ctx context.Context, in *ReplayWebhooksRequest, opts ...grpc.CallOption) (*ReplayWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayWebhooksResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ReplayWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetWasteSummary(ctx context.Context, in *GetWasteSummaryRequest, opts ...grpc.CallOption) (*GetWasteSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWasteSummaryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetWasteSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) GetStockHistory(ctx context.Context, in *GetStockHistoryRequest, opts ...grpc.CallOption) (*GetStockHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockHistoryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetStockHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) SetReorderPoint(ctx context.Context, in *SetReorderPointRequest, opts ...grpc.CallOption) (*SetReorderPointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetReorderPointResponse)
+	err := c.cc.Invoke(ctx, InventoryService_SetReorderPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ListLowStock(ctx context.Context, in *ListLowStockRequest, opts ...grpc.CallOption) (*ListLowStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ListLowStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) DecideAdjustment(ctx context.Context, in *DecideAdjustmentRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, InventoryService_DecideAdjustment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
 type InventoryServiceServer interface {
 	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
 	ReserveItems(context.Context, *ReserveItemsRequest) (*ReserveItemsResponse, error)
+	// Browsing: stocked skus page by page, with current prices from pricing.
+	ListStock(context.Context, *ListStockRequest) (*ListStockResponse, error)
 	ReleaseItems(context.Context, *ReleaseItemsRequest) (*ReleaseItemsResponse, error)
 	RestockItemsOrder(context.Context, *RestockItemsOrderRequest) (*RestockItemsOrderResponse, error)
 	ProcessCustomerOrder(context.Context, *ProcessCustomerOrderRequest) (*ProcessCustomerOrderResponse, error)
 	ReportJobStatus(context.Context, *ReportJobStatusRequest) (*ReportJobStatusResponse, error)
+	WatchOrderProgress(*WatchOrderProgressRequest, grpc.ServerStreamingServer[OrderProgressEvent]) error
+	RegisterRobot(context.Context, *RegisterRobotRequest) (*RegisterRobotResponse, error)
+	RobotHeartbeat(context.Context, *RobotHeartbeatRequest) (*RobotHeartbeatResponse, error)
+	AcknowledgeDispatch(context.Context, *AcknowledgeDispatchRequest) (*AcknowledgeDispatchResponse, error)
+	// Admin: inspect and replay ordering webhooks that have not been delivered.
+	ListUndeliveredWebhooks(context.Context, *ListUndeliveredWebhooksRequest) (*ListUndeliveredWebhooksResponse, error)
+	ReplayWebhooks(context.Context, *ReplayWebhooksRequest) (*ReplayWebhooksResponse, error)
+	// Reporting: expired stock written off by the scheduled expiry job.
+	GetWasteSummary(context.Context, *GetWasteSummaryRequest) (*GetWasteSummaryResponse, error)
+	// Audit: journaled quantity changes for one sku.
+	GetStockHistory(context.Context, *GetStockHistoryRequest) (*GetStockHistoryResponse, error)
+	// Replenishment: per-sku reorder thresholds and the skus currently below them.
+	SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error)
+	ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error)
+	// Corrections after a physical count; large ones need a second person's decision.
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	DecideAdjustment(context.Context, *DecideAdjustmentRequest) (*AdjustStockResponse, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -133,6 +323,9 @@ func (UnimplementedInventoryServiceServer) CheckAvailability(context.Context, *C
 func (UnimplementedInventoryServiceServer) ReserveItems(context.Context, *ReserveItemsRequest) (*ReserveItemsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReserveItems not implemented")
 }
+func (UnimplementedInventoryServiceServer) ListStock(context.Context, *ListStockRequest) (*ListStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStock not implemented")
+}
 func (UnimplementedInventoryServiceServer) ReleaseItems(context.Context, *ReleaseItemsRequest) (*ReleaseItemsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReleaseItems not implemented")
 }
@@ -145,6 +338,42 @@ func (UnimplementedInventoryServiceServer) ProcessCustomerOrder(context.Context,
 func (UnimplementedInventoryServiceServer) ReportJobStatus(context.Context, *ReportJobStatusRequest) (*ReportJobStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ReportJobStatus not implemented")
 }
+func (UnimplementedInventoryServiceServer) WatchOrderProgress(*WatchOrderProgressRequest, grpc.ServerStreamingServer[OrderProgressEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchOrderProgress not implemented")
+}
+func (UnimplementedInventoryServiceServer) RegisterRobot(context.Context, *RegisterRobotRequest) (*RegisterRobotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterRobot not implemented")
+}
+func (UnimplementedInventoryServiceServer) RobotHeartbeat(context.Context, *RobotHeartbeatRequest) (*RobotHeartbeatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RobotHeartbeat not implemented")
+}
+func (UnimplementedInventoryServiceServer) AcknowledgeDispatch(context.Context, *AcknowledgeDispatchRequest) (*AcknowledgeDispatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcknowledgeDispatch not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListUndeliveredWebhooks(context.Context, *ListUndeliveredWebhooksRequest) (*ListUndeliveredWebhooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUndeliveredWebhooks not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReplayWebhooks(context.Context, *ReplayWebhooksRequest) (*ReplayWebhooksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayWebhooks not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetWasteSummary(context.Context, *GetWasteSummaryRequest) (*GetWasteSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWasteSummary not implemented")
+}
+func (UnimplementedInventoryServiceServer) GetStockHistory(context.Context, *GetStockHistoryRequest) (*GetStockHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStockHistory not implemented")
+}
+func (UnimplementedInventoryServiceServer) SetReorderPoint(context.Context, *SetReorderPointRequest) (*SetReorderPointResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetReorderPoint not implemented")
+}
+func (UnimplementedInventoryServiceServer) ListLowStock(context.Context, *ListLowStockRequest) (*ListLowStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLowStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) DecideAdjustment(context.Context, *DecideAdjustmentRequest) (*AdjustStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DecideAdjustment not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -202,6 +431,24 @@ func _InventoryService_ReserveItems_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_ListStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListStock(ctx, req.(*ListStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_ReleaseItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReleaseItemsRequest)
 	if err := dec(in); err != nil {
@@ -274,6 +521,215 @@ func _InventoryService_ReportJobStatus_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_WatchOrderProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InventoryServiceServer).WatchOrderProgress(m, &grpc.GenericServerStream[WatchOrderProgressRequest, OrderProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type InventoryService_WatchOrderProgressServer = grpc.ServerStreamingServer[OrderProgressEvent]
+
+func _InventoryService_RegisterRobot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRobotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RegisterRobot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RegisterRobot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RegisterRobot(ctx, req.(*RegisterRobotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RobotHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RobotHeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RobotHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RobotHeartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RobotHeartbeat(ctx, req.(*RobotHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_AcknowledgeDispatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeDispatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).AcknowledgeDispatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_AcknowledgeDispatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).AcknowledgeDispatch(ctx, req.(*AcknowledgeDispatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListUndeliveredWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUndeliveredWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListUndeliveredWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListUndeliveredWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListUndeliveredWebhooks(ctx, req.(*ListUndeliveredWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReplayWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReplayWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReplayWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReplayWebhooks(ctx, req.(*ReplayWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetWasteSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWasteSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetWasteSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetWasteSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetWasteSummary(ctx, req.(*GetWasteSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_GetStockHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetStockHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetStockHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetStockHistory(ctx, req.(*GetStockHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_SetReorderPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReorderPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_SetReorderPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).SetReorderPoint(ctx, req.(*SetReorderPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ListLowStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ListLowStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ListLowStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ListLowStock(ctx, req.(*ListLowStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_DecideAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).DecideAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_DecideAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).DecideAdjustment(ctx, req.(*DecideAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +745,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReserveItems",
 			Handler:    _InventoryService_ReserveItems_Handler,
 		},
+		{
+			MethodName: "ListStock",
+			Handler:    _InventoryService_ListStock_Handler,
+		},
 		{
 			MethodName: "ReleaseItems",
 			Handler:    _InventoryService_ReleaseItems_Handler,
@@ -305,7 +765,57 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportJobStatus",
 			Handler:    _InventoryService_ReportJobStatus_Handler,
 		},
+		{
+			MethodName: "RegisterRobot",
+			Handler:    _InventoryService_RegisterRobot_Handler,
+		},
+		{
+			MethodName: "RobotHeartbeat",
+			Handler:    _InventoryService_RobotHeartbeat_Handler,
+		},
+		{
+			MethodName: "AcknowledgeDispatch",
+			Handler:    _InventoryService_AcknowledgeDispatch_Handler,
+		},
+		{
+			MethodName: "ListUndeliveredWebhooks",
+			Handler:    _InventoryService_ListUndeliveredWebhooks_Handler,
+		},
+		{
+			MethodName: "ReplayWebhooks",
+			Handler:    _InventoryService_ReplayWebhooks_Handler,
+		},
+		{
+			MethodName: "GetWasteSummary",
+			Handler:    _InventoryService_GetWasteSummary_Handler,
+		},
+		{
+			MethodName: "GetStockHistory",
+			Handler:    _InventoryService_GetStockHistory_Handler,
+		},
+		{
+			MethodName: "SetReorderPoint",
+			Handler:    _InventoryService_SetReorderPoint_Handler,
+		},
+		{
+			MethodName: "ListLowStock",
+			Handler:    _InventoryService_ListLowStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _InventoryService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "DecideAdjustment",
+			Handler:    _InventoryService_DecideAdjustment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrderProgress",
+			Handler:       _InventoryService_WatchOrderProgress_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory/proto/inventory.proto",
 }
@@ -23,6 +23,10 @@ const (
 	PricingService_CreateItem_FullMethodName         = "/pricing.PricingService/CreateItem"
 	PricingService_CalculateBill_FullMethodName      = "/pricing.PricingService/CalculateBill"
 	PricingService_UpdateStockMetrics_FullMethodName = "/pricing.PricingService/UpdateStockMetrics"
+	PricingService_GetPrices_FullMethodName          = "/pricing.PricingService/GetPrices"
+	PricingService_GetPriceAt_FullMethodName         = "/pricing.PricingService/GetPriceAt"
+	PricingService_ListPriceHistory_FullMethodName   = "/pricing.PricingService/ListPriceHistory"
+	PricingService_CreateQuote_FullMethodName        = "/pricing.PricingService/CreateQuote"
 )
 
 // PricingServiceClient is the client API for PricingService service.
@@ -33,6 +37,10 @@ type PricingServiceClient interface {
 	CreateItem(ctx context.Context, in *CreateItemRequest, opts ...grpc.CallOption) (*CreateItemResponse, error)
 	CalculateBill(ctx context.Context, in *CalculateBillRequest, opts ...grpc.CallOption) (*CalculateBillResponse, error)
 	UpdateStockMetrics(ctx context.Context, in *UpdateStockMetricsRequest, opts ...grpc.CallOption) (*UpdateStockMetricsResponse, error)
+	GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error)
+	GetPriceAt(ctx context.Context, in *GetPriceAtRequest, opts ...grpc.CallOption) (*GetPriceAtResponse, error)
+	ListPriceHistory(ctx context.Context, in *ListPriceHistoryRequest, opts ...grpc.CallOption) (*ListPriceHistoryResponse, error)
+	CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*CreateQuoteResponse, error)
 }
 
 type pricingServiceClient struct {
@@ -83,6 +91,46 @@ func (c *pricingServiceClient) UpdateStockMetrics(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *pricingServiceClient) GetPrices(ctx context.Context, in *GetPricesRequest, opts ...grpc.CallOption) (*GetPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPricesResponse)
+	err := c.cc.Invoke(ctx, PricingService_GetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) GetPriceAt(ctx context.Context, in *GetPriceAtRequest, opts ...grpc.CallOption) (*GetPriceAtResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceAtResponse)
+	err := c.cc.Invoke(ctx, PricingService_GetPriceAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ListPriceHistory(ctx context.Context, in *ListPriceHistoryRequest, opts ...grpc.CallOption) (*ListPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, PricingService_ListPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*CreateQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateQuoteResponse)
+	err := c.cc.Invoke(ctx, PricingService_CreateQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PricingServiceServer is the server API for PricingService service.
 // All implementations must embed UnimplementedPricingServiceServer
 // for forward compatibility.
@@ -91,6 +139,10 @@ type PricingServiceServer interface {
 	CreateItem(context.Context, *CreateItemRequest) (*CreateItemResponse, error)
 	CalculateBill(context.Context, *CalculateBillRequest) (*CalculateBillResponse, error)
 	UpdateStockMetrics(context.Context, *UpdateStockMetricsRequest) (*UpdateStockMetricsResponse, error)
+	GetPrices(context.Context, *GetPricesRequest) (*GetPricesResponse, error)
+	GetPriceAt(context.Context, *GetPriceAtRequest) (*GetPriceAtResponse, error)
+	ListPriceHistory(context.Context, *ListPriceHistoryRequest) (*ListPriceHistoryResponse, error)
+	CreateQuote(context.Context, *CreateQuoteRequest) (*CreateQuoteResponse, error)
 	mustEmbedUnimplementedPricingServiceServer()
 }
 
@@ -113,6 +165,18 @@ func (UnimplementedPricingServiceServer) CalculateBill(context.Context, *Calcula
 func (UnimplementedPricingServiceServer) UpdateStockMetrics(context.Context, *UpdateStockMetricsRequest) (*UpdateStockMetricsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateStockMetrics not implemented")
 }
+func (UnimplementedPricingServiceServer) GetPrices(context.Context, *GetPricesRequest) (*GetPricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPrices not implemented")
+}
+func (UnimplementedPricingServiceServer) GetPriceAt(context.Context, *GetPriceAtRequest) (*GetPriceAtResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceAt not implemented")
+}
+func (UnimplementedPricingServiceServer) ListPriceHistory(context.Context, *ListPriceHistoryRequest) (*ListPriceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPriceHistory not implemented")
+}
+func (UnimplementedPricingServiceServer) CreateQuote(context.Context, *CreateQuoteRequest) (*CreateQuoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateQuote not implemented")
+}
 func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
 func (UnimplementedPricingServiceServer) testEmbeddedByValue()                        {}
 
@@ -206,6 +270,78 @@ func _PricingService_UpdateStockMetrics_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PricingService_GetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).GetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_GetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).GetPrices(ctx, req.(*GetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_GetPriceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).GetPriceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_GetPriceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).GetPriceAt(ctx, req.(*GetPriceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ListPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListPriceHistory(ctx, req.(*ListPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_CreateQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CreateQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CreateQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CreateQuote(ctx, req.(*CreateQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +365,22 @@ var PricingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateStockMetrics",
 			Handler:    _PricingService_UpdateStockMetrics_Handler,
 		},
+		{
+			MethodName: "GetPrices",
+			Handler:    _PricingService_GetPrices_Handler,
+		},
+		{
+			MethodName: "GetPriceAt",
+			Handler:    _PricingService_GetPriceAt_Handler,
+		},
+		{
+			MethodName: "ListPriceHistory",
+			Handler:    _PricingService_ListPriceHistory_Handler,
+		},
+		{
+			MethodName: "CreateQuote",
+			Handler:    _PricingService_CreateQuote_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory/proto/pricing.proto",
@@ -0,0 +1,7 @@
+ALTER TABLE grocery_order_items
+DROP COLUMN picked_quantity,
+DROP COLUMN short_quantity;
+
+ALTER TABLE restock_order_items
+DROP COLUMN picked_quantity,
+DROP COLUMN short_quantity;
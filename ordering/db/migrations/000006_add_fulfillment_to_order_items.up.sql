@@ -0,0 +1,8 @@
+-- Per-line quantities actually handled by robots, reported by inventory at finalization
+ALTER TABLE grocery_order_items
+ADD COLUMN picked_quantity INT,
+ADD COLUMN short_quantity INT NOT NULL DEFAULT 0;
+
+ALTER TABLE restock_order_items
+ADD COLUMN picked_quantity INT,
+ADD COLUMN short_quantity INT NOT NULL DEFAULT 0;
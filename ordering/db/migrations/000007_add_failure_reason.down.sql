@@ -0,0 +1,2 @@
+ALTER TABLE grocery_orders DROP COLUMN failure_reason;
+ALTER TABLE restock_orders DROP COLUMN failure_reason;
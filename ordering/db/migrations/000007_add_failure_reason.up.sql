@@ -0,0 +1,3 @@
+-- Why inventory gave up on an order (e.g. robot timeout)
+ALTER TABLE grocery_orders ADD COLUMN failure_reason TEXT;
+ALTER TABLE restock_orders ADD COLUMN failure_reason TEXT;
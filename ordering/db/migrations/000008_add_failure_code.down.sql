@@ -0,0 +1,2 @@
+ALTER TABLE grocery_orders DROP COLUMN failure_code;
+ALTER TABLE restock_orders DROP COLUMN failure_code;
@@ -0,0 +1,3 @@
+-- Machine-readable failure class sent by inventory (e.g. ROBOT_FAILED, SLA_TIMEOUT)
+ALTER TABLE grocery_orders ADD COLUMN failure_code TEXT;
+ALTER TABLE restock_orders ADD COLUMN failure_code TEXT;
@@ -0,0 +1,3 @@
+ALTER TABLE grocery_orders DROP COLUMN quoted_total;
+ALTER TABLE grocery_orders DROP COLUMN quote_expires_at;
+ALTER TABLE grocery_orders DROP COLUMN quote_id;
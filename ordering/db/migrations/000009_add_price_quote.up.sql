@@ -0,0 +1,4 @@
+-- Pricing quote locked at preview; the order is billed at its prices once confirmed.
+ALTER TABLE grocery_orders ADD COLUMN quote_id TEXT;
+ALTER TABLE grocery_orders ADD COLUMN quote_expires_at TIMESTAMPTZ;
+ALTER TABLE grocery_orders ADD COLUMN quoted_total NUMERIC(10, 2);
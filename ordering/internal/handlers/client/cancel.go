@@ -2,11 +2,15 @@ package client
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 
 	"auto_grocery/ordering/internal/auth"
 	"auto_grocery/ordering/internal/store"
 	pb "auto_grocery/ordering/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type CancelOrderHandler struct {
@@ -33,16 +37,17 @@ func (h *CancelOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbItems, _ := h.OrderStore.GetOrderItems(r.Context(), req.OrderID)
-	protoItems := make(map[string]int32)
-	for _, item := range dbItems {
-		protoItems[item.Sku] = int32(item.Quantity)
+	// Inventory releases whatever the order still holds.
+	_, err := h.InventoryClient.ReleaseItems(r.Context(), &pb.ReleaseItemsRequest{OrderId: req.OrderID})
+	if status.Code(err) == codes.FailedPrecondition {
+		http.Error(w, "Order already dispatched", http.StatusConflict)
+		return
+	} else if err != nil {
+		log.Printf("[cancel] release failed order=%s err=%v", req.OrderID, err)
+		http.Error(w, "Failed to release reservation", http.StatusBadGateway)
+		return
 	}
 
-	h.InventoryClient.ReleaseItems(r.Context(), &pb.ReleaseItemsRequest{
-		OrderId: req.OrderID, Items: protoItems,
-	})
-
 	h.OrderStore.DeleteOrder(r.Context(), req.OrderID)
 	json.NewEncoder(w).Encode(map[string]string{"status": "cancelled"})
 }
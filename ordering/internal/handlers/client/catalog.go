@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "auto_grocery/ordering/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CatalogHandler struct {
+	InventoryClient pb.InventoryServiceClient
+}
+
+// catalogItem is one sku as shown to clients browsing the catalog.
+type catalogItem struct {
+	Sku        string  `json:"sku"`
+	Name       string  `json:"name"`
+	AisleType  string  `json:"aisle_type"`
+	Quantity   int32   `json:"quantity"`
+	ExpiryDate string  `json:"expiry_date,omitempty"`
+	UnitPrice  float64 `json:"unit_price"`
+	Priced     bool    `json:"priced"`
+}
+
+// ServeHTTP pages through the stocked catalog with current prices.
+// Query params: aisle, in_stock, expiring_within_days, q, page_size, page_token.
+func (h *CatalogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.ListStockRequest{
+		AisleType:  query.Get("aisle"),
+		NamePrefix: query.Get("q"),
+		PageToken:  query.Get("page_token"),
+	}
+
+	var err error
+	if v := query.Get("in_stock"); v != "" {
+		if req.InStockOnly, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "in_stock must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+	for name, dst := range map[string]*int32{
+		"expiring_within_days": &req.ExpiringWithinDays,
+		"page_size":            &req.PageSize,
+	} {
+		v := query.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || n < 0 {
+			http.Error(w, name+" must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		*dst = int32(n)
+	}
+
+	resp, err := h.InventoryClient.ListStock(r.Context(), req)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("[catalog] ERROR list stock failed err=%v", err)
+		http.Error(w, "Inventory unavailable", http.StatusBadGateway)
+		return
+	}
+
+	items := make([]catalogItem, 0, len(resp.GetItems()))
+	for _, i := range resp.GetItems() {
+		item := catalogItem{
+			Sku:       i.GetSku(),
+			Name:      i.GetName(),
+			AisleType: i.GetAisleType(),
+			Quantity:  i.GetQuantity(),
+			UnitPrice: i.GetUnitPrice(),
+			Priced:    i.GetPriced(),
+		}
+		if i.GetExpiryDate() != nil {
+			item.ExpiryDate = i.GetExpiryDate().AsTime().Format(time.RFC3339)
+		}
+		items = append(items, item)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"items":           items,
+			"next_page_token": resp.GetNextPageToken(),
+		},
+	})
+}
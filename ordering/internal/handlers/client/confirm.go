@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"time"
 
 	"auto_grocery/ordering/internal/auth"
 	"auto_grocery/ordering/internal/store"
 	pb "auto_grocery/ordering/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ConfirmOrderHandler struct {
@@ -62,6 +66,18 @@ func (h *ConfirmOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Order is not in PENDING state", http.StatusConflict)
 		return
 	}
+	// The quoted prices only hold until the quote expires; after that the customer must see the new prices.
+	if order.QuoteID != "" && time.Now().After(order.QuoteExpiresAt) {
+		if _, err := h.InventoryClient.ReleaseItems(r.Context(), &pb.ReleaseItemsRequest{OrderId: reqBody.OrderID}); err != nil {
+			log.Printf("[confirm] WARN release after quote expiry failed order=%s err=%v", reqBody.OrderID, err)
+		}
+		if _, err := h.OrderStore.ExpirePendingOrder(r.Context(), reqBody.OrderID, "QUOTE_EXPIRED", "price quote expired before confirmation"); err != nil {
+			log.Printf("[confirm] failed to set EXPIRED order=%s err=%v", reqBody.OrderID, err)
+		}
+		log.Printf("[confirm] quote expired order=%s quote=%s expires_at=%s", reqBody.OrderID, order.QuoteID, order.QuoteExpiresAt.Format(time.RFC3339))
+		http.Error(w, "Price quote expired; preview the order again", http.StatusConflict)
+		return
+	}
 
 	// Fetch trusted order items from storage.
 	dbItems, err := h.OrderStore.GetOrderItems(r.Context(), reqBody.OrderID)
@@ -77,24 +93,47 @@ func (h *ConfirmOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		protoItems[item.Sku] = int32(item.Quantity)
 	}
 
-	// Update status and dispatch robots.
-	err = h.OrderStore.UpdateStatus(r.Context(), reqBody.OrderID, "PROCESSING")
+	// Update status and dispatch robots. Only the confirm that moves the order out of PENDING dispatches it;
+	// a concurrent one sees the order taken.
+	claimed, err := h.OrderStore.TransitionStatus(r.Context(), reqBody.OrderID, "PENDING", "PROCESSING", "", "")
 	if err != nil {
 		log.Printf("[confirm] failed to set PROCESSING order=%s err=%v", reqBody.OrderID, err)
 		http.Error(w, "Database update failed", http.StatusInternalServerError)
 		return
 	}
+	if !claimed {
+		log.Printf("[confirm] invalid status order=%s (confirmed concurrently)", reqBody.OrderID)
+		http.Error(w, "Order is not in PENDING state", http.StatusConflict)
+		return
+	}
 	log.Printf("[confirm] status PROCESSING set order=%s items=%v", reqBody.OrderID, protoItems)
 
 	grpcReq := &pb.ProcessCustomerOrderRequest{
 		OrderId: reqBody.OrderID,
 		Items:   protoItems,
+		QuoteId: order.QuoteID,
 	}
 
 	resp, err := h.InventoryClient.ProcessCustomerOrder(context.Background(), grpcReq)
+	if status.Code(err) == codes.FailedPrecondition {
+		// The stock hold lapsed before confirmation; inventory has already released it.
+		if _, err := h.OrderStore.TransitionStatus(r.Context(), reqBody.OrderID, "PROCESSING", "EXPIRED",
+			"RESERVATION_EXPIRED", "stock reservation expired before dispatch"); err != nil {
+			log.Printf("[confirm] failed to set EXPIRED order=%s err=%v", reqBody.OrderID, err)
+		}
+		log.Printf("[confirm] reservation expired order=%s err=%v", reqBody.OrderID, err)
+		http.Error(w, "Reservation expired; preview the order again", http.StatusConflict)
+		return
+	}
 	if err != nil {
-		// Roll back order status if dispatch fails.
-		h.OrderStore.UpdateStatus(r.Context(), reqBody.OrderID, "FAILED_DISPATCH")
+		// Roll back order status if dispatch fails; the hold is still HELD, so hand the stock back now
+		// rather than when it expires.
+		if _, err := h.OrderStore.TransitionStatus(r.Context(), reqBody.OrderID, "PROCESSING", "FAILED_DISPATCH", "", ""); err != nil {
+			log.Printf("[confirm] failed to set FAILED_DISPATCH order=%s err=%v", reqBody.OrderID, err)
+		}
+		if _, err := h.InventoryClient.ReleaseItems(r.Context(), &pb.ReleaseItemsRequest{OrderId: reqBody.OrderID}); err != nil {
+			log.Printf("[confirm] WARN release after failed dispatch order=%s err=%v", reqBody.OrderID, err)
+		}
 		log.Printf("[confirm] dispatch failed order=%s err=%v", reqBody.OrderID, err)
 		http.Error(w, "Failed to assign robots: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"time"
 
 	"auto_grocery/ordering/internal/auth"
 	"auto_grocery/ordering/internal/store"
@@ -17,7 +18,7 @@ type PreviewOrderHandler struct {
 	InventoryClient pb.InventoryServiceClient
 }
 
-// ServeHTTP reserves requested items in inventory and persists a pending order.
+// ServeHTTP reserves requested items in inventory and persists a pending order with its price quote.
 func (h *PreviewOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value(auth.UserKey).(int)
 	if !ok {
@@ -31,6 +32,8 @@ func (h *PreviewOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			Sku      string `json:"sku"`
 			Quantity int32  `json:"quantity"`
 		} `json:"items"`
+		// AllowPartial reserves whatever is in stock instead of rejecting the whole order.
+		AllowPartial bool `json:"allow_partial"`
 	}
 	// Validate JSON payload.
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -46,31 +49,54 @@ func (h *PreviewOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 	log.Printf("[preview] user=%d items=%v", userID, protoItems)
 
+	mode := pb.ReserveMode_RESERVE_MODE_ALL_OR_NOTHING
+	if req.AllowPartial {
+		mode = pb.ReserveMode_RESERVE_MODE_PARTIAL
+	}
 	grpcResp, err := h.InventoryClient.ReserveItems(r.Context(), &pb.ReserveItemsRequest{
-		OrderId: orderUUID, Items: protoItems,
+		OrderId: orderUUID, Items: protoItems, Mode: mode,
 	})
 
 	// Validate transport and business response success.
-	if err != nil || !grpcResp.GetSuccess() {
-		if err != nil {
-			log.Printf("[preview] reserve grpc failed order=%s user=%d err=%v", orderUUID, userID, err)
-		} else {
-			log.Printf("[preview] reserve rejected order=%s user=%d reason=%s", orderUUID, userID, grpcResp.GetErrorMessage())
-		}
+	if err != nil {
+		log.Printf("[preview] reserve grpc failed order=%s user=%d err=%v", orderUUID, userID, err)
 		http.Error(w, "Reservation failed", http.StatusConflict)
 		return
 	}
-	log.Printf("[preview] reserve success order=%s user=%d", orderUUID, userID)
+	lines := previewLines(grpcResp.GetLines())
+	if !grpcResp.GetSuccess() {
+		log.Printf("[preview] reserve rejected order=%s user=%d reason=%s", orderUUID, userID, grpcResp.GetErrorMessage())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":           "Reservation failed: " + grpcResp.GetErrorMessage(),
+			"lines":           lines,
+			"suggested_items": suggestedItems(grpcResp.GetLines()),
+		})
+		return
+	}
+	log.Printf("[preview] reserve success order=%s user=%d expires_at=%s", orderUUID, userID, grpcResp.GetExpiresAt().AsTime().Format(time.RFC3339))
 
 	var dbItems []store.GroceryOrderItem
-	// Persist the trusted requested reservation items.
-	for sku, qty := range protoItems {
-		dbItems = append(dbItems, store.GroceryOrderItem{Sku: sku, Quantity: int(qty)})
+	reservedItems := make(map[string]int32)
+	// Persist what inventory actually reserved; a partial reserve may hold less than was asked for.
+	for _, line := range grpcResp.GetLines() {
+		if line.GetReserved() > 0 {
+			dbItems = append(dbItems, store.GroceryOrderItem{Sku: line.GetSku(), Quantity: int(line.GetReserved())})
+			reservedItems[line.GetSku()] = line.GetReserved()
+		}
 	}
 
-	err = h.OrderStore.CreateGroceryOrder(r.Context(), store.GroceryOrder{
-		OrderID: orderUUID, ClientID: userID, Status: "PENDING",
-	}, dbItems)
+	order := store.GroceryOrder{OrderID: orderUUID, ClientID: userID, Status: "PENDING"}
+	quote := grpcResp.GetQuote()
+	if quote != nil {
+		order.QuoteID = quote.GetQuoteId()
+		order.QuoteExpiresAt = quote.GetExpiresAt().AsTime()
+		order.QuotedTotal = quote.GetTotal()
+	} else {
+		log.Printf("[preview] WARN no price quote order=%s user=%d, billing at picking-time prices", orderUUID, userID)
+	}
+	err = h.OrderStore.CreateGroceryOrder(r.Context(), order, dbItems)
 
 	if err != nil {
 		log.Printf("[preview] failed to create order row order=%s user=%d err=%v", orderUUID, userID, err)
@@ -79,10 +105,133 @@ func (h *PreviewOrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	}
 	log.Printf("[preview] order persisted order=%s user=%d", orderUUID, userID)
 
+	resp := map[string]interface{}{
+		"status":     "reserved",
+		"order_id":   orderUUID,
+		"items":      reservedItems,
+		"lines":      lines,
+		"expires_at": grpcResp.GetExpiresAt().AsTime().Format(time.RFC3339),
+	}
+	if quote != nil {
+		resp["quote"] = previewQuoteFrom(quote)
+	}
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":   "reserved",
-		"order_id": orderUUID,
-		"items":    protoItems,
-	})
+	json.NewEncoder(w).Encode(resp)
+}
+
+// previewLine is the per-sku reserve outcome shown to the client.
+type previewLine struct {
+	Sku         string              `json:"sku"`
+	Requested   int32               `json:"requested"`
+	Available   int32               `json:"available"`
+	Reserved    int32               `json:"reserved"`
+	Shortfall   int32               `json:"shortfall"`
+	Substitutes []previewSubstitute `json:"substitutes,omitempty"`
+}
+
+// previewSubstitute is an in-stock replacement suggested for a short sku.
+type previewSubstitute struct {
+	Sku               string  `json:"sku"`
+	Name              string  `json:"name"`
+	Available         int32   `json:"available"`
+	UnitPrice         float64 `json:"unit_price"`
+	Similarity        float64 `json:"similarity"`
+	SuggestedQuantity int32   `json:"suggested_quantity"`
+}
+
+// previewQuote is the price quote the order is billed at if confirmed before expires_at.
+type previewQuote struct {
+	QuoteID   string             `json:"quote_id"`
+	Lines     []previewQuoteLine `json:"lines"`
+	Total     float64            `json:"total"`
+	ExpiresAt string             `json:"expires_at"`
+}
+
+// previewQuoteLine is one quoted sku.
+type previewQuoteLine struct {
+	Sku        string  `json:"sku"`
+	Quantity   int32   `json:"quantity"`
+	UnitPrice  float64 `json:"unit_price"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+// previewItem matches the preview request's item shape so clients can resubmit it as-is.
+type previewItem struct {
+	Sku      string `json:"sku"`
+	Quantity int32  `json:"quantity"`
+}
+
+// previewLines converts inventory reserve lines to their JSON form.
+func previewLines(lines []*pb.ReserveLine) []previewLine {
+	out := make([]previewLine, 0, len(lines))
+	for _, l := range lines {
+		line := previewLine{
+			Sku:       l.GetSku(),
+			Requested: l.GetRequested(),
+			Available: l.GetAvailable(),
+			Reserved:  l.GetReserved(),
+			Shortfall: l.GetShortfall(),
+		}
+		for _, s := range l.GetSubstitutes() {
+			line.Substitutes = append(line.Substitutes, previewSubstitute{
+				Sku:               s.GetSku(),
+				Name:              s.GetName(),
+				Available:         s.GetAvailable(),
+				UnitPrice:         s.GetUnitPrice(),
+				Similarity:        s.GetSimilarity(),
+				SuggestedQuantity: s.GetSuggestedQuantity(),
+			})
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// previewQuoteFrom converts an inventory price quote to its JSON form.
+func previewQuoteFrom(q *pb.PriceQuote) previewQuote {
+	out := previewQuote{
+		QuoteID:   q.GetQuoteId(),
+		Lines:     make([]previewQuoteLine, 0, len(q.GetLines())),
+		Total:     q.GetTotal(),
+		ExpiresAt: q.GetExpiresAt().AsTime().Format(time.RFC3339),
+	}
+	for _, l := range q.GetLines() {
+		out.Lines = append(out.Lines, previewQuoteLine{
+			Sku:        l.GetSku(),
+			Quantity:   l.GetQuantity(),
+			UnitPrice:  l.GetUnitPrice(),
+			TotalPrice: l.GetTotalPrice(),
+		})
+	}
+	return out
+}
+
+// suggestedItems builds a retry request: what is in stock of each sku, with each shortfall
+// covered by its best substitute where there is one.
+func suggestedItems(lines []*pb.ReserveLine) []previewItem {
+	quantities := make(map[string]int32)
+	var order []string
+	// Two short skus can share a substitute, so never ask for more than it has.
+	add := func(sku string, qty int32, available int32) {
+		qty = min(qty, available-quantities[sku])
+		if qty <= 0 {
+			return
+		}
+		if _, ok := quantities[sku]; !ok {
+			order = append(order, sku)
+		}
+		quantities[sku] += qty
+	}
+	for _, l := range lines {
+		add(l.GetSku(), l.GetRequested(), l.GetAvailable())
+		if subs := l.GetSubstitutes(); l.GetShortfall() > 0 && len(subs) > 0 {
+			add(subs[0].GetSku(), subs[0].GetSuggestedQuantity(), subs[0].GetAvailable())
+		}
+	}
+
+	items := make([]previewItem, 0, len(order))
+	for _, sku := range order {
+		items = append(items, previewItem{Sku: sku, Quantity: quantities[sku]})
+	}
+	return items
 }
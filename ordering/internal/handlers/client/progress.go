@@ -0,0 +1,86 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"auto_grocery/ordering/internal/auth"
+	"auto_grocery/ordering/internal/store"
+	pb "auto_grocery/ordering/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type OrderProgressHandler struct {
+	OrderStore      *store.OrderStore
+	InventoryClient pb.InventoryServiceClient
+}
+
+// ServeHTTP relays inventory fulfillment events for a client's order as server-sent events.
+func (h *OrderProgressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(auth.UserKey).(int)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	orderID := r.URL.Query().Get("order_id")
+	order, _ := h.OrderStore.GetOrderByID(r.Context(), orderID)
+	if order == nil || order.ClientID != userID {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	stream, err := h.InventoryClient.WatchOrderProgress(r.Context(), &pb.WatchOrderProgressRequest{
+		OrderId:   orderID,
+		OrderType: "CUSTOMER",
+	})
+	if err != nil {
+		log.Printf("[order-progress] ERROR watch failed order=%s err=%v", orderID, err)
+		http.Error(w, "Progress unavailable", http.StatusBadGateway)
+		return
+	}
+
+	// The first receive tells us whether inventory is tracking the order at all.
+	event, err := stream.Recv()
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			http.Error(w, "Order is not in fulfillment", http.StatusConflict)
+			return
+		}
+		log.Printf("[order-progress] ERROR watch failed order=%s err=%v", orderID, err)
+		http.Error(w, "Progress unavailable", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+	for {
+		data, err := marshal.Marshal(event)
+		if err != nil {
+			log.Printf("[order-progress] WARN encode failed order=%s err=%v", orderID, err)
+			return
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.GetEventType(), data)
+		flusher.Flush()
+
+		event, err = stream.Recv()
+		if err != nil {
+			// io.EOF once the order is finalized; anything else means the client or inventory went away.
+			return
+		}
+	}
+}
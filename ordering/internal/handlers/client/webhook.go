@@ -17,9 +17,12 @@ type WebhookHandler struct {
 }
 
 type WebhookPayload struct {
-	OrderID    string  `json:"order_id"`
-	Status     string  `json:"status"`
-	TotalPrice float64 `json:"total_price"`
+	OrderID    string                  `json:"order_id"`
+	Status     string                  `json:"status"`
+	TotalPrice float64                 `json:"total_price"`
+	Lines      []store.FulfillmentLine `json:"lines"`
+	Reason     string                  `json:"reason"`
+	ReasonCode string                  `json:"reason_code"`
 }
 
 // ServeHTTP processes inventory completion webhooks and updates client order status.
@@ -31,6 +34,20 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// An expired hold only cancels orders the client never confirmed.
+	if payload.Status == "EXPIRED" {
+		expired, err := h.OrderStore.ExpirePendingOrder(r.Context(), payload.OrderID, payload.ReasonCode, payload.Reason)
+		if err != nil {
+			log.Printf("[client-webhook] ERROR failed to expire order order_id=%s err=%v", payload.OrderID, err)
+			http.Error(w, "Database update failed", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[client-webhook] reservation expired order_id=%s applied=%t", payload.OrderID, expired)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Webhook received"))
+		return
+	}
+
 	// Persist final order status.
 	err := h.OrderStore.UpdateOrderStatus(r.Context(), payload.OrderID, payload.Status, payload.TotalPrice)
 	if err != nil {
@@ -38,6 +55,14 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Database update failed", http.StatusInternalServerError)
 		return
 	}
+	if err := h.OrderStore.RecordFulfillment(r.Context(), payload.OrderID, payload.Lines); err != nil {
+		log.Printf("[client-webhook] WARN failed to record fulfillment lines order_id=%s err=%v", payload.OrderID, err)
+	}
+	if payload.Reason != "" {
+		if err := h.OrderStore.SetFailureReason(r.Context(), payload.OrderID, payload.ReasonCode, payload.Reason); err != nil {
+			log.Printf("[client-webhook] WARN failed to record failure reason order_id=%s err=%v", payload.OrderID, err)
+		}
+	}
 
 	// Publish analytics asynchronously.
 	go func() {
@@ -35,6 +35,10 @@ func NewRouter(
 	mux.Handle("GET /api/truck/restock/status", &truck.RestockStatusHandler{
 		RestockStore: restockStore,
 	})
+	mux.Handle("GET /api/truck/suggested-restock", &truck.SuggestedRestockHandler{
+		RestockStore:    restockStore,
+		InventoryClient: inventoryClient,
+	})
 
 	// --- Internal Webhooks (Protected by X-Internal-Secret) ---
 	clientWebhook := &client.WebhookHandler{
@@ -54,6 +58,10 @@ func NewRouter(
 		return auth.AuthMiddleware(h)
 	}
 
+	mux.Handle("GET /api/client/catalog", protected(&client.CatalogHandler{
+		InventoryClient: inventoryClient,
+	}))
+
 	mux.Handle("POST /api/client/order/preview", protected(&client.PreviewOrderHandler{
 		OrderStore:      orderStore,
 		InventoryClient: inventoryClient,
@@ -77,5 +85,10 @@ func NewRouter(
 		OrderStore: orderStore,
 	}))
 
+	mux.Handle("GET /api/client/orders/progress", protected(&client.OrderProgressHandler{
+		OrderStore:      orderStore,
+		InventoryClient: inventoryClient,
+	}))
+
 	return mux
 }
@@ -0,0 +1,104 @@
+package truck
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+
+	"auto_grocery/ordering/internal/store"
+	pb "auto_grocery/ordering/proto"
+)
+
+type SuggestedRestockHandler struct {
+	RestockStore    *store.RestockStore
+	InventoryClient pb.InventoryServiceClient
+}
+
+// suggestedItem uses the restock request's item shape so a manifest can be posted back as-is.
+type suggestedItem struct {
+	Sku             string  `json:"sku"`
+	Name            string  `json:"name"`
+	AisleType       string  `json:"aisle_type"`
+	Quantity        int32   `json:"quantity"`
+	UnitCost        float64 `json:"unit_cost"`
+	CurrentQuantity int32   `json:"current_quantity"`
+	ReorderPoint    int32   `json:"reorder_point"`
+	TargetLevel     int32   `json:"target_level"`
+}
+
+// suggestedManifest is one supplier's proposed restock.
+type suggestedManifest struct {
+	SupplierID   string          `json:"supplier_id"`
+	SupplierName string          `json:"supplier_name"`
+	Items        []suggestedItem `json:"items"`
+	TotalCost    float64         `json:"total_cost"`
+}
+
+// ServeHTTP proposes one restock manifest per supplier for every sku below its reorder point.
+func (h *SuggestedRestockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	low, err := h.InventoryClient.ListLowStock(r.Context(), &pb.ListLowStockRequest{})
+	if err != nil {
+		log.Printf("[truck-suggest] ERROR low stock lookup failed err=%v", err)
+		http.Error(w, "Inventory unavailable", http.StatusBadGateway)
+		return
+	}
+
+	skus := make([]string, 0, len(low.GetItems()))
+	for _, item := range low.GetItems() {
+		skus = append(skus, item.GetSku())
+	}
+	supplies, err := h.RestockStore.GetLastSupplies(r.Context(), skus)
+	if err != nil {
+		log.Printf("[truck-suggest] ERROR supplier lookup failed err=%v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	manifests := make(map[string]*suggestedManifest)
+	// Skus never restocked through ordering have no supplier or cost to propose.
+	var unassigned []suggestedItem
+	for _, item := range low.GetItems() {
+		line := suggestedItem{
+			Sku:             item.GetSku(),
+			Name:            item.GetName(),
+			AisleType:       item.GetAisleType(),
+			Quantity:        item.GetSuggestedQuantity(),
+			CurrentQuantity: item.GetQuantity(),
+			ReorderPoint:    item.GetReorderPoint(),
+			TargetLevel:     item.GetTargetLevel(),
+		}
+		supply, ok := supplies[item.GetSku()]
+		if !ok {
+			unassigned = append(unassigned, line)
+			continue
+		}
+		line.UnitCost = supply.UnitCost
+
+		m, ok := manifests[supply.SupplierID]
+		if !ok {
+			m = &suggestedManifest{SupplierID: supply.SupplierID, SupplierName: supply.SupplierName}
+			manifests[supply.SupplierID] = m
+		}
+		m.Items = append(m.Items, line)
+		m.TotalCost += line.UnitCost * float64(line.Quantity)
+	}
+
+	out := make([]*suggestedManifest, 0, len(manifests))
+	for _, m := range manifests {
+		m.TotalCost = math.Round(m.TotalCost*100) / 100
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SupplierID < out[j].SupplierID })
+	log.Printf("[truck-suggest] low_skus=%d manifests=%d unassigned=%d", len(skus), len(out), len(unassigned))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"manifests":  out,
+			"unassigned": unassigned,
+		},
+	})
+}
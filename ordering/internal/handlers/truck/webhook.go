@@ -21,9 +21,12 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	log.Printf("[truck-webhook] request received")
 	// Decode webhook payload.
 	var req struct {
-		OrderID   string  `json:"order_id"`
-		Status    string  `json:"status"`
-		TotalCost float64 `json:"total_cost"`
+		OrderID    string                  `json:"order_id"`
+		Status     string                  `json:"status"`
+		TotalCost  float64                 `json:"total_cost"`
+		Lines      []store.FulfillmentLine `json:"lines"`
+		Reason     string                  `json:"reason"`
+		ReasonCode string                  `json:"reason_code"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("[truck-webhook] invalid json err=%v", err)
@@ -40,6 +43,14 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	log.Printf("[truck-webhook] order updated order_id=%s status=%s", req.OrderID, req.Status)
+	if err := h.RestockStore.RecordFulfillment(r.Context(), req.OrderID, req.Lines); err != nil {
+		log.Printf("[truck-webhook] WARN failed to record fulfillment lines order_id=%s err=%v", req.OrderID, err)
+	}
+	if req.Reason != "" {
+		if err := h.RestockStore.SetFailureReason(r.Context(), req.OrderID, req.ReasonCode, req.Reason); err != nil {
+			log.Printf("[truck-webhook] WARN failed to record failure reason order_id=%s err=%v", req.OrderID, err)
+		}
+	}
 
 	// Publish analytics asynchronously.
 	go func() {
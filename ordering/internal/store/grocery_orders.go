@@ -8,12 +8,18 @@ import (
 )
 
 type GroceryOrder struct {
-	ID         int
-	OrderID    string
-	ClientID   int
-	Status     string
-	TotalPrice float64
-	CreatedAt  time.Time
+	ID            int
+	OrderID       string
+	ClientID      int
+	Status        string
+	TotalPrice    float64
+	FailureCode   string
+	FailureReason string
+	CreatedAt     time.Time
+	// Pricing quote locked at preview; QuoteID is empty when pricing could not quote.
+	QuoteID        string
+	QuoteExpiresAt time.Time
+	QuotedTotal    float64
 }
 
 type GroceryOrderItem struct {
@@ -23,6 +29,14 @@ type GroceryOrderItem struct {
 	Quantity int
 }
 
+// FulfillmentLine is inventory's per-sku comparison of requested and robot-handled quantities.
+type FulfillmentLine struct {
+	Sku       string `json:"sku"`
+	Requested int    `json:"requested"`
+	Picked    int    `json:"picked"`
+	Short     int    `json:"short"`
+}
+
 type OrderStore struct {
 	db *sql.DB
 }
@@ -42,12 +56,17 @@ func (s *OrderStore) CreateGroceryOrder(ctx context.Context, order GroceryOrder,
 
 	// 1. Insert the Order Header
 	queryHeader := `
-		INSERT INTO grocery_orders (order_id, client_id, status, total_price)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO grocery_orders (order_id, client_id, status, total_price, quote_id, quote_expires_at, quoted_total)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
 		RETURNING id
 	`
+	var quoteExpiresAt, quotedTotal any
+	if order.QuoteID != "" {
+		quoteExpiresAt, quotedTotal = order.QuoteExpiresAt, order.QuotedTotal
+	}
 	// Note: We scan the generated DB ID into 'order.ID' for use in the items loop below
-	err = tx.QueryRowContext(ctx, queryHeader, order.OrderID, order.ClientID, "PENDING", 0.0).Scan(&order.ID)
+	err = tx.QueryRowContext(ctx, queryHeader, order.OrderID, order.ClientID, "PENDING", 0.0,
+		order.QuoteID, quoteExpiresAt, quotedTotal).Scan(&order.ID)
 	if err != nil {
 		return fmt.Errorf("failed to save order header: %w", err)
 	}
@@ -77,7 +96,7 @@ func (s *OrderStore) CreateGroceryOrder(ctx context.Context, order GroceryOrder,
 // GetOrdersByClientID returns all orders for a given client.
 func (s *OrderStore) GetOrdersByClientID(ctx context.Context, clientID int) ([]GroceryOrder, error) {
 	query := `
-		SELECT id, order_id, status, total_price, created_at
+		SELECT id, order_id, status, total_price, COALESCE(failure_code, ''), COALESCE(failure_reason, ''), created_at
 		FROM grocery_orders
 		WHERE client_id = $1
 		ORDER BY created_at DESC
@@ -91,7 +110,7 @@ func (s *OrderStore) GetOrdersByClientID(ctx context.Context, clientID int) ([]G
 	var history []GroceryOrder
 	for rows.Next() {
 		var o GroceryOrder
-		if err := rows.Scan(&o.ID, &o.OrderID, &o.Status, &o.TotalPrice, &o.CreatedAt); err != nil {
+		if err := rows.Scan(&o.ID, &o.OrderID, &o.Status, &o.TotalPrice, &o.FailureCode, &o.FailureReason, &o.CreatedAt); err != nil {
 			return nil, err
 		}
 		o.ClientID = clientID
@@ -104,7 +123,7 @@ func (s *OrderStore) GetOrdersByClientID(ctx context.Context, clientID int) ([]G
 // GetLastOrderByClientID returns the latest order for polling UX.
 func (s *OrderStore) GetLastOrderByClientID(ctx context.Context, clientID int) (*GroceryOrder, error) {
 	query := `
-		SELECT id, order_id, status, total_price, created_at
+		SELECT id, order_id, status, total_price, COALESCE(failure_code, ''), COALESCE(failure_reason, ''), created_at
 		FROM grocery_orders
 		WHERE client_id = $1
 		ORDER BY created_at DESC
@@ -113,7 +132,7 @@ func (s *OrderStore) GetLastOrderByClientID(ctx context.Context, clientID int) (
 	var o GroceryOrder
 
 	err := s.db.QueryRowContext(ctx, query, clientID).Scan(
-		&o.ID, &o.OrderID, &o.Status, &o.TotalPrice, &o.CreatedAt,
+		&o.ID, &o.OrderID, &o.Status, &o.TotalPrice, &o.FailureCode, &o.FailureReason, &o.CreatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -148,6 +167,57 @@ func (s *OrderStore) UpdateOrderStatus(ctx context.Context, orderID string, stat
 	return nil
 }
 
+// SetFailureReason records why inventory failed an order.
+func (s *OrderStore) SetFailureReason(ctx context.Context, orderID string, code string, reason string) error {
+	query := `UPDATE grocery_orders SET failure_code = NULLIF($1, ''), failure_reason = $2 WHERE order_id = $3`
+	_, err := s.db.ExecContext(ctx, query, code, reason, orderID)
+	return err
+}
+
+// ExpirePendingOrder marks a still-PENDING order EXPIRED and reports whether it changed.
+func (s *OrderStore) ExpirePendingOrder(ctx context.Context, orderID string, code string, reason string) (bool, error) {
+	query := `
+        UPDATE grocery_orders
+        SET status = 'EXPIRED', failure_code = NULLIF($2, ''), failure_reason = $3
+        WHERE order_id = $1 AND status = 'PENDING'
+    `
+	res, err := s.db.ExecContext(ctx, query, orderID, code, reason)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// RecordFulfillment stores picked and short quantities for each order line.
+func (s *OrderStore) RecordFulfillment(ctx context.Context, orderID string, lines []FulfillmentLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE grocery_order_items i
+		SET picked_quantity = $1, short_quantity = $2
+		FROM grocery_orders o
+		WHERE i.order_id = o.id AND o.order_id = $3 AND i.sku = $4
+	`
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, query, line.Picked, line.Short, orderID, line.Sku); err != nil {
+			return fmt.Errorf("failed to record fulfillment for %s: %w", line.Sku, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // GetOrderItems returns item lines for a business order id.
 func (s *OrderStore) GetOrderItems(ctx context.Context, orderID string) ([]GroceryOrderItem, error) {
 	var dbID int
@@ -205,13 +275,16 @@ func (s *OrderStore) DeleteOrder(ctx context.Context, orderID string) error {
 // GetOrderByID fetches a single order by business order id.
 func (s *OrderStore) GetOrderByID(ctx context.Context, orderID string) (*GroceryOrder, error) {
 	query := `
-		SELECT id, order_id, client_id, status, total_price, created_at
+		SELECT id, order_id, client_id, status, total_price, COALESCE(failure_code, ''), COALESCE(failure_reason, ''), created_at,
+		       COALESCE(quote_id, ''), quote_expires_at, COALESCE(quoted_total, 0)
 		FROM grocery_orders
 		WHERE order_id = $1
 	`
 	var o GroceryOrder
+	var quoteExpiresAt sql.NullTime
 	err := s.db.QueryRowContext(ctx, query, orderID).Scan(
-		&o.ID, &o.OrderID, &o.ClientID, &o.Status, &o.TotalPrice, &o.CreatedAt,
+		&o.ID, &o.OrderID, &o.ClientID, &o.Status, &o.TotalPrice, &o.FailureCode, &o.FailureReason, &o.CreatedAt,
+		&o.QuoteID, &quoteExpiresAt, &o.QuotedTotal,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -224,13 +297,28 @@ func (s *OrderStore) GetOrderByID(ctx context.Context, orderID string) (*Grocery
 		o.CreatedAt.Hour(), o.CreatedAt.Minute(), o.CreatedAt.Second(),
 		o.CreatedAt.Nanosecond(), time.Local,
 	)
+	o.QuoteExpiresAt = quoteExpiresAt.Time
 
 	return &o, nil
 }
 
-// UpdateStatus updates only the lifecycle status for an order.
-func (s *OrderStore) UpdateStatus(ctx context.Context, orderID string, status string) error {
-	query := `UPDATE grocery_orders SET status = $1 WHERE order_id = $2`
-	_, err := s.db.ExecContext(ctx, query, status, orderID)
-	return err
+// TransitionStatus moves an order from one lifecycle status to another and reports whether the order was
+// still in the from status. A non-empty code records why the order ended up in the new status.
+func (s *OrderStore) TransitionStatus(ctx context.Context, orderID string, from string, to string, code string, reason string) (bool, error) {
+	query := `
+        UPDATE grocery_orders
+        SET status = $3,
+            failure_code = COALESCE(NULLIF($4, ''), failure_code),
+            failure_reason = CASE WHEN $4 = '' THEN failure_reason ELSE $5 END
+        WHERE order_id = $1 AND status = $2
+    `
+	res, err := s.db.ExecContext(ctx, query, orderID, from, to, code, reason)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
 }
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type RestockStore struct {
@@ -17,12 +19,14 @@ func NewRestockStore(db *sql.DB) *RestockStore {
 }
 
 type RestockOrder struct {
-	ID         int
-	OrderID    string
-	SupplierID int // Internal DB ID from suppliers table
-	Status     string
-	TotalCost  float64
-	CreatedAt  time.Time
+	ID            int
+	OrderID       string
+	SupplierID    int // Internal DB ID from suppliers table
+	Status        string
+	TotalCost     float64
+	FailureCode   string
+	FailureReason string
+	CreatedAt     time.Time
 }
 
 type RestockOrderItem struct {
@@ -127,16 +131,50 @@ func (s *RestockStore) UpdateOrderStatus(ctx context.Context, businessOrderID st
 	return nil
 }
 
+// SetFailureReason records why inventory failed a restock order.
+func (s *RestockStore) SetFailureReason(ctx context.Context, businessOrderID string, code string, reason string) error {
+	query := `UPDATE restock_orders SET failure_code = NULLIF($1, ''), failure_reason = $2 WHERE order_id = $3`
+	_, err := s.db.ExecContext(ctx, query, code, reason, businessOrderID)
+	return err
+}
+
+// RecordFulfillment stores offloaded and short quantities for each restock line.
+func (s *RestockStore) RecordFulfillment(ctx context.Context, businessOrderID string, lines []FulfillmentLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// A manifest may list one sku on several lines; inventory reports the sku total, so it lands on each line.
+	query := `
+        UPDATE restock_order_items i
+        SET picked_quantity = $1, short_quantity = $2
+        FROM restock_orders o
+        WHERE i.order_id = o.id AND o.order_id = $3 AND i.sku = $4
+    `
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, query, line.Picked, line.Short, businessOrderID, line.Sku); err != nil {
+			return fmt.Errorf("failed to record restock fulfillment for %s: %w", line.Sku, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // GetRestockOrder fetches a restock order by business order id.
 func (s *RestockStore) GetRestockOrder(ctx context.Context, orderID string) (*RestockOrder, error) {
 	query := `
-		SELECT id, order_id, supplier_id, status, total_cost, created_at
+		SELECT id, order_id, supplier_id, status, total_cost, COALESCE(failure_code, ''), COALESCE(failure_reason, ''), created_at
 		FROM restock_orders
 		WHERE order_id = $1
 	`
 	var o RestockOrder
 	err := s.db.QueryRowContext(ctx, query, orderID).Scan(
-		&o.ID, &o.OrderID, &o.SupplierID, &o.Status, &o.TotalCost, &o.CreatedAt,
+		&o.ID, &o.OrderID, &o.SupplierID, &o.Status, &o.TotalCost, &o.FailureCode, &o.FailureReason, &o.CreatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("restock order not found: %w", err)
@@ -150,3 +188,42 @@ func (s *RestockStore) GetRestockOrder(ctx context.Context, orderID string) (*Re
 
 	return &o, nil
 }
+
+// LastSupply is the most recent restock line recorded for a sku.
+type LastSupply struct {
+	Sku          string
+	Name         string
+	AisleType    string
+	UnitCost     float64
+	SupplierID   string // business id, e.g. "SUPP-NESTLE-01"
+	SupplierName string
+	OrderedAt    time.Time
+}
+
+// GetLastSupplies returns, per sku, the supplier and unit cost of its latest restock line.
+func (s *RestockStore) GetLastSupplies(ctx context.Context, skus []string) (map[string]LastSupply, error) {
+	query := `
+		SELECT DISTINCT ON (i.sku) i.sku, i.name, COALESCE(i.aisle_type, ''), i.unit_cost,
+		       sp.supplier_id, sp.name, o.created_at
+		FROM restock_order_items i
+		JOIN restock_orders o ON o.id = i.order_id
+		JOIN suppliers sp ON sp.id = o.supplier_id
+		WHERE i.sku = ANY($1)
+		ORDER BY i.sku, o.created_at DESC, o.id DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(skus))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load last supplies: %w", err)
+	}
+	defer rows.Close()
+
+	supplies := make(map[string]LastSupply)
+	for rows.Next() {
+		var l LastSupply
+		if err := rows.Scan(&l.Sku, &l.Name, &l.AisleType, &l.UnitCost, &l.SupplierID, &l.SupplierName, &l.OrderedAt); err != nil {
+			return nil, err
+		}
+		supplies[l.Sku] = l
+	}
+	return supplies, rows.Err()
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"time"
 
 	"auto_grocery/pricing/internal/handler"
 	"auto_grocery/pricing/internal/store"
@@ -23,6 +24,19 @@ func getenv(key, fallback string) string {
 	return value
 }
 
+func getenvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("[pricing] WARN invalid %s=%q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
 // main initializes dependencies and starts the pricing gRPC service.
 func main() {
 	// 1. Load Config
@@ -52,7 +66,9 @@ func main() {
 	catalogStore := store.NewCatalogStore(db)
 
 	// Create the handler (Logic is now triggered via gRPC call from Inventory)
-	pricingHandler := handler.NewPricingHandler(catalogStore)
+	// Quotes lock prices from order preview until the customer confirms.
+	quoteTTL := getenvDuration("QUOTE_TTL", 15*time.Minute)
+	pricingHandler := handler.NewPricingHandler(catalogStore, quoteTTL)
 
 	// 4. Start gRPC Server
 	pricingGRPCAddr := getenv("PRICING_GRPC_ADDR", ":50052")
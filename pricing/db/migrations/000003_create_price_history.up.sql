@@ -0,0 +1,18 @@
+-- Every price a sku has had. The open period (effective_to IS NULL) is the current price,
+-- which catalog.unit_price mirrors.
+CREATE TABLE price_history (
+    id BIGSERIAL PRIMARY KEY,
+    sku TEXT NOT NULL,
+    unit_price NUMERIC(10, 2) NOT NULL,
+    source TEXT NOT NULL, -- STOCK_METRICS, MANUAL, PROMO
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to TIMESTAMPTZ,
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE UNIQUE INDEX idx_price_history_open ON price_history(sku) WHERE effective_to IS NULL;
+CREATE INDEX idx_price_history_sku_from ON price_history(sku, effective_from DESC);
+
+-- Prices set before history was kept open it; when and how they were set is unknown.
+INSERT INTO price_history (sku, unit_price, source, effective_from)
+SELECT sku, unit_price, 'MANUAL', CURRENT_TIMESTAMP FROM catalog;
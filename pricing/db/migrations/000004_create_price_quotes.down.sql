@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS price_quote_lines;
+DROP TABLE IF EXISTS price_quotes;
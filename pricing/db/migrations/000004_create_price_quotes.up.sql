@@ -0,0 +1,15 @@
+-- Prices locked at order preview. An order is billed at its quote's prices no matter how the
+-- catalog moves while it is picked.
+CREATE TABLE price_quotes (
+    quote_id TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE price_quote_lines (
+    quote_id TEXT NOT NULL REFERENCES price_quotes(quote_id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(10, 2) NOT NULL,
+    PRIMARY KEY (quote_id, sku)
+);
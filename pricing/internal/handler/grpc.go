@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"log"
+	"time"
 
 	"auto_grocery/pricing/internal/logic"
 	"auto_grocery/pricing/internal/store"
@@ -14,12 +15,13 @@ import (
 
 type PricingHandler struct {
 	pb.UnimplementedPricingServiceServer
-	store *store.CatalogStore
+	store    *store.CatalogStore
+	quoteTTL time.Duration
 }
 
-// NewPricingHandler constructs a pricing gRPC handler.
-func NewPricingHandler(s *store.CatalogStore) *PricingHandler {
-	return &PricingHandler{store: s}
+// NewPricingHandler constructs a pricing gRPC handler; quotes it creates expire after quoteTTL.
+func NewPricingHandler(s *store.CatalogStore, quoteTTL time.Duration) *PricingHandler {
+	return &PricingHandler{store: s, quoteTTL: quoteTTL}
 }
 
 // UpdateStockMetrics recalculates and persists sku prices from stock metrics.
@@ -44,7 +46,7 @@ func (h *PricingHandler) UpdateStockMetrics(ctx context.Context, req *pb.UpdateS
 
 		// 3. Update the Database
 		// UpsertItem handles "Insert if new, Update if exists"
-		_, err := h.store.UpsertItem(ctx, item)
+		_, err := h.store.UpsertItem(ctx, item, store.SourceStockMetrics)
 		if err != nil {
 			// We log the error but CONTINUE so one failure doesn't stop the whole batch
 			log.Printf("[pricing] update failed sku=%s err=%v", item.Sku, err)
@@ -63,59 +65,90 @@ func (h *PricingHandler) UpdateStockMetrics(ctx context.Context, req *pb.UpdateS
 	}, nil
 }
 
-// CalculateBill computes line totals and aggregate amount for a cart payload.
+// CalculateBill computes line totals and aggregate amount for a cart payload. With a quote_id, skus the
+// quote covers are billed at its locked prices and the rest at current catalog prices.
 func (h *PricingHandler) CalculateBill(ctx context.Context, req *pb.CalculateBillRequest) (*pb.CalculateBillResponse, error) {
-	log.Printf("[pricing] CalculateBill called items=%d", len(req.GetItems()))
+	log.Printf("[pricing] CalculateBill called items=%d quote=%s", len(req.GetItems()), req.GetQuoteId())
+	quoted := map[string]float64{}
+	if req.GetQuoteId() != "" {
+		var err error
+		if quoted, err = h.quotedPrices(ctx, req.GetQuoteId()); err != nil {
+			return nil, err
+		}
+	}
+
 	var skus []string
 	for _, item := range req.GetItems() {
-		skus = append(skus, item.GetSku())
+		if _, ok := quoted[item.GetSku()]; !ok {
+			skus = append(skus, item.GetSku())
+		}
 	}
-	log.Printf("[pricing] CalculateBill skus=%v", skus)
+	log.Printf("[pricing] CalculateBill catalog skus=%v", skus)
 
-	itemsMap, err := h.store.GetItemsBySKUs(ctx, skus)
-	if err != nil {
-		log.Printf("[pricing] CalculateBill fetch prices failed err=%v", err)
-		return nil, status.Errorf(codes.Internal, "failed to fetch prices: %v", err)
+	itemsMap := map[string]store.Item{}
+	if len(skus) > 0 {
+		var err error
+		itemsMap, err = h.store.GetItemsBySKUs(ctx, skus)
+		if err != nil {
+			log.Printf("[pricing] CalculateBill fetch prices failed err=%v", err)
+			return nil, status.Errorf(codes.Internal, "failed to fetch prices: %v", err)
+		}
 	}
 
 	var lineItems []*pb.LineItem
 	var grandTotal float64
 
 	for _, cartItem := range req.GetItems() {
-		dbItem, exists := itemsMap[cartItem.GetSku()]
-		if !exists {
-			log.Printf("[pricing] CalculateBill missing sku=%s", cartItem.GetSku())
-			return nil, status.Errorf(codes.NotFound, "sku %s not found in catalog", cartItem.GetSku())
+		unitPrice, ok := quoted[cartItem.GetSku()]
+		if !ok {
+			dbItem, exists := itemsMap[cartItem.GetSku()]
+			if !exists {
+				log.Printf("[pricing] CalculateBill missing sku=%s", cartItem.GetSku())
+				return nil, status.Errorf(codes.NotFound, "sku %s not found in catalog", cartItem.GetSku())
+			}
+			if req.GetQuoteId() != "" {
+				log.Printf("[pricing] WARN sku=%s not in quote=%s, billing at current price", cartItem.GetSku(), req.GetQuoteId())
+			}
+			unitPrice = dbItem.UnitPrice
 		}
 
-		totalPrice := dbItem.UnitPrice * float64(cartItem.GetQuantity())
+		totalPrice := unitPrice * float64(cartItem.GetQuantity())
 		grandTotal += totalPrice
 
 		lineItems = append(lineItems, &pb.LineItem{
-			Sku:        dbItem.Sku,
-			UnitPrice:  dbItem.UnitPrice,
+			Sku:        cartItem.GetSku(),
+			UnitPrice:  unitPrice,
 			Quantity:   cartItem.GetQuantity(),
 			TotalPrice: totalPrice,
 		})
-		log.Printf("[pricing] line item sku=%s qty=%d unit=%.2f total=%.2f", dbItem.Sku, cartItem.GetQuantity(), dbItem.UnitPrice, totalPrice)
+		log.Printf("[pricing] line item sku=%s qty=%d unit=%.2f total=%.2f", cartItem.GetSku(), cartItem.GetQuantity(), unitPrice, totalPrice)
 	}
 	log.Printf("[pricing] CalculateBill complete grand_total=%.2f", grandTotal)
 
 	return &pb.CalculateBillResponse{
 		Items:      lineItems,
 		GrandTotal: grandTotal,
+		QuoteId:    req.GetQuoteId(),
 	}, nil
 }
 
 // CreateItem creates or updates a catalog sku with an explicit unit price.
 func (h *PricingHandler) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (*pb.CreateItemResponse, error) {
-	log.Printf("[pricing] CreateItem called sku=%s unit_price=%.2f", req.GetSku(), req.GetUnitPrice())
+	log.Printf("[pricing] CreateItem called sku=%s unit_price=%.2f source=%s", req.GetSku(), req.GetUnitPrice(), req.GetSource())
+	source := store.SourceManual
+	switch req.GetSource() {
+	case pb.PriceSource_PRICE_SOURCE_UNSPECIFIED, pb.PriceSource_PRICE_SOURCE_MANUAL:
+	case pb.PriceSource_PRICE_SOURCE_PROMO:
+		source = store.SourcePromo
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "source must be MANUAL or PROMO, got %s", req.GetSource())
+	}
 	item := store.Item{
 		Sku:       req.GetSku(),
 		UnitPrice: req.GetUnitPrice(),
 	}
 
-	id, err := h.store.UpsertItem(ctx, item)
+	id, err := h.store.UpsertItem(ctx, item, source)
 	if err != nil {
 		log.Printf("[pricing] CreateItem failed sku=%s err=%v", req.GetSku(), err)
 		return nil, status.Errorf(codes.Internal, "failed to upsert item: %v", err)
@@ -143,3 +176,19 @@ func (h *PricingHandler) GetPrice(ctx context.Context, req *pb.GetPriceRequest)
 		UnitPrice: item.UnitPrice,
 	}, nil
 }
+
+// GetPrices returns the latest stored prices for a sku batch.
+func (h *PricingHandler) GetPrices(ctx context.Context, req *pb.GetPricesRequest) (*pb.GetPricesResponse, error) {
+	log.Printf("[pricing] GetPrices called skus=%d", len(req.GetSkus()))
+	itemsMap, err := h.store.GetItemsBySKUs(ctx, req.GetSkus())
+	if err != nil {
+		log.Printf("[pricing] GetPrices fetch prices failed err=%v", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch prices: %v", err)
+	}
+
+	prices := make(map[string]float64, len(itemsMap))
+	for sku, item := range itemsMap {
+		prices[sku] = item.UnitPrice
+	}
+	return &pb.GetPricesResponse{Prices: prices}, nil
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"auto_grocery/pricing/internal/store"
+	pb "auto_grocery/pricing/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultHistoryPageSize = 50
+	maxHistoryPageSize     = 200
+)
+
+// GetPriceAt returns the price a sku had at a point in time.
+func (h *PricingHandler) GetPriceAt(ctx context.Context, req *pb.GetPriceAtRequest) (*pb.GetPriceAtResponse, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+	at := time.Now()
+	if req.GetAt() != nil {
+		at = req.GetAt().AsTime()
+	}
+	log.Printf("[pricing] GetPriceAt called sku=%s at=%s", req.GetSku(), at.Format(time.RFC3339))
+
+	period, err := h.store.GetPriceAt(ctx, req.GetSku(), at)
+	if errors.Is(err, store.ErrNoPrice) {
+		return nil, status.Errorf(codes.NotFound, "sku %s had no price at %s", req.GetSku(), at.Format(time.RFC3339))
+	} else if err != nil {
+		log.Printf("[pricing] GetPriceAt failed sku=%s err=%v", req.GetSku(), err)
+		return nil, status.Errorf(codes.Internal, "failed to get price: %v", err)
+	}
+	return &pb.GetPriceAtResponse{Price: pricePeriodProto(period)}, nil
+}
+
+// ListPriceHistory pages through a sku's price periods, newest first.
+func (h *PricingHandler) ListPriceHistory(ctx context.Context, req *pb.ListPriceHistoryRequest) (*pb.ListPriceHistoryResponse, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultHistoryPageSize
+	}
+	pageSize = min(pageSize, maxHistoryPageSize)
+
+	// The page token is the id of the last period on the previous page.
+	var before int64
+	if token := req.GetPageToken(); token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			before, err = strconv.ParseInt(string(raw), 10, 64)
+		}
+		if err != nil || before <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+
+	var filter store.HistoryFilter
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+	log.Printf("[pricing] ListPriceHistory called sku=%s page_size=%d", req.GetSku(), pageSize)
+
+	// One extra row tells us whether another page follows.
+	periods, err := h.store.ListPriceHistory(ctx, req.GetSku(), filter, before, pageSize+1)
+	if err != nil {
+		log.Printf("[pricing] ListPriceHistory failed sku=%s err=%v", req.GetSku(), err)
+		return nil, status.Errorf(codes.Internal, "failed to list price history: %v", err)
+	}
+	resp := &pb.ListPriceHistoryResponse{}
+	if len(periods) > pageSize {
+		periods = periods[:pageSize]
+		last := strconv.FormatInt(periods[pageSize-1].ID, 10)
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+	}
+	for i := range periods {
+		resp.Prices = append(resp.Prices, pricePeriodProto(&periods[i]))
+	}
+	return resp, nil
+}
+
+// pricePeriodProto converts a stored price period to its wire form.
+func pricePeriodProto(p *store.PricePeriod) *pb.PricePeriod {
+	out := &pb.PricePeriod{
+		Sku:           p.Sku,
+		UnitPrice:     p.UnitPrice,
+		Source:        priceSourceProto(p.Source),
+		EffectiveFrom: timestamppb.New(p.EffectiveFrom),
+	}
+	if p.EffectiveTo != nil {
+		out.EffectiveTo = timestamppb.New(*p.EffectiveTo)
+	}
+	return out
+}
+
+// priceSourceProto maps a stored price source to its enum value.
+func priceSourceProto(source string) pb.PriceSource {
+	if v, ok := pb.PriceSource_value["PRICE_SOURCE_"+source]; ok {
+		return pb.PriceSource(v)
+	}
+	return pb.PriceSource_PRICE_SOURCE_UNSPECIFIED
+}
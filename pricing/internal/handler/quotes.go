@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"auto_grocery/pricing/internal/store"
+	pb "auto_grocery/pricing/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateQuote locks the current catalog prices of a cart for quoteTTL.
+func (h *PricingHandler) CreateQuote(ctx context.Context, req *pb.CreateQuoteRequest) (*pb.CreateQuoteResponse, error) {
+	log.Printf("[pricing] CreateQuote called items=%d", len(req.GetItems()))
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+	items := make(map[string]int32, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		if item.GetSku() == "" || item.GetQuantity() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid quantity %d for sku %q", item.GetQuantity(), item.GetSku())
+		}
+		items[item.GetSku()] += item.GetQuantity()
+	}
+
+	quote, err := h.store.CreateQuote(ctx, items, time.Now().Add(h.quoteTTL))
+	if errors.Is(err, store.ErrUnknownSKU) {
+		log.Printf("[pricing] CreateQuote rejected err=%v", err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		log.Printf("[pricing] CreateQuote failed err=%v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create quote: %v", err)
+	}
+
+	resp := &pb.CreateQuoteResponse{QuoteId: quote.ID, ExpiresAt: timestamppb.New(quote.ExpiresAt)}
+	for _, line := range quote.Lines {
+		totalPrice := line.UnitPrice * float64(line.Quantity)
+		resp.GrandTotal += totalPrice
+		resp.Items = append(resp.Items, &pb.LineItem{
+			Sku:        line.Sku,
+			UnitPrice:  line.UnitPrice,
+			Quantity:   line.Quantity,
+			TotalPrice: totalPrice,
+		})
+	}
+	log.Printf("[pricing] CreateQuote success quote=%s grand_total=%.2f expires_at=%s", quote.ID, resp.GrandTotal, quote.ExpiresAt.Format(time.RFC3339))
+	return resp, nil
+}
+
+// quotedPrices loads a quote's unit prices by sku. The quote's expiry is not checked: it bounds how long
+// the customer may take to confirm, while billing runs once the robots finish, well after confirmation.
+func (h *PricingHandler) quotedPrices(ctx context.Context, quoteID string) (map[string]float64, error) {
+	quote, err := h.store.GetQuote(ctx, quoteID)
+	if errors.Is(err, store.ErrQuoteNotFound) {
+		return nil, status.Errorf(codes.NotFound, "quote %s not found", quoteID)
+	} else if err != nil {
+		log.Printf("[pricing] load quote failed quote=%s err=%v", quoteID, err)
+		return nil, status.Errorf(codes.Internal, "failed to load quote: %v", err)
+	}
+	prices := make(map[string]float64, len(quote.Lines))
+	for _, line := range quote.Lines {
+		prices[line.Sku] = line.UnitPrice
+	}
+	return prices, nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+
+	"github.com/lib/pq"
 )
 
 type Item struct {
@@ -22,9 +24,17 @@ func NewCatalogStore(db *sql.DB) *CatalogStore {
 	return &CatalogStore{db: db}
 }
 
-// UpsertItem inserts or updates a catalog sku and returns its row id.
-func (s *CatalogStore) UpsertItem(ctx context.Context, item Item) (int, error) {
-	log.Printf("[pricing-store] UpsertItem sku=%s unit_price=%.2f", item.Sku, item.UnitPrice)
+// UpsertItem inserts or updates a catalog sku and returns its row id. A new or changed price closes the
+// sku's current price period and opens one recorded with source, in the same transaction.
+func (s *CatalogStore) UpsertItem(ctx context.Context, item Item, source string) (int, error) {
+	log.Printf("[pricing-store] UpsertItem sku=%s unit_price=%.2f source=%s", item.Sku, item.UnitPrice, source)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert item %s: %w", item.Sku, err)
+	}
+	defer tx.Rollback()
+
+	// The upsert locks the catalog row, so concurrent price changes for one sku queue up here.
 	query := `
         INSERT INTO catalog (sku, unit_price)
         VALUES ($1, $2)
@@ -35,12 +45,19 @@ func (s *CatalogStore) UpsertItem(ctx context.Context, item Item) (int, error) {
     `
 
 	var id int
-	err := s.db.QueryRowContext(ctx, query, item.Sku, item.UnitPrice).Scan(&id)
+	err = tx.QueryRowContext(ctx, query, item.Sku, item.UnitPrice).Scan(&id)
 
 	if err != nil {
 		log.Printf("[pricing-store] UpsertItem failed sku=%s err=%v", item.Sku, err)
 		return 0, fmt.Errorf("failed to upsert item %s: %w", item.Sku, err)
 	}
+	if err := recordPriceChange(ctx, tx, item.Sku, item.UnitPrice, source); err != nil {
+		log.Printf("[pricing-store] UpsertItem history failed sku=%s err=%v", item.Sku, err)
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to upsert item %s: %w", item.Sku, err)
+	}
 	log.Printf("[pricing-store] UpsertItem success sku=%s id=%d", item.Sku, id)
 
 	return id, nil
@@ -78,7 +95,7 @@ func (s *CatalogStore) GetItemsBySKUs(ctx context.Context, skus []string) (map[s
         FROM catalog
         WHERE sku = ANY($1)
     `
-	rows, err := s.db.QueryContext(ctx, query, skus)
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(skus))
 	if err != nil {
 		log.Printf("[pricing-store] GetItemsBySKUs query failed err=%v", err)
 		return nil, err
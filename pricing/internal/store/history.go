@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Price sources recorded in price_history.
+const (
+	SourceStockMetrics = "STOCK_METRICS"
+	SourceManual       = "MANUAL"
+	SourcePromo        = "PROMO"
+)
+
+// ErrNoPrice is returned when a sku had no price at the requested time.
+var ErrNoPrice = errors.New("no price")
+
+// PricePeriod is one price a sku had; EffectiveTo is nil while the price is current.
+type PricePeriod struct {
+	ID            int64
+	Sku           string
+	UnitPrice     float64
+	Source        string
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+}
+
+// HistoryFilter narrows ListPriceHistory to periods overlapping [From, To); zero times are open-ended.
+type HistoryFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+// recordPriceChange closes the sku's current period and opens a new one, unless the price is unchanged.
+// The caller must hold the sku's catalog row lock.
+func recordPriceChange(ctx context.Context, tx *sql.Tx, sku string, unitPrice float64, source string) error {
+	// Read the clock after the lock is held: NOW() is the transaction start, which can predate the period
+	// a concurrent writer opened while we waited. One value for both statements makes the periods meet.
+	var now time.Time
+	if err := tx.QueryRowContext(ctx, `SELECT clock_timestamp()`).Scan(&now); err != nil {
+		return fmt.Errorf("failed to read clock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+        UPDATE price_history
+        SET effective_to = GREATEST($3, effective_from)
+        WHERE sku = $1 AND effective_to IS NULL AND unit_price <> $2::numeric(10, 2)
+    `, sku, unitPrice, now); err != nil {
+		return fmt.Errorf("failed to close price period: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+        INSERT INTO price_history (sku, unit_price, source, effective_from)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (sku) WHERE effective_to IS NULL DO NOTHING
+    `, sku, unitPrice, source, now); err != nil {
+		return fmt.Errorf("failed to open price period: %w", err)
+	}
+	return nil
+}
+
+// GetPriceAt returns the price period of sku that was in effect at the given time, or ErrNoPrice.
+func (s *CatalogStore) GetPriceAt(ctx context.Context, sku string, at time.Time) (*PricePeriod, error) {
+	log.Printf("[pricing-store] GetPriceAt sku=%s at=%s", sku, at.Format(time.RFC3339))
+	var p PricePeriod
+	var to sql.NullTime
+	err := s.db.QueryRowContext(ctx, `
+        SELECT id, sku, unit_price, source, effective_from, effective_to
+        FROM price_history
+        WHERE sku = $1 AND effective_from <= $2 AND (effective_to IS NULL OR effective_to > $2)
+        ORDER BY effective_from DESC, id DESC
+        LIMIT 1
+    `, sku, at).Scan(&p.ID, &p.Sku, &p.UnitPrice, &p.Source, &p.EffectiveFrom, &to)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoPrice
+	} else if err != nil {
+		log.Printf("[pricing-store] GetPriceAt failed sku=%s err=%v", sku, err)
+		return nil, fmt.Errorf("failed to get price at %s: %w", at.Format(time.RFC3339), err)
+	}
+	if to.Valid {
+		p.EffectiveTo = &to.Time
+	}
+	return &p, nil
+}
+
+// ListPriceHistory pages through a sku's price periods newest first, starting below the period id before
+// (0 for the first page).
+func (s *CatalogStore) ListPriceHistory(ctx context.Context, sku string, filter HistoryFilter, before int64, limit int) ([]PricePeriod, error) {
+	log.Printf("[pricing-store] ListPriceHistory sku=%s before=%d limit=%d", sku, before, limit)
+	var from, to sql.NullTime
+	if !filter.From.IsZero() {
+		from = sql.NullTime{Time: filter.From, Valid: true}
+	}
+	if !filter.To.IsZero() {
+		to = sql.NullTime{Time: filter.To, Valid: true}
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT id, sku, unit_price, source, effective_from, effective_to
+        FROM price_history
+        WHERE sku = $1
+          AND ($2::bigint = 0 OR id < $2)
+          AND ($3::timestamptz IS NULL OR effective_to IS NULL OR effective_to > $3)
+          AND ($4::timestamptz IS NULL OR effective_from < $4)
+        ORDER BY id DESC
+        LIMIT $5
+    `, sku, before, from, to, limit)
+	if err != nil {
+		log.Printf("[pricing-store] ListPriceHistory query failed err=%v", err)
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []PricePeriod
+	for rows.Next() {
+		var p PricePeriod
+		var end sql.NullTime
+		if err := rows.Scan(&p.ID, &p.Sku, &p.UnitPrice, &p.Source, &p.EffectiveFrom, &end); err != nil {
+			return nil, fmt.Errorf("failed to scan price history: %w", err)
+		}
+		if end.Valid {
+			p.EffectiveTo = &end.Time
+		}
+		periods = append(periods, p)
+	}
+	return periods, rows.Err()
+}
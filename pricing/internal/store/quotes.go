@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrQuoteNotFound is returned when no quote has the requested id.
+	ErrQuoteNotFound = errors.New("quote not found")
+	// ErrUnknownSKU is returned when a quoted sku is missing from the catalog.
+	ErrUnknownSKU = errors.New("sku not found in catalog")
+)
+
+// Quote locks the unit price of each line for the order it was created for.
+type Quote struct {
+	ID        string
+	Lines     []QuoteLine // sorted by sku
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// QuoteLine is one quoted sku.
+type QuoteLine struct {
+	Sku       string
+	Quantity  int32
+	UnitPrice float64
+}
+
+// CreateQuote stores a new quote pricing items (sku -> quantity) at the catalog's current prices.
+// It returns ErrUnknownSKU, naming the sku, when one is not in the catalog.
+func (s *CatalogStore) CreateQuote(ctx context.Context, items map[string]int32, expiresAt time.Time) (*Quote, error) {
+	log.Printf("[pricing-store] CreateQuote items=%v expires_at=%s", items, expiresAt.Format(time.RFC3339))
+	skus := slices.Sorted(maps.Keys(items))
+	quantities := make([]int32, len(skus))
+	for i, sku := range skus {
+		quantities[i] = items[sku]
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+	defer tx.Rollback()
+
+	q := Quote{ID: uuid.New().String()}
+	if err := tx.QueryRowContext(ctx, `
+        INSERT INTO price_quotes (quote_id, expires_at)
+        VALUES ($1, $2)
+        RETURNING created_at, expires_at
+    `, q.ID, expiresAt).Scan(&q.CreatedAt, &q.ExpiresAt); err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	// Copy the prices straight from the catalog so the quote holds exactly what a bill would have charged.
+	rows, err := tx.QueryContext(ctx, `
+        INSERT INTO price_quote_lines (quote_id, sku, quantity, unit_price)
+        SELECT $1, c.sku, q.quantity, c.unit_price
+        FROM unnest($2::text[], $3::int[]) AS q(sku, quantity)
+        JOIN catalog c ON c.sku = q.sku
+        RETURNING sku, quantity, unit_price
+    `, q.ID, pq.Array(skus), pq.Array(quantities))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote lines: %w", err)
+	}
+	quoted := make(map[string]QuoteLine, len(skus))
+	for rows.Next() {
+		var line QuoteLine
+		if err := rows.Scan(&line.Sku, &line.Quantity, &line.UnitPrice); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan quote line: %w", err)
+		}
+		quoted[line.Sku] = line
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to create quote lines: %w", err)
+	}
+
+	for _, sku := range skus {
+		line, ok := quoted[sku]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSKU, sku)
+		}
+		q.Lines = append(q.Lines, line)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+	log.Printf("[pricing-store] CreateQuote success quote=%s lines=%d", q.ID, len(q.Lines))
+	return &q, nil
+}
+
+// GetQuote loads a quote with its lines, or returns ErrQuoteNotFound.
+func (s *CatalogStore) GetQuote(ctx context.Context, quoteID string) (*Quote, error) {
+	log.Printf("[pricing-store] GetQuote quote=%s", quoteID)
+	q := Quote{ID: quoteID}
+	err := s.db.QueryRowContext(ctx, `
+        SELECT created_at, expires_at
+        FROM price_quotes
+        WHERE quote_id = $1
+    `, quoteID).Scan(&q.CreatedAt, &q.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuoteNotFound
+	} else if err != nil {
+		log.Printf("[pricing-store] GetQuote failed quote=%s err=%v", quoteID, err)
+		return nil, fmt.Errorf("failed to get quote %s: %w", quoteID, err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+        SELECT sku, quantity, unit_price
+        FROM price_quote_lines
+        WHERE quote_id = $1
+        ORDER BY sku
+    `, quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote lines %s: %w", quoteID, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var line QuoteLine
+		if err := rows.Scan(&line.Sku, &line.Quantity, &line.UnitPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan quote line: %w", err)
+		}
+		q.Lines = append(q.Lines, line)
+	}
+	return &q, rows.Err()
+}
@@ -9,6 +9,7 @@ package pricingpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PriceSource records what set a price.
+type PriceSource int32
+
+const (
+	PriceSource_PRICE_SOURCE_UNSPECIFIED   PriceSource = 0
+	PriceSource_PRICE_SOURCE_STOCK_METRICS PriceSource = 1 // UpdateStockMetrics from inventory
+	PriceSource_PRICE_SOURCE_MANUAL        PriceSource = 2
+	PriceSource_PRICE_SOURCE_PROMO         PriceSource = 3
+)
+
+// Enum value maps for PriceSource.
+var (
+	PriceSource_name = map[int32]string{
+		0: "PRICE_SOURCE_UNSPECIFIED",
+		1: "PRICE_SOURCE_STOCK_METRICS",
+		2: "PRICE_SOURCE_MANUAL",
+		3: "PRICE_SOURCE_PROMO",
+	}
+	PriceSource_value = map[string]int32{
+		"PRICE_SOURCE_UNSPECIFIED":   0,
+		"PRICE_SOURCE_STOCK_METRICS": 1,
+		"PRICE_SOURCE_MANUAL":        2,
+		"PRICE_SOURCE_PROMO":         3,
+	}
+)
+
+func (x PriceSource) Enum() *PriceSource {
+	p := new(PriceSource)
+	*p = x
+	return p
+}
+
+func (x PriceSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_pricing_proto_pricing_proto_enumTypes[0].Descriptor()
+}
+
+func (PriceSource) Type() protoreflect.EnumType {
+	return &file_pricing_proto_pricing_proto_enumTypes[0]
+}
+
+func (x PriceSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceSource.Descriptor instead.
+func (PriceSource) EnumDescriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{0}
+}
+
 type GetPriceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
@@ -147,6 +201,7 @@ type CreateItemRequest struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Brand         string                 `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
 	UnitPrice     float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Source        PriceSource            `protobuf:"varint,5,opt,name=source,proto3,enum=pricing.PriceSource" json:"source,omitempty"` // MANUAL (default) or PROMO
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,6 +264,13 @@ func (x *CreateItemRequest) GetUnitPrice() float64 {
 	return 0
 }
 
+func (x *CreateItemRequest) GetSource() PriceSource {
+	if x != nil {
+		return x.Source
+	}
+	return PriceSource_PRICE_SOURCE_UNSPECIFIED
+}
+
 type CreateItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -308,6 +370,7 @@ func (x *CartItem) GetQuantity() int32 {
 type CalculateBillRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*CartItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	QuoteId       string                 `protobuf:"bytes,2,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"` // bill quoted skus at the quote's locked prices, the rest at current prices
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -349,6 +412,13 @@ func (x *CalculateBillRequest) GetItems() []*CartItem {
 	return nil
 }
 
+func (x *CalculateBillRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
 type LineItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
@@ -429,6 +499,7 @@ type CalculateBillResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*LineItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	GrandTotal    float64                `protobuf:"fixed64,2,opt,name=grand_total,json=grandTotal,proto3" json:"grand_total,omitempty"`
+	QuoteId       string                 `protobuf:"bytes,3,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"` // echoed when the bill used a quote
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -477,6 +548,13 @@ func (x *CalculateBillResponse) GetGrandTotal() float64 {
 	return 0
 }
 
+func (x *CalculateBillResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
 type StockMetric struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
@@ -633,11 +711,513 @@ func (x *UpdateStockMetricsResponse) GetUpdatedCount() int32 {
 	return 0
 }
 
+type GetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skus          []string               `protobuf:"bytes,1,rep,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPricesRequest) Reset() {
+	*x = GetPricesRequest{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricesRequest) ProtoMessage() {}
+
+func (x *GetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricesRequest.ProtoReflect.Descriptor instead.
+func (*GetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPricesRequest) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+type GetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        map[string]float64     `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // sku -> unit_price; skus missing from the catalog are omitted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPricesResponse) Reset() {
+	*x = GetPricesResponse{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPricesResponse) ProtoMessage() {}
+
+func (x *GetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPricesResponse.ProtoReflect.Descriptor instead.
+func (*GetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPricesResponse) GetPrices() map[string]float64 {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+// PricePeriod is one price a sku had and when it applied.
+type PricePeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,2,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Source        PriceSource            `protobuf:"varint,3,opt,name=source,proto3,enum=pricing.PriceSource" json:"source,omitempty"`
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"` // inclusive
+	EffectiveTo   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=effective_to,json=effectiveTo,proto3" json:"effective_to,omitempty"`       // exclusive; unset while the price is current
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricePeriod) Reset() {
+	*x = PricePeriod{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricePeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricePeriod) ProtoMessage() {}
+
+func (x *PricePeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricePeriod.ProtoReflect.Descriptor instead.
+func (*PricePeriod) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PricePeriod) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *PricePeriod) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *PricePeriod) GetSource() PriceSource {
+	if x != nil {
+		return x.Source
+	}
+	return PriceSource_PRICE_SOURCE_UNSPECIFIED
+}
+
+func (x *PricePeriod) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *PricePeriod) GetEffectiveTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveTo
+	}
+	return nil
+}
+
+type GetPriceAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"` // defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceAtRequest) Reset() {
+	*x = GetPriceAtRequest{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceAtRequest) ProtoMessage() {}
+
+func (x *GetPriceAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceAtRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceAtRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPriceAtRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *GetPriceAtRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type GetPriceAtResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         *PricePeriod           `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceAtResponse) Reset() {
+	*x = GetPriceAtResponse{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceAtResponse) ProtoMessage() {}
+
+func (x *GetPriceAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceAtResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceAtResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetPriceAtResponse) GetPrice() *PricePeriod {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type ListPriceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                            // only periods still effective at or after this time
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                // only periods that started before this time
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // default 50, max 200
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceHistoryRequest) Reset() {
+	*x = ListPriceHistoryRequest{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceHistoryRequest) ProtoMessage() {}
+
+func (x *ListPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPriceHistoryRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ListPriceHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListPriceHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListPriceHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPriceHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*PricePeriod         `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"` // newest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceHistoryResponse) Reset() {
+	*x = ListPriceHistoryResponse{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceHistoryResponse) ProtoMessage() {}
+
+func (x *ListPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPriceHistoryResponse) GetPrices() []*PricePeriod {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *ListPriceHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CartItem            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQuoteRequest) Reset() {
+	*x = CreateQuoteRequest{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuoteRequest) ProtoMessage() {}
+
+func (x *CreateQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateQuoteRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// CreateQuoteResponse locks the current price of every line until the quote is billed.
+type CreateQuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Items         []*LineItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"` // sorted by sku
+	GrandTotal    float64                `protobuf:"fixed64,3,opt,name=grand_total,json=grandTotal,proto3" json:"grand_total,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // the customer must confirm before this
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQuoteResponse) Reset() {
+	*x = CreateQuoteResponse{}
+	mi := &file_pricing_proto_pricing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuoteResponse) ProtoMessage() {}
+
+func (x *CreateQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_pricing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuoteResponse.ProtoReflect.Descriptor instead.
+func (*CreateQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_pricing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateQuoteResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *CreateQuoteResponse) GetItems() []*LineItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateQuoteResponse) GetGrandTotal() float64 {
+	if x != nil {
+		return x.GrandTotal
+	}
+	return 0
+}
+
+func (x *CreateQuoteResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 var File_pricing_proto_pricing_proto protoreflect.FileDescriptor
 
 const file_pricing_proto_pricing_proto_rawDesc = "" +
 	"\n" +
-	"\x1bpricing/proto/pricing.proto\x12\apricing\"#\n" +
+	"\x1bpricing/proto/pricing.proto\x12\apricing\x1a\x1fgoogle/protobuf/timestamp.proto\"#\n" +
 	"\x0fGetPriceRequest\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\"}\n" +
 	"\x10GetPriceResponse\x12\x0e\n" +
@@ -646,20 +1226,22 @@ const file_pricing_proto_pricing_proto_rawDesc = "" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
 	"\x05brand\x18\x04 \x01(\tR\x05brand\x12\x1d\n" +
 	"\n" +
-	"unit_price\x18\x05 \x01(\x01R\tunitPrice\"n\n" +
+	"unit_price\x18\x05 \x01(\x01R\tunitPrice\"\x9c\x01\n" +
 	"\x11CreateItemRequest\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05brand\x18\x03 \x01(\tR\x05brand\x12\x1d\n" +
 	"\n" +
-	"unit_price\x18\x04 \x01(\x01R\tunitPrice\"$\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12,\n" +
+	"\x06source\x18\x05 \x01(\x0e2\x14.pricing.PriceSourceR\x06source\"$\n" +
 	"\x12CreateItemResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"8\n" +
 	"\bCartItem\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"?\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"Z\n" +
 	"\x14CalculateBillRequest\x12'\n" +
-	"\x05items\x18\x01 \x03(\v2\x11.pricing.CartItemR\x05items\"\x8c\x01\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.pricing.CartItemR\x05items\x12\x19\n" +
+	"\bquote_id\x18\x02 \x01(\tR\aquoteId\"\x8c\x01\n" +
 	"\bLineItem\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -667,11 +1249,12 @@ const file_pricing_proto_pricing_proto_rawDesc = "" +
 	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1a\n" +
 	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1f\n" +
 	"\vtotal_price\x18\x05 \x01(\x01R\n" +
-	"totalPrice\"a\n" +
+	"totalPrice\"|\n" +
 	"\x15CalculateBillResponse\x12'\n" +
 	"\x05items\x18\x01 \x03(\v2\x11.pricing.LineItemR\x05items\x12\x1f\n" +
 	"\vgrand_total\x18\x02 \x01(\x01R\n" +
-	"grandTotal\"X\n" +
+	"grandTotal\x12\x19\n" +
+	"\bquote_id\x18\x03 \x01(\tR\aquoteId\"X\n" +
 	"\vStockMetric\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
 	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1b\n" +
@@ -680,13 +1263,61 @@ const file_pricing_proto_pricing_proto_rawDesc = "" +
 	"\aupdates\x18\x01 \x03(\v2\x14.pricing.StockMetricR\aupdates\"[\n" +
 	"\x1aUpdateStockMetricsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rupdated_count\x18\x02 \x01(\x05R\fupdatedCount2\xc7\x02\n" +
+	"\rupdated_count\x18\x02 \x01(\x05R\fupdatedCount\"&\n" +
+	"\x10GetPricesRequest\x12\x12\n" +
+	"\x04skus\x18\x01 \x03(\tR\x04skus\"\x8e\x01\n" +
+	"\x11GetPricesResponse\x12>\n" +
+	"\x06prices\x18\x01 \x03(\v2&.pricing.GetPricesResponse.PricesEntryR\x06prices\x1a9\n" +
+	"\vPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xee\x01\n" +
+	"\vPricePeriod\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x02 \x01(\x01R\tunitPrice\x12,\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x14.pricing.PriceSourceR\x06source\x12A\n" +
+	"\x0eeffective_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12=\n" +
+	"\feffective_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveTo\"Q\n" +
+	"\x11GetPriceAtRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"@\n" +
+	"\x12GetPriceAtResponse\x12*\n" +
+	"\x05price\x18\x01 \x01(\v2\x14.pricing.PricePeriodR\x05price\"\xc3\x01\n" +
+	"\x17ListPriceHistoryRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"p\n" +
+	"\x18ListPriceHistoryResponse\x12,\n" +
+	"\x06prices\x18\x01 \x03(\v2\x14.pricing.PricePeriodR\x06prices\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"=\n" +
+	"\x12CreateQuoteRequest\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.pricing.CartItemR\x05items\"\xb5\x01\n" +
+	"\x13CreateQuoteResponse\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12'\n" +
+	"\x05items\x18\x02 \x03(\v2\x11.pricing.LineItemR\x05items\x12\x1f\n" +
+	"\vgrand_total\x18\x03 \x01(\x01R\n" +
+	"grandTotal\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt*|\n" +
+	"\vPriceSource\x12\x1c\n" +
+	"\x18PRICE_SOURCE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aPRICE_SOURCE_STOCK_METRICS\x10\x01\x12\x17\n" +
+	"\x13PRICE_SOURCE_MANUAL\x10\x02\x12\x16\n" +
+	"\x12PRICE_SOURCE_PROMO\x10\x032\xf5\x04\n" +
 	"\x0ePricingService\x12?\n" +
 	"\bGetPrice\x12\x18.pricing.GetPriceRequest\x1a\x19.pricing.GetPriceResponse\x12E\n" +
 	"\n" +
 	"CreateItem\x12\x1a.pricing.CreateItemRequest\x1a\x1b.pricing.CreateItemResponse\x12N\n" +
 	"\rCalculateBill\x12\x1d.pricing.CalculateBillRequest\x1a\x1e.pricing.CalculateBillResponse\x12]\n" +
-	"\x12UpdateStockMetrics\x12\".pricing.UpdateStockMetricsRequest\x1a#.pricing.UpdateStockMetricsResponseB&Z$auto_grocery/pricing/proto;pricingpbb\x06proto3"
+	"\x12UpdateStockMetrics\x12\".pricing.UpdateStockMetricsRequest\x1a#.pricing.UpdateStockMetricsResponse\x12B\n" +
+	"\tGetPrices\x12\x19.pricing.GetPricesRequest\x1a\x1a.pricing.GetPricesResponse\x12E\n" +
+	"\n" +
+	"GetPriceAt\x12\x1a.pricing.GetPriceAtRequest\x1a\x1b.pricing.GetPriceAtResponse\x12W\n" +
+	"\x10ListPriceHistory\x12 .pricing.ListPriceHistoryRequest\x1a!.pricing.ListPriceHistoryResponse\x12H\n" +
+	"\vCreateQuote\x12\x1b.pricing.CreateQuoteRequest\x1a\x1c.pricing.CreateQuoteResponseB&Z$auto_grocery/pricing/proto;pricingpbb\x06proto3"
 
 var (
 	file_pricing_proto_pricing_proto_rawDescOnce sync.Once
@@ -700,37 +1331,71 @@ func file_pricing_proto_pricing_proto_rawDescGZIP() []byte {
 	return file_pricing_proto_pricing_proto_rawDescData
 }
 
-var file_pricing_proto_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pricing_proto_pricing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pricing_proto_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_pricing_proto_pricing_proto_goTypes = []any{
-	(*GetPriceRequest)(nil),            // 0: pricing.GetPriceRequest
-	(*GetPriceResponse)(nil),           // 1: pricing.GetPriceResponse
-	(*CreateItemRequest)(nil),          // 2: pricing.CreateItemRequest
-	(*CreateItemResponse)(nil),         // 3: pricing.CreateItemResponse
-	(*CartItem)(nil),                   // 4: pricing.CartItem
-	(*CalculateBillRequest)(nil),       // 5: pricing.CalculateBillRequest
-	(*LineItem)(nil),                   // 6: pricing.LineItem
-	(*CalculateBillResponse)(nil),      // 7: pricing.CalculateBillResponse
-	(*StockMetric)(nil),                // 8: pricing.StockMetric
-	(*UpdateStockMetricsRequest)(nil),  // 9: pricing.UpdateStockMetricsRequest
-	(*UpdateStockMetricsResponse)(nil), // 10: pricing.UpdateStockMetricsResponse
+	(PriceSource)(0),                   // 0: pricing.PriceSource
+	(*GetPriceRequest)(nil),            // 1: pricing.GetPriceRequest
+	(*GetPriceResponse)(nil),           // 2: pricing.GetPriceResponse
+	(*CreateItemRequest)(nil),          // 3: pricing.CreateItemRequest
+	(*CreateItemResponse)(nil),         // 4: pricing.CreateItemResponse
+	(*CartItem)(nil),                   // 5: pricing.CartItem
+	(*CalculateBillRequest)(nil),       // 6: pricing.CalculateBillRequest
+	(*LineItem)(nil),                   // 7: pricing.LineItem
+	(*CalculateBillResponse)(nil),      // 8: pricing.CalculateBillResponse
+	(*StockMetric)(nil),                // 9: pricing.StockMetric
+	(*UpdateStockMetricsRequest)(nil),  // 10: pricing.UpdateStockMetricsRequest
+	(*UpdateStockMetricsResponse)(nil), // 11: pricing.UpdateStockMetricsResponse
+	(*GetPricesRequest)(nil),           // 12: pricing.GetPricesRequest
+	(*GetPricesResponse)(nil),          // 13: pricing.GetPricesResponse
+	(*PricePeriod)(nil),                // 14: pricing.PricePeriod
+	(*GetPriceAtRequest)(nil),          // 15: pricing.GetPriceAtRequest
+	(*GetPriceAtResponse)(nil),         // 16: pricing.GetPriceAtResponse
+	(*ListPriceHistoryRequest)(nil),    // 17: pricing.ListPriceHistoryRequest
+	(*ListPriceHistoryResponse)(nil),   // 18: pricing.ListPriceHistoryResponse
+	(*CreateQuoteRequest)(nil),         // 19: pricing.CreateQuoteRequest
+	(*CreateQuoteResponse)(nil),        // 20: pricing.CreateQuoteResponse
+	nil,                                // 21: pricing.GetPricesResponse.PricesEntry
+	(*timestamppb.Timestamp)(nil),      // 22: google.protobuf.Timestamp
 }
 var file_pricing_proto_pricing_proto_depIdxs = []int32{
-	4,  // 0: pricing.CalculateBillRequest.items:type_name -> pricing.CartItem
-	6,  // 1: pricing.CalculateBillResponse.items:type_name -> pricing.LineItem
-	8,  // 2: pricing.UpdateStockMetricsRequest.updates:type_name -> pricing.StockMetric
-	0,  // 3: pricing.PricingService.GetPrice:input_type -> pricing.GetPriceRequest
-	2,  // 4: pricing.PricingService.CreateItem:input_type -> pricing.CreateItemRequest
-	5,  // 5: pricing.PricingService.CalculateBill:input_type -> pricing.CalculateBillRequest
-	9,  // 6: pricing.PricingService.UpdateStockMetrics:input_type -> pricing.UpdateStockMetricsRequest
-	1,  // 7: pricing.PricingService.GetPrice:output_type -> pricing.GetPriceResponse
-	3,  // 8: pricing.PricingService.CreateItem:output_type -> pricing.CreateItemResponse
-	7,  // 9: pricing.PricingService.CalculateBill:output_type -> pricing.CalculateBillResponse
-	10, // 10: pricing.PricingService.UpdateStockMetrics:output_type -> pricing.UpdateStockMetricsResponse
-	7,  // [7:11] is the sub-list for method output_type
-	3,  // [3:7] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: pricing.CreateItemRequest.source:type_name -> pricing.PriceSource
+	5,  // 1: pricing.CalculateBillRequest.items:type_name -> pricing.CartItem
+	7,  // 2: pricing.CalculateBillResponse.items:type_name -> pricing.LineItem
+	9,  // 3: pricing.UpdateStockMetricsRequest.updates:type_name -> pricing.StockMetric
+	21, // 4: pricing.GetPricesResponse.prices:type_name -> pricing.GetPricesResponse.PricesEntry
+	0,  // 5: pricing.PricePeriod.source:type_name -> pricing.PriceSource
+	22, // 6: pricing.PricePeriod.effective_from:type_name -> google.protobuf.Timestamp
+	22, // 7: pricing.PricePeriod.effective_to:type_name -> google.protobuf.Timestamp
+	22, // 8: pricing.GetPriceAtRequest.at:type_name -> google.protobuf.Timestamp
+	14, // 9: pricing.GetPriceAtResponse.price:type_name -> pricing.PricePeriod
+	22, // 10: pricing.ListPriceHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	22, // 11: pricing.ListPriceHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	14, // 12: pricing.ListPriceHistoryResponse.prices:type_name -> pricing.PricePeriod
+	5,  // 13: pricing.CreateQuoteRequest.items:type_name -> pricing.CartItem
+	7,  // 14: pricing.CreateQuoteResponse.items:type_name -> pricing.LineItem
+	22, // 15: pricing.CreateQuoteResponse.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 16: pricing.PricingService.GetPrice:input_type -> pricing.GetPriceRequest
+	3,  // 17: pricing.PricingService.CreateItem:input_type -> pricing.CreateItemRequest
+	6,  // 18: pricing.PricingService.CalculateBill:input_type -> pricing.CalculateBillRequest
+	10, // 19: pricing.PricingService.UpdateStockMetrics:input_type -> pricing.UpdateStockMetricsRequest
+	12, // 20: pricing.PricingService.GetPrices:input_type -> pricing.GetPricesRequest
+	15, // 21: pricing.PricingService.GetPriceAt:input_type -> pricing.GetPriceAtRequest
+	17, // 22: pricing.PricingService.ListPriceHistory:input_type -> pricing.ListPriceHistoryRequest
+	19, // 23: pricing.PricingService.CreateQuote:input_type -> pricing.CreateQuoteRequest
+	2,  // 24: pricing.PricingService.GetPrice:output_type -> pricing.GetPriceResponse
+	4,  // 25: pricing.PricingService.CreateItem:output_type -> pricing.CreateItemResponse
+	8,  // 26: pricing.PricingService.CalculateBill:output_type -> pricing.CalculateBillResponse
+	11, // 27: pricing.PricingService.UpdateStockMetrics:output_type -> pricing.UpdateStockMetricsResponse
+	13, // 28: pricing.PricingService.GetPrices:output_type -> pricing.GetPricesResponse
+	16, // 29: pricing.PricingService.GetPriceAt:output_type -> pricing.GetPriceAtResponse
+	18, // 30: pricing.PricingService.ListPriceHistory:output_type -> pricing.ListPriceHistoryResponse
+	20, // 31: pricing.PricingService.CreateQuote:output_type -> pricing.CreateQuoteResponse
+	24, // [24:32] is the sub-list for method output_type
+	16, // [16:24] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_pricing_proto_pricing_proto_init() }
@@ -743,13 +1408,14 @@ func file_pricing_proto_pricing_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pricing_proto_pricing_proto_rawDesc), len(file_pricing_proto_pricing_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pricing_proto_pricing_proto_goTypes,
 		DependencyIndexes: file_pricing_proto_pricing_proto_depIdxs,
+		EnumInfos:         file_pricing_proto_pricing_proto_enumTypes,
 		MessageInfos:      file_pricing_proto_pricing_proto_msgTypes,
 	}.Build()
 	File_pricing_proto_pricing_proto = out.File
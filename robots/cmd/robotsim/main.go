@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	pb "auto_grocery/inventory/proto"
+
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func getenv(key, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// main starts one simulated robot per requested aisle against a running inventory service.
+func main() {
+	_ = godotenv.Load("robots/.env")
+
+	aislesFlag := flag.String("aisles", "", "comma-separated aisles to serve (required), e.g. bread,dairy")
+	inventoryAddr := flag.String("inventory", getenv("INVENTORY_GRPC_ADDR", "localhost:50051"), "inventory gRPC address")
+	zmqAddr := flag.String("zmq", getenv("ROBOT_ZMQ_SUB_ADDR", "tcp://localhost:5556"), "inventory robot ZMQ PUB address")
+	idPrefix := flag.String("id-prefix", "sim", "robot id prefix; ids are <prefix>-<aisle>")
+	latency := flag.Duration("latency", 500*time.Millisecond, "simulated pick latency per item")
+	failureRate := flag.Float64("failure-rate", 0, "probability [0,1] a task is reported FAILED with nothing picked (inventory re-dispatches it)")
+	shortRate := flag.Float64("short-rate", 0, "probability [0,1] each item is short-picked (task reported PARTIAL)")
+	duplicateRate := flag.Float64("duplicate-rate", 0, "probability [0,1] a status report is sent twice")
+	noAck := flag.Bool("no-ack", false, "do not acknowledge dispatches (exercises redelivery)")
+	noRegister := flag.Bool("no-register", false, "do not register with the fleet or heartbeat")
+	seed := flag.Uint64("seed", 0, "random seed for reproducible chaos runs (0 = time based)")
+	flag.Parse()
+
+	var aisles []string
+	for _, aisle := range strings.Split(*aislesFlag, ",") {
+		if aisle = strings.TrimSpace(aisle); aisle != "" {
+			aisles = append(aisles, aisle)
+		}
+	}
+	if len(aisles) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: robotsim -aisles bread,dairy [flags]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	for name, rate := range map[string]float64{"failure-rate": *failureRate, "short-rate": *shortRate, "duplicate-rate": *duplicateRate} {
+		if rate < 0 || rate > 1 {
+			log.Fatalf("[robotsim] -%s must be within [0,1], got %v", name, rate)
+		}
+	}
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+
+	conn, err := grpc.NewClient(*inventoryAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+	client := pb.NewInventoryServiceClient(conn)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("[robotsim] starting aisles=%v inventory=%s zmq=%s latency=%s failure=%.2f short=%.2f duplicate=%.2f seed=%d",
+		aisles, *inventoryAddr, *zmqAddr, *latency, *failureRate, *shortRate, *duplicateRate, *seed)
+
+	done := make(chan struct{}, len(aisles))
+	for i, aisle := range aisles {
+		robot := &simRobot{
+			id:            *idPrefix + "-" + aisle,
+			aisle:         aisle,
+			zmqAddr:       *zmqAddr,
+			client:        client,
+			latency:       *latency,
+			failureRate:   *failureRate,
+			shortRate:     *shortRate,
+			duplicateRate: *duplicateRate,
+			ack:           !*noAck,
+			register:      !*noRegister,
+			rng:           newRand(*seed + uint64(i)),
+		}
+		go func() {
+			if err := robot.run(ctx); err != nil {
+				log.Printf("[robotsim] ERROR robot=%s stopped err=%v", robot.id, err)
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	for range aisles {
+		<-done
+	}
+	log.Printf("[robotsim] all robots stopped")
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"syscall"
+	"time"
+
+	"auto_grocery/inventory/fbs/RobotMessages"
+	pb "auto_grocery/inventory/proto"
+
+	zmq "github.com/pebbe/zmq4"
+)
+
+// handledWindow bounds how many task keys a robot remembers to skip redeliveries.
+const handledWindow = 1000
+
+// simRobot serves a single aisle the way a robots/ worker does, with injectable faults.
+type simRobot struct {
+	id            string
+	aisle         string
+	zmqAddr       string
+	client        pb.InventoryServiceClient
+	latency       time.Duration
+	failureRate   float64
+	shortRate     float64
+	duplicateRate float64
+	ack           bool
+	register      bool
+	rng           *rand.Rand
+
+	handled    map[string]struct{}
+	handledLog []string
+}
+
+// newRand returns a deterministic generator for one robot.
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// run registers the robot, subscribes to its aisle topic, and processes tasks until ctx ends.
+func (r *simRobot) run(ctx context.Context) error {
+	if r.register {
+		interval := r.registerWithFleet(ctx)
+		go r.heartbeatLoop(ctx, interval)
+	}
+
+	sub, err := zmq.NewSocket(zmq.SUB)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+	if err := sub.Connect(r.zmqAddr); err != nil {
+		return err
+	}
+	if err := sub.SetSubscribe(r.aisle); err != nil {
+		return err
+	}
+	// Wake up periodically so shutdown is noticed between messages.
+	if err := sub.SetRcvtimeo(time.Second); err != nil {
+		return err
+	}
+	log.Printf("[robotsim] robot=%s subscribed topic=%s addr=%s", r.id, r.aisle, r.zmqAddr)
+
+	for ctx.Err() == nil {
+		parts, err := sub.RecvMessageBytes(0)
+		if err != nil {
+			if zmq.AsErrno(err) == zmq.Errno(syscall.EAGAIN) {
+				continue
+			}
+			return err
+		}
+		// SUB filtering is prefix-based, so only exact topic matches are ours.
+		if len(parts) != 2 || string(parts[0]) != r.aisle {
+			continue
+		}
+		r.handle(ctx, RobotMessages.GetRootAsOrderBroadcast(parts[1], 0))
+	}
+	return nil
+}
+
+// handle acknowledges, simulates, and reports one aisle task.
+func (r *simRobot) handle(ctx context.Context, broadcast *RobotMessages.OrderBroadcast) {
+	orderID := string(broadcast.OrderId())
+	orderType := string(broadcast.OrderType())
+	retry := broadcast.Retry()
+	log.Printf("[robotsim] robot=%s received order=%s type=%s retry=%d items=%d aisles_in_order=%d", r.id, orderID, orderType, retry, broadcast.ItemsLength(), broadcast.AislesLength())
+
+	if r.ack {
+		_, err := r.client.AcknowledgeDispatch(ctx, &pb.AcknowledgeDispatchRequest{
+			OrderId:   orderID,
+			OrderType: orderType,
+			Aisle:     r.aisle,
+			RobotId:   r.id,
+		})
+		if err != nil {
+			log.Printf("[robotsim] WARN robot=%s ack failed order=%s err=%v", r.id, orderID, err)
+		}
+	}
+	// A retry after a FAILED report is a new task even though the order is the same.
+	if !r.remember(fmt.Sprintf("%s:%s:%d", orderType, orderID, retry)) {
+		log.Printf("[robotsim] robot=%s skipping redelivered task order=%s", r.id, orderID)
+		return
+	}
+
+	req := &pb.ReportJobStatusRequest{
+		OrderId:        orderID,
+		RobotId:        r.id,
+		OrderType:      orderType,
+		Aisle:          r.aisle,
+		JobStatus:      pb.JobStatus_JOB_STATUS_NO_OP,
+		Retry:          retry,
+		ProcessedItems: map[string]int32{},
+	}
+
+	if r.roll(r.failureRate) {
+		log.Printf("[robotsim] robot=%s injecting failure order=%s", r.id, orderID)
+		req.JobStatus = pb.JobStatus_JOB_STATUS_FAILED
+	} else {
+		var item RobotMessages.Item
+		for i := 0; i < broadcast.ItemsLength(); i++ {
+			if !broadcast.Items(&item, i) || string(item.Aisle()) != r.aisle {
+				continue
+			}
+			if req.JobStatus == pb.JobStatus_JOB_STATUS_NO_OP {
+				req.JobStatus = pb.JobStatus_JOB_STATUS_SUCCESS
+			}
+			sku, qty := string(item.Sku()), item.Quantity()
+			var lot RobotMessages.LotPick
+			for j := 0; j < item.LotsLength(); j++ {
+				if item.Lots(&lot, j) {
+					log.Printf("[robotsim] robot=%s take lot=%d qty=%d order=%s sku=%s", r.id, lot.LotId(), lot.Quantity(), orderID, sku)
+				}
+			}
+			if qty > 0 && r.roll(r.shortRate) {
+				short := r.rng.Int32N(qty)
+				log.Printf("[robotsim] robot=%s injecting short pick order=%s sku=%s qty=%d picked=%d", r.id, orderID, sku, qty, short)
+				qty = short
+				req.JobStatus = pb.JobStatus_JOB_STATUS_PARTIAL
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.latency):
+			}
+			req.ProcessedItems[sku] = qty
+		}
+	}
+
+	r.report(ctx, req)
+	if r.roll(r.duplicateRate) {
+		log.Printf("[robotsim] robot=%s injecting duplicate report order=%s", r.id, orderID)
+		r.report(ctx, req)
+	}
+}
+
+// report sends one status report to inventory.
+func (r *simRobot) report(ctx context.Context, req *pb.ReportJobStatusRequest) {
+	resp, err := r.client.ReportJobStatus(ctx, req)
+	if err != nil {
+		log.Printf("[robotsim] ERROR robot=%s report failed order=%s err=%v", r.id, req.GetOrderId(), err)
+		return
+	}
+	log.Printf("[robotsim] robot=%s reported order=%s status=%s items=%v success=%t", r.id, req.GetOrderId(), req.GetJobStatus(), req.GetProcessedItems(), resp.GetSuccess())
+}
+
+// registerWithFleet registers until inventory answers and returns the heartbeat interval.
+func (r *simRobot) registerWithFleet(ctx context.Context) time.Duration {
+	for {
+		resp, err := r.client.RegisterRobot(ctx, &pb.RegisterRobotRequest{RobotId: r.id, Aisle: r.aisle})
+		if err == nil && resp.GetSuccess() {
+			interval := time.Duration(resp.GetHeartbeatIntervalSeconds()) * time.Second
+			if interval <= 0 {
+				interval = 10 * time.Second
+			}
+			log.Printf("[robotsim] robot=%s registered aisle=%s heartbeat=%s", r.id, r.aisle, interval)
+			return interval
+		}
+		log.Printf("[robotsim] WARN robot=%s register failed, retrying err=%v", r.id, err)
+		select {
+		case <-ctx.Done():
+			return time.Second
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// heartbeatLoop keeps the robot in the live fleet, registering again if inventory forgot it.
+func (r *simRobot) heartbeatLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			resp, err := r.client.RobotHeartbeat(ctx, &pb.RobotHeartbeatRequest{RobotId: r.id, Aisle: r.aisle})
+			if err != nil {
+				log.Printf("[robotsim] WARN robot=%s heartbeat failed err=%v", r.id, err)
+				continue
+			}
+			if !resp.GetRegistered() {
+				r.registerWithFleet(ctx)
+			}
+		}
+	}
+}
+
+// remember records a task key and reports whether it was new.
+func (r *simRobot) remember(key string) bool {
+	if r.handled == nil {
+		r.handled = make(map[string]struct{})
+	}
+	if _, seen := r.handled[key]; seen {
+		return false
+	}
+	r.handled[key] = struct{}{}
+	r.handledLog = append(r.handledLog, key)
+	if len(r.handledLog) > handledWindow {
+		delete(r.handled, r.handledLog[0])
+		r.handledLog = r.handledLog[1:]
+	}
+	return true
+}
+
+// roll returns true with the given probability.
+func (r *simRobot) roll(rate float64) bool {
+	return rate > 0 && r.rng.Float64() < rate
+}
@@ -15,18 +15,74 @@ static_assert(FLATBUFFERS_VERSION_MAJOR == 25 &&
 
 namespace RobotMessages {
 
+struct LotPick;
+struct LotPickBuilder;
+
 struct Item;
 struct ItemBuilder;
 
 struct OrderBroadcast;
 struct OrderBroadcastBuilder;
 
+struct LotPick FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
+  typedef LotPickBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_LOT_ID = 4,
+    VT_QUANTITY = 6
+  };
+  int64_t lot_id() const {
+    return GetField<int64_t>(VT_LOT_ID, 0);
+  }
+  int32_t quantity() const {
+    return GetField<int32_t>(VT_QUANTITY, 0);
+  }
+  template <bool B = false>
+  bool Verify(::flatbuffers::VerifierTemplate<B> &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyField<int64_t>(verifier, VT_LOT_ID, 8) &&
+           VerifyField<int32_t>(verifier, VT_QUANTITY, 4) &&
+           verifier.EndTable();
+  }
+};
+
+struct LotPickBuilder {
+  typedef LotPick Table;
+  ::flatbuffers::FlatBufferBuilder &fbb_;
+  ::flatbuffers::uoffset_t start_;
+  void add_lot_id(int64_t lot_id) {
+    fbb_.AddElement<int64_t>(LotPick::VT_LOT_ID, lot_id, 0);
+  }
+  void add_quantity(int32_t quantity) {
+    fbb_.AddElement<int32_t>(LotPick::VT_QUANTITY, quantity, 0);
+  }
+  explicit LotPickBuilder(::flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  ::flatbuffers::Offset<LotPick> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = ::flatbuffers::Offset<LotPick>(end);
+    return o;
+  }
+};
+
+inline ::flatbuffers::Offset<LotPick> CreateLotPick(
+    ::flatbuffers::FlatBufferBuilder &_fbb,
+    int64_t lot_id = 0,
+    int32_t quantity = 0) {
+  LotPickBuilder builder_(_fbb);
+  builder_.add_lot_id(lot_id);
+  builder_.add_quantity(quantity);
+  return builder_.Finish();
+}
+
 struct Item FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
   typedef ItemBuilder Builder;
   enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
     VT_SKU = 4,
     VT_QUANTITY = 6,
-    VT_AISLE = 8
+    VT_AISLE = 8,
+    VT_LOTS = 10
   };
   const ::flatbuffers::String *sku() const {
     return GetPointer<const ::flatbuffers::String *>(VT_SKU);
@@ -37,6 +93,9 @@ struct Item FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
   const ::flatbuffers::String *aisle() const {
     return GetPointer<const ::flatbuffers::String *>(VT_AISLE);
   }
+  const ::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::LotPick>> *lots() const {
+    return GetPointer<const ::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::LotPick>> *>(VT_LOTS);
+  }
   template <bool B = false>
   bool Verify(::flatbuffers::VerifierTemplate<B> &verifier) const {
     return VerifyTableStart(verifier) &&
@@ -45,6 +104,9 @@ struct Item FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
            VerifyField<int32_t>(verifier, VT_QUANTITY, 4) &&
            VerifyOffset(verifier, VT_AISLE) &&
            verifier.VerifyString(aisle()) &&
+           VerifyOffset(verifier, VT_LOTS) &&
+           verifier.VerifyVector(lots()) &&
+           verifier.VerifyVectorOfTables(lots()) &&
            verifier.EndTable();
   }
 };
@@ -62,6 +124,9 @@ struct ItemBuilder {
   void add_aisle(::flatbuffers::Offset<::flatbuffers::String> aisle) {
     fbb_.AddOffset(Item::VT_AISLE, aisle);
   }
+  void add_lots(::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::LotPick>>> lots) {
+    fbb_.AddOffset(Item::VT_LOTS, lots);
+  }
   explicit ItemBuilder(::flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -77,8 +142,10 @@ inline ::flatbuffers::Offset<Item> CreateItem(
     ::flatbuffers::FlatBufferBuilder &_fbb,
     ::flatbuffers::Offset<::flatbuffers::String> sku = 0,
     int32_t quantity = 0,
-    ::flatbuffers::Offset<::flatbuffers::String> aisle = 0) {
+    ::flatbuffers::Offset<::flatbuffers::String> aisle = 0,
+    ::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::LotPick>>> lots = 0) {
   ItemBuilder builder_(_fbb);
+  builder_.add_lots(lots);
   builder_.add_aisle(aisle);
   builder_.add_quantity(quantity);
   builder_.add_sku(sku);
@@ -89,14 +156,17 @@ inline ::flatbuffers::Offset<Item> CreateItemDirect(
     ::flatbuffers::FlatBufferBuilder &_fbb,
     const char *sku = nullptr,
     int32_t quantity = 0,
-    const char *aisle = nullptr) {
+    const char *aisle = nullptr,
+    const std::vector<::flatbuffers::Offset<RobotMessages::LotPick>> *lots = nullptr) {
   auto sku__ = sku ? _fbb.CreateString(sku) : 0;
   auto aisle__ = aisle ? _fbb.CreateString(aisle) : 0;
+  auto lots__ = lots ? _fbb.CreateVector<::flatbuffers::Offset<RobotMessages::LotPick>>(*lots) : 0;
   return RobotMessages::CreateItem(
       _fbb,
       sku__,
       quantity,
-      aisle__);
+      aisle__,
+      lots__);
 }
 
 struct OrderBroadcast FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
@@ -104,7 +174,9 @@ struct OrderBroadcast FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
   enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
     VT_ORDER_ID = 4,
     VT_ORDER_TYPE = 6,
-    VT_ITEMS = 8
+    VT_ITEMS = 8,
+    VT_AISLES = 10,
+    VT_RETRY = 12
   };
   const ::flatbuffers::String *order_id() const {
     return GetPointer<const ::flatbuffers::String *>(VT_ORDER_ID);
@@ -115,6 +187,12 @@ struct OrderBroadcast FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
   const ::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::Item>> *items() const {
     return GetPointer<const ::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::Item>> *>(VT_ITEMS);
   }
+  const ::flatbuffers::Vector<::flatbuffers::Offset<::flatbuffers::String>> *aisles() const {
+    return GetPointer<const ::flatbuffers::Vector<::flatbuffers::Offset<::flatbuffers::String>> *>(VT_AISLES);
+  }
+  int32_t retry() const {
+    return GetField<int32_t>(VT_RETRY, 0);
+  }
   template <bool B = false>
   bool Verify(::flatbuffers::VerifierTemplate<B> &verifier) const {
     return VerifyTableStart(verifier) &&
@@ -125,6 +203,10 @@ struct OrderBroadcast FLATBUFFERS_FINAL_CLASS : private ::flatbuffers::Table {
            VerifyOffset(verifier, VT_ITEMS) &&
            verifier.VerifyVector(items()) &&
            verifier.VerifyVectorOfTables(items()) &&
+           VerifyOffset(verifier, VT_AISLES) &&
+           verifier.VerifyVector(aisles()) &&
+           verifier.VerifyVectorOfStrings(aisles()) &&
+           VerifyField<int32_t>(verifier, VT_RETRY, 4) &&
            verifier.EndTable();
   }
 };
@@ -142,6 +224,12 @@ struct OrderBroadcastBuilder {
   void add_items(::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::Item>>> items) {
     fbb_.AddOffset(OrderBroadcast::VT_ITEMS, items);
   }
+  void add_aisles(::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<::flatbuffers::String>>> aisles) {
+    fbb_.AddOffset(OrderBroadcast::VT_AISLES, aisles);
+  }
+  void add_retry(int32_t retry) {
+    fbb_.AddElement<int32_t>(OrderBroadcast::VT_RETRY, retry, 0);
+  }
   explicit OrderBroadcastBuilder(::flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -157,8 +245,12 @@ inline ::flatbuffers::Offset<OrderBroadcast> CreateOrderBroadcast(
     ::flatbuffers::FlatBufferBuilder &_fbb,
     ::flatbuffers::Offset<::flatbuffers::String> order_id = 0,
     ::flatbuffers::Offset<::flatbuffers::String> order_type = 0,
-    ::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::Item>>> items = 0) {
+    ::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<RobotMessages::Item>>> items = 0,
+    ::flatbuffers::Offset<::flatbuffers::Vector<::flatbuffers::Offset<::flatbuffers::String>>> aisles = 0,
+    int32_t retry = 0) {
   OrderBroadcastBuilder builder_(_fbb);
+  builder_.add_retry(retry);
+  builder_.add_aisles(aisles);
   builder_.add_items(items);
   builder_.add_order_type(order_type);
   builder_.add_order_id(order_id);
@@ -169,15 +261,20 @@ inline ::flatbuffers::Offset<OrderBroadcast> CreateOrderBroadcastDirect(
     ::flatbuffers::FlatBufferBuilder &_fbb,
     const char *order_id = nullptr,
     const char *order_type = nullptr,
-    const std::vector<::flatbuffers::Offset<RobotMessages::Item>> *items = nullptr) {
+    const std::vector<::flatbuffers::Offset<RobotMessages::Item>> *items = nullptr,
+    const std::vector<::flatbuffers::Offset<::flatbuffers::String>> *aisles = nullptr,
+    int32_t retry = 0) {
   auto order_id__ = order_id ? _fbb.CreateString(order_id) : 0;
   auto order_type__ = order_type ? _fbb.CreateString(order_type) : 0;
   auto items__ = items ? _fbb.CreateVector<::flatbuffers::Offset<RobotMessages::Item>>(*items) : 0;
+  auto aisles__ = aisles ? _fbb.CreateVector<::flatbuffers::Offset<::flatbuffers::String>>(*aisles) : 0;
   return RobotMessages::CreateOrderBroadcast(
       _fbb,
       order_id__,
       order_type__,
-      items__);
+      items__,
+      aisles__,
+      retry);
 }
 
 inline const RobotMessages::OrderBroadcast *GetOrderBroadcast(const void *buf) {
@@ -2,10 +2,13 @@
 #include <string>
 #include <vector>
 #include <map>
+#include <deque>
+#include <unordered_set>
 #include <thread>
 #include <chrono>
 #include <cstdlib>
 #include <fstream>
+#include <unistd.h>
 #include <zmq.hpp>
 
 // Generated Flatbuffers headers
@@ -18,9 +21,15 @@
 using grpc::Channel;
 using grpc::ClientContext;
 using grpc::Status;
+using inventory::AcknowledgeDispatchRequest;
+using inventory::AcknowledgeDispatchResponse;
 using inventory::InventoryService;
+using inventory::RegisterRobotRequest;
+using inventory::RegisterRobotResponse;
 using inventory::ReportJobStatusRequest;
 using inventory::ReportJobStatusResponse;
+using inventory::RobotHeartbeatRequest;
+using inventory::RobotHeartbeatResponse;
 
 std::string GetEnv(const char* key, const std::string& fallback) {
     const char* value = std::getenv(key);
@@ -50,36 +59,64 @@ public:
     /**
      * @brief Constructs a robot worker bound to a single aisle.
      */
-    RobotWorker(std::shared_ptr<Channel> channel, std::string aisle, std::string zmqSubAddr)
-        : stub_(InventoryService::NewStub(channel)), aisle_type_(aisle), zmq_sub_addr_(zmqSubAddr) {}
+    RobotWorker(std::shared_ptr<Channel> channel, std::string robotId, std::string aisle, std::string zmqSubAddr)
+        : stub_(InventoryService::NewStub(channel)), robot_id_(robotId), aisle_type_(aisle), zmq_sub_addr_(zmqSubAddr) {}
 
     /**
      * @brief Starts the receive-filter-work-report processing loop.
      */
     void Run() {
+        int heartbeat_seconds = Register();
+        std::thread([this, heartbeat_seconds]() { HeartbeatLoop(heartbeat_seconds); }).detach();
+
         zmq::context_t context(1);
         zmq::socket_t subscriber(context, zmq::socket_type::sub);
         subscriber.connect(zmq_sub_addr_);
-        subscriber.set(zmq::sockopt::subscribe, ""); 
-        std::cout << "[robot] connected SUB socket to " << zmq_sub_addr_ << std::endl;
+        // Inventory publishes one message per aisle with the aisle name as the topic frame.
+        subscriber.set(zmq::sockopt::subscribe, aisle_type_);
+        std::cout << "[robot] connected SUB socket to " << zmq_sub_addr_ << " topic=" << aisle_type_ << std::endl;
 
         // Updated log message to only use aisle
         std::cout << "Robot started for aisle: " << aisle_type_ << std::endl;
 
         while (true) {
-            zmq::message_t msg;
-            auto res = subscriber.recv(msg, zmq::recv_flags::none);
+            zmq::message_t topic;
+            auto res = subscriber.recv(topic, zmq::recv_flags::none);
             if (!res) {
                 std::cout << "[robot] recv returned no message for aisle=" << aisle_type_ << std::endl;
                 continue;
             }
+            if (!topic.more()) {
+                std::cout << "[robot] dropping single-frame message for aisle=" << aisle_type_ << std::endl;
+                continue;
+            }
+            zmq::message_t msg;
+            res = subscriber.recv(msg, zmq::recv_flags::none);
+            if (!res) {
+                std::cout << "[robot] recv returned no payload for aisle=" << aisle_type_ << std::endl;
+                continue;
+            }
+            // SUB filtering is prefix-based, so "Dairy" would also match "DairyFrozen".
+            if (std::string(static_cast<const char*>(topic.data()), topic.size()) != aisle_type_) {
+                continue;
+            }
             std::cout << "[robot] raw broadcast received bytes=" << msg.size() << " aisle=" << aisle_type_ << std::endl;
             
             auto broadcast = RobotMessages::GetOrderBroadcast(msg.data());
 
             std::string order_type = broadcast->order_type()->str();
             std::string order_id = broadcast->order_id()->str();
-            std::cout << "Received " << order_type << " Job: " << order_id << std::endl;
+            int32_t retry = broadcast->retry();
+            std::cout << "Received " << order_type << " Job: " << order_id << " aisles_in_order=" << (broadcast->aisles() ? broadcast->aisles()->size() : 0) << std::endl;
+
+            // Ack first so inventory stops re-broadcasting while we work; redeliveries of a
+            // task we already handled are acknowledged again but not re-picked.
+            Acknowledge(order_id, order_type);
+            // A retry after a FAILED report is a new task even though the order is the same.
+            if (!RememberOrder(order_type + ":" + order_id + ":" + std::to_string(retry))) {
+                std::cout << "[robot] skipping redelivered task order=" << order_id << " aisle=" << aisle_type_ << std::endl;
+                continue;
+            }
             
             bool found_work = false;
             std::map<std::string, int32_t> processed_items;
@@ -91,6 +128,12 @@ public:
                 if (item->aisle()->str() == aisle_type_) {
                     found_work = true;
                     std::cout << "Picking " << item->quantity() << "x " << item->sku()->str() << std::endl;
+                    // Customer orders name the reserved lots so the oldest units leave the shelf first.
+                    if (item->lots()) {
+                        for (auto lot : *item->lots()) {
+                            std::cout << "[robot] take lot=" << lot->lot_id() << " qty=" << lot->quantity() << " sku=" << item->sku()->str() << std::endl;
+                        }
+                    }
                     processed_items[item->sku()->str()] = item->quantity();
                     std::cout << "[robot] start work sleep sku=" << item->sku()->str() << " order=" << order_id << std::endl;
                     
@@ -103,22 +146,111 @@ public:
                 std::cout << "[robot] no matching aisle work for order=" << order_id << " aisle=" << aisle_type_ << std::endl;
             }
 
-            ReportToInventory(order_id, order_type, found_work, processed_items);
+            ReportToInventory(order_id, order_type, retry, found_work, processed_items);
         }
     }
 
 private:
+    /**
+     * @brief Registers this robot with the inventory fleet, retrying until inventory answers.
+     * @return Heartbeat interval in seconds requested by inventory.
+     */
+    int Register() {
+        while (true) {
+            RegisterRobotRequest request;
+            RegisterRobotResponse response;
+            ClientContext context;
+
+            request.set_robot_id(robot_id_);
+            request.set_aisle(aisle_type_);
+
+            Status status = stub_->RegisterRobot(&context, request, &response);
+            if (status.ok() && response.success()) {
+                std::cout << "[robot] registered robot=" << robot_id_ << " aisle=" << aisle_type_
+                          << " heartbeat=" << response.heartbeat_interval_seconds() << "s" << std::endl;
+                return response.heartbeat_interval_seconds() > 0 ? response.heartbeat_interval_seconds() : 10;
+            }
+            std::cerr << "[robot] register failed robot=" << robot_id_ << " message=" << status.error_message() << std::endl;
+            std::this_thread::sleep_for(std::chrono::seconds(2));
+        }
+    }
+
+    /**
+     * @brief Sends periodic heartbeats and re-registers if inventory forgot this robot.
+     */
+    void HeartbeatLoop(int interval_seconds) {
+        while (true) {
+            std::this_thread::sleep_for(std::chrono::seconds(interval_seconds));
+
+            RobotHeartbeatRequest request;
+            RobotHeartbeatResponse response;
+            ClientContext context;
+
+            request.set_robot_id(robot_id_);
+            request.set_aisle(aisle_type_);
+
+            Status status = stub_->RobotHeartbeat(&context, request, &response);
+            if (!status.ok()) {
+                std::cerr << "[robot] heartbeat failed robot=" << robot_id_ << " message=" << status.error_message() << std::endl;
+                continue;
+            }
+            if (!response.registered()) {
+                std::cout << "[robot] heartbeat rejected, re-registering robot=" << robot_id_ << std::endl;
+                interval_seconds = Register();
+            }
+        }
+    }
+
+    /**
+     * @brief Tells inventory this robot received the order's task for its aisle.
+     */
+    void Acknowledge(const std::string& order_id, const std::string& order_type) {
+        AcknowledgeDispatchRequest request;
+        AcknowledgeDispatchResponse response;
+        ClientContext context;
+
+        request.set_order_id(order_id);
+        request.set_order_type(order_type);
+        request.set_aisle(aisle_type_);
+        request.set_robot_id(robot_id_);
+
+        Status status = stub_->AcknowledgeDispatch(&context, request, &response);
+        if (!status.ok()) {
+            std::cerr << "[robot] dispatch ack failed order=" << order_id << " code=" << status.error_code() << " message=" << status.error_message() << std::endl;
+        }
+    }
+
+    /**
+     * @brief Records a handled task key in a bounded window.
+     * @return False if the task was already handled.
+     */
+    bool RememberOrder(const std::string& key) {
+        if (!handled_orders_.insert(key).second) {
+            return false;
+        }
+        handled_order_log_.push_back(key);
+        if (handled_order_log_.size() > kHandledOrderWindow) {
+            handled_orders_.erase(handled_order_log_.front());
+            handled_order_log_.pop_front();
+        }
+        return true;
+    }
+
     /**
      * @brief Reports per-order processing status back to inventory via gRPC.
      */
-    void ReportToInventory(const std::string& order_id, const std::string& order_type, bool worked, const std::map<std::string, int32_t>& items) {
+    void ReportToInventory(const std::string& order_id, const std::string& order_type, int32_t retry, bool worked, const std::map<std::string, int32_t>& items) {
         ReportJobStatusRequest request;
         ReportJobStatusResponse response;
         ClientContext context;
 
         request.set_order_id(order_id);
         request.set_order_type(order_type);
+        request.set_robot_id(robot_id_);
+        request.set_aisle(aisle_type_);
+        request.set_job_status(worked ? inventory::JOB_STATUS_SUCCESS : inventory::JOB_STATUS_NO_OP);
         request.set_status(worked ? "SUCCESS" : "NO_OP");
+        request.set_retry(retry);
         std::cout << "[robot] reporting status order=" << order_id << " type=" << order_type << " status=" << request.status() << std::endl;
         
         auto* req_items = request.mutable_processed_items(); 
@@ -139,8 +271,13 @@ private:
     }
 
     std::unique_ptr<InventoryService::Stub> stub_;
+    std::string robot_id_;
     std::string aisle_type_;
     std::string zmq_sub_addr_;
+
+    static constexpr size_t kHandledOrderWindow = 1000;
+    std::unordered_set<std::string> handled_orders_;
+    std::deque<std::string> handled_order_log_;
 };
 
 int main(int argc, char** argv) {
@@ -158,8 +295,12 @@ int main(int argc, char** argv) {
 
     const std::string inventoryGrpcAddr = GetEnv("INVENTORY_GRPC_ADDR", "localhost:50051");
     const std::string robotZmqSubAddr = GetEnv("ROBOT_ZMQ_SUB_ADDR", "tcp://localhost:5556");
+
+    char host[256] = {0};
+    gethostname(host, sizeof(host) - 1);
+    const std::string robotId = GetEnv("ROBOT_ID", aisle + "-" + std::string(host) + "-" + std::to_string(getpid()));
     
-    RobotWorker robot(grpc::CreateChannel(inventoryGrpcAddr, grpc::InsecureChannelCredentials()), aisle, robotZmqSubAddr);
+    RobotWorker robot(grpc::CreateChannel(inventoryGrpcAddr, grpc::InsecureChannelCredentials()), robotId, aisle, robotZmqSubAddr);
     robot.Run();
 
     return 0;